@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
@@ -104,7 +105,7 @@ func TestMainIntegration(t *testing.T) {
 			}
 
 			// Run the creator (should work in dry-run mode)
-			err = prCreator.Run()
+			err = prCreator.Run(context.Background())
 			if err != nil {
 				t.Errorf("Unexpected error during creator run: %v", err)
 			}
@@ -219,7 +220,7 @@ func TestAssignmentDiscovery(t *testing.T) {
 
 			// Since we can't directly access findAssignments, we'll test through Run()
 			// In dry-run mode, this should discover assignments and simulate operations
-			err = prCreator.Run()
+			err = prCreator.Run(context.Background())
 			if err != nil {
 				t.Errorf("Unexpected error during assignment discovery: %v", err)
 			}
@@ -314,7 +315,7 @@ Absolute path (should not be changed):
 		t.Fatalf("Failed to create PR creator: %v", err)
 	}
 
-	err = prCreator.Run()
+	err = prCreator.Run(context.Background())
 	if err != nil {
 		t.Errorf("Unexpected error during PR creation with image rewriting: %v", err)
 	}
@@ -447,7 +448,7 @@ func TestComplexWorkflow(t *testing.T) {
 				t.Fatalf("Failed to create PR creator for %s: %v", tt.description, err)
 			}
 
-			err = prCreator.Run()
+			err = prCreator.Run(context.Background())
 			if err != nil {
 				t.Errorf("Failed to run complex workflow for %s: %v", tt.description, err)
 			}
@@ -502,7 +503,7 @@ func BenchmarkFullWorkflow(b *testing.B) {
 			b.Fatalf("Failed to create PR creator: %v", err)
 		}
 
-		err = prCreator.Run()
+		err = prCreator.Run(context.Background())
 		if err != nil {
 			b.Fatalf("Failed to run workflow: %v", err)
 		}
@@ -581,7 +582,7 @@ func TestBranchNameConflictValidation(t *testing.T) {
 	}
 
 	// This should fail due to branch name conflicts
-	err = prCreator.Run()
+	err = prCreator.Run(context.Background())
 	if err == nil {
 		t.Errorf("Expected error due to branch name conflicts, but got none")
 		return
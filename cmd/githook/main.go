@@ -80,9 +80,11 @@ func processAssignmentBranch(currentBranch string, patterns *workflow.WorkflowPa
 		assignmentPatterns = []string{constants.DefaultAssignmentRegex}
 	}
 
-		// Compile regex patterns using the regex processor
-	rootProcessor := regex.NewPatternProcessorWithPatterns(rootPatterns)
-	assignmentProcessor := regex.NewPatternProcessorWithPatterns(assignmentPatterns)
+		// Compile regex patterns using regex.Processor, which -- unlike the legacy
+		// PatternProcessor -- strips a "regex:" tag prefix before compiling, so a tagged
+		// pattern like constants.DefaultAssignmentRegex actually matches.
+	rootProcessor := regex.NewWithPatterns(rootPatterns)
+	assignmentProcessor := regex.NewWithPatterns(assignmentPatterns)
 
 	// Find all assignment folders using assignment package
 	processor, err := assignment.NewAssignmentProcessor("", rootProcessor, assignmentProcessor)
@@ -1,12 +1,17 @@
 package assignment
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
+	texttemplate "text/template"
+	"text/template/parse"
 
 	"assignment-pull-request/internal/regex"
 )
@@ -15,12 +20,76 @@ import (
 type Info struct {
 	Path       string
 	BranchName string
+
+	// Override carries the per-assignment settings resolved via Processor.Overrides, if
+	// configured -- zero-valued when no override applied.
+	Override Override
+}
+
+// Override holds the per-assignment settings a Processor.Overrides resolver can customize on top
+// of the action's usual defaults, e.g. from a checked-in per-assignment config file.
+type Override struct {
+	// BranchTemplate, if set, takes precedence over the pattern's own branch-name template
+	// (and BRANCH_TEMPLATE) when rendering this assignment's branch name.
+	BranchTemplate string
+	// PRTitle, if set, replaces the branch name as the pull request title.
+	PRTitle string
+	// BaseBranch, if set, replaces the default branch as the pull request's base.
+	BaseBranch string
+	// Skip, if true, excludes the assignment from processing entirely.
+	Skip bool
+	// Reviewers, if set, are requested as reviewers on the assignment's pull request.
+	Reviewers []string
+	// TeamReviewers, if set, are requested as team reviewers on the assignment's pull request.
+	TeamReviewers []string
+	// Labels, if set, are applied to the assignment's pull request.
+	Labels []string
+	// Assignees, if set, are assigned to the assignment's pull request.
+	Assignees []string
+	// Milestone, if set, is assigned to the assignment's pull request.
+	Milestone string
+	// Draft, if true, opens the assignment's pull request as a draft.
+	Draft bool
+}
+
+// OverrideResolver resolves the Override settings for an assignment path, e.g. from a checked-in
+// per-assignment config file (see creator.assignmentOverridesFile). Resolve is called once per
+// discovered assignment during scanAssignments.
+type OverrideResolver interface {
+	Resolve(assignmentPath string) Override
 }
 
 // Processor handles assignment discovery and processing
 type Processor struct {
 	repositoryRoot    string
 	assignmentPattern *regex.Processor
+
+	// MaxWorkers bounds the worker pool used to match candidate directories against the
+	// assignment patterns concurrently. Defaults to runtime.NumCPU().
+	MaxWorkers int
+
+	// IgnorePatterns are additional gitignore-style patterns applied on top of any .gitignore
+	// and .assignmentignore files found in the repository
+	IgnorePatterns []string
+
+	// ExcludePattern, if set, prunes any candidate directory whose path matches it before the
+	// directory is tested against assignmentPattern
+	ExcludePattern *regex.ExcludeMatcher
+
+	// RootExcludePattern, if set, prunes an entire top-level root folder by name before it's
+	// walked, ahead of ExcludePattern and assignmentPattern
+	RootExcludePattern *regex.ExcludeMatcher
+
+	// Overrides, if set, resolves per-assignment settings (custom branch template, PR title,
+	// base branch, skip flag, reviewers, labels, milestone) applied on top of every discovered
+	// assignment's Info
+	Overrides OverrideResolver
+
+	// scanOnce memoizes the full assignment scan so it runs at most once per Processor
+	// instance, however many times ProcessAssignments/ProcessAssignmentsContext is called
+	scanOnce   sync.Once
+	scanResult []Info
+	scanErr    error
 }
 
 // NewProcessor creates a new Processor with assignment regex patterns
@@ -31,23 +100,61 @@ func NewProcessor(repositoryRoot string, assignmentProcessor *regex.Processor) (
 		return nil, fmt.Errorf("failed to compile assignment patterns: %w", err)
 	}
 
-	// Validate that assignment patterns have capturing groups
-	for _, pattern := range assignmentPatterns {
+	templates := assignmentProcessor.Templates()
+	bodyTemplates := assignmentProcessor.BodyTemplates()
+	defaultTemplate := assignmentProcessor.DefaultTemplate()
+
+	// Validate that assignment patterns have capturing groups, and that any configured
+	// branch-name/PR-body templates only reference groups the pattern actually captures --
+	// catching a typo'd {{.cours}} at construction time instead of silently rendering it blank
+	// (or, with missingkey=error, failing) the first time a matching assignment is found. A
+	// pattern with no per-pattern branch template is checked against defaultTemplate instead, so
+	// a BRANCH_TEMPLATE referencing a group one pattern doesn't capture is also caught here.
+	for i, pattern := range assignmentPatterns {
 		if !hasCapturingGroups(pattern) {
 			return nil, fmt.Errorf("assignment regex '%s' must contain at least one capturing group (e.g., (?P<name>...) or (...)) to extract branch names", pattern.String())
 		}
+		branchTemplate := defaultTemplate
+		if i < len(templates) && templates[i] != "" {
+			branchTemplate = templates[i]
+		}
+		if err := validateTemplateGroups(pattern, branchTemplate); err != nil {
+			return nil, fmt.Errorf("branch name template for pattern '%s': %w", pattern.String(), err)
+		}
+		if i < len(bodyTemplates) {
+			if err := validateTemplateGroups(pattern, bodyTemplates[i]); err != nil {
+				return nil, fmt.Errorf("PR body template for pattern '%s': %w", pattern.String(), err)
+			}
+		}
 	}
 
 	return &Processor{
 		repositoryRoot:    repositoryRoot,
 		assignmentPattern: assignmentProcessor,
+		MaxWorkers:        runtime.NumCPU(),
 	}, nil
 }
 
-// ProcessAssignments discovers all assignments and returns assignment info with unique branch names
+// ProcessAssignments discovers all assignments and returns assignment info with unique branch
+// names. The underlying scan is memoized: repeated calls on the same Processor return the
+// cached result without re-scanning the repository.
 func (ap *Processor) ProcessAssignments() ([]Info, error) {
-	// Find all assignment paths
-	assignments, err := ap.findAssignments()
+	return ap.ProcessAssignmentsContext(context.Background())
+}
+
+// ProcessAssignmentsContext is ProcessAssignments with support for cancelling a long-running
+// scan via ctx. Like ProcessAssignments, the scan is memoized per Processor instance; ctx is
+// only consulted on the call that actually triggers the scan.
+func (ap *Processor) ProcessAssignmentsContext(ctx context.Context) ([]Info, error) {
+	ap.scanOnce.Do(func() {
+		ap.scanResult, ap.scanErr = ap.scanAssignments(ctx)
+	})
+	return ap.scanResult, ap.scanErr
+}
+
+// scanAssignments finds all assignment paths and derives their unique branch names
+func (ap *Processor) scanAssignments(ctx context.Context) ([]Info, error) {
+	assignments, err := ap.findAssignments(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error finding assignments: %w", err)
 	}
@@ -60,7 +167,15 @@ func (ap *Processor) ProcessAssignments() ([]Info, error) {
 	branchCounts := make(map[string]int)
 
 	for _, assignment := range assignments {
-		branchName, found := ap.extractBranchNameFromPath(assignment)
+		var override Override
+		if ap.Overrides != nil {
+			override = ap.Overrides.Resolve(assignment)
+			if override.Skip {
+				continue
+			}
+		}
+
+		branchName, found := ap.extractBranchNameFromPath(assignment, override.BranchTemplate)
 		if found {
 			branchCounts[branchName]++
 			uniqueBranchName := branchName
@@ -70,8 +185,9 @@ func (ap *Processor) ProcessAssignments() ([]Info, error) {
 			results = append(results, Info{
 				Path:       assignment,
 				BranchName: uniqueBranchName,
+				Override:   override,
 			})
-		} 
+		}
 	}
 
 	return results, nil
@@ -101,10 +217,13 @@ func (ap *Processor) validateBranchNameUniqueness(assignments []Info) error {
 	return nil
 }
 
-// findAssignments finds all assignment folders matching the processor's regex patterns
-func (ap *Processor) findAssignments() ([]string, error) {
+// findAssignments finds all assignment folders matching the processor's regex patterns. The
+// immediate subdirectories of the repository root are treated as independent units of work and
+// scanned concurrently by a bounded pool of MaxWorkers goroutines; within each unit, candidate
+// directories are collected serially (pruning ignored subtrees) and matched against the
+// compiled patterns. The scan can be cancelled early via ctx.
+func (ap *Processor) findAssignments(ctx context.Context) ([]string, error) {
 	fmt.Printf("📁 Searching for assignment folders...\n")
-	var assignments []string
 
 	// Determine the root directory to walk
 	rootDir := ap.repositoryRoot
@@ -118,18 +237,180 @@ func (ap *Processor) findAssignments() ([]string, error) {
 		return nil, fmt.Errorf("failed to compile assignment patterns: %w", err)
 	}
 
-	checkedDirs := 0
-	matchedDirs := 0
+	baseMatcher := &ignoreMatcher{}
+	baseMatcher = baseMatcher.withRules(loadIgnoreFile(filepath.Join(rootDir, ".gitignore")))
+	baseMatcher = baseMatcher.withRules(loadIgnoreFile(filepath.Join(rootDir, ".assignmentignore")))
+	baseMatcher = baseMatcher.withRules(parseIgnoreLines(ap.IgnorePatterns))
+
+	folders, err := ap.topLevelFolders(rootDir, baseMatcher)
+	if err != nil {
+		return nil, fmt.Errorf("error finding assignments: %w", err)
+	}
+
+	assignments, err := ap.scanRootFoldersConcurrently(ctx, rootDir, folders, baseMatcher, assignmentPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(assignments)
+
+	return assignments, nil
+}
+
+// topLevelFolders returns the immediate, non-hidden, non-ignored subdirectories of rootDir that
+// assignmentPattern could still match something beneath — the unit of work fanned out across the
+// worker pool in scanRootFoldersConcurrently
+func (ap *Processor) topLevelFolders(rootDir string, baseMatcher *ignoreMatcher) ([]string, error) {
+	entries, err := os.ReadDir(rootDir)
+	if err != nil {
+		return nil, err
+	}
 
-	// Walk the entire directory tree and check each directory against assignment patterns
-	err = filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+	var folders []string
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if baseMatcher.matches(entry.Name(), true) {
+			continue
+		}
+		if ap.RootExcludePattern.Excludes(entry.Name()) {
+			continue
+		}
+		if !ap.assignmentPattern.ChildMayMatch(entry.Name()) {
+			continue
+		}
+		folders = append(folders, entry.Name())
+	}
+
+	return folders, nil
+}
+
+// scanRootFoldersConcurrently fans out one scan per top-level folder across a pool of
+// MaxWorkers (defaulting to GOMAXPROCS) goroutines, collecting matches on a results channel and
+// aggregating any per-folder failures into a *MultiError
+func (ap *Processor) scanRootFoldersConcurrently(ctx context.Context, rootDir string, folders []string, baseMatcher *ignoreMatcher, patterns []*regexp.Regexp) ([]string, error) {
+	maxWorkers := ap.MaxWorkers
+	if maxWorkers <= 0 {
+		maxWorkers = runtime.GOMAXPROCS(0)
+	}
+
+	jobs := make(chan string)
+	results := make(chan string)
+	errorsCh := make(chan error)
+
+	var wg sync.WaitGroup
+	for i := 0; i < maxWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for folder := range jobs {
+				matches, err := ap.scanRootFolder(ctx, rootDir, folder, baseMatcher, patterns)
+				if err != nil {
+					errorsCh <- fmt.Errorf("scanning folder '%s': %w", folder, err)
+					continue
+				}
+				for _, match := range matches {
+					results <- match
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for _, folder := range folders {
+			jobs <- folder
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+		close(errorsCh)
+	}()
+
+	var assignments []string
+	var multiErr MultiError
+	resultsOpen, errorsOpen := true, true
+	for resultsOpen || errorsOpen {
+		select {
+		case match, ok := <-results:
+			if !ok {
+				resultsOpen = false
+				continue
+			}
+			assignments = append(assignments, match)
+		case err, ok := <-errorsCh:
+			if !ok {
+				errorsOpen = false
+				continue
+			}
+			multiErr.Errors = append(multiErr.Errors, err)
+		}
+	}
+
+	if len(multiErr.Errors) > 0 {
+		return assignments, &multiErr
+	}
+	return assignments, nil
+}
+
+// scanRootFolder collects candidate directories under a single top-level folder, pruning
+// ignored subtrees, and matches them against patterns, checking ctx between directories so a
+// cancellation doesn't have to wait for the whole folder to finish
+func (ap *Processor) scanRootFolder(ctx context.Context, rootDir, folder string, baseMatcher *ignoreMatcher, patterns []*regexp.Regexp) ([]string, error) {
+	folderPath := filepath.Join(rootDir, folder)
+
+	dirs, err := ap.collectCandidateDirs(folderPath, rootDir, baseMatcher)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []string
+	for _, dir := range dirs {
+		select {
+		case <-ctx.Done():
+			return matches, ctx.Err()
+		default:
+		}
+
+		relativePath, err := filepath.Rel(rootDir, dir)
+		if err != nil {
+			continue
+		}
+		relativeNormalizedPath := filepath.ToSlash(relativePath)
+
+		for _, pattern := range patterns {
+			if pattern.MatchString(relativeNormalizedPath) {
+				matches = append(matches, dir)
+				break
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// collectCandidateDirs walks walkDir serially, skipping hidden directories and anything excluded
+// by baseMatcher, a descendant's own .gitignore, ap.ExcludePattern, or assignmentPattern's own
+// ChildMayMatch pruning, and returns walkDir itself plus every remaining descendant directory as
+// a candidate for pattern matching.
+// Relative paths for ignore matching are computed against repoRoot so rules from the
+// repository's top-level .gitignore/.assignmentignore apply consistently regardless of which
+// folder is being walked.
+func (ap *Processor) collectCandidateDirs(walkDir, repoRoot string, baseMatcher *ignoreMatcher) ([]string, error) {
+	matcherByDir := map[string]*ignoreMatcher{filepath.Dir(walkDir): baseMatcher}
+
+	var dirs []string
+	err := filepath.Walk(walkDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Skip hidden directories and files (but not the current directory ".")
+		// Skip hidden directories and files (but not walkDir itself)
 		baseName := filepath.Base(path)
-		if strings.HasPrefix(baseName, ".") && path != "." && path != rootDir {
+		if strings.HasPrefix(baseName, ".") && path != walkDir {
 			if info.IsDir() {
 				return filepath.SkipDir
 			}
@@ -141,41 +422,35 @@ func (ap *Processor) findAssignments() ([]string, error) {
 			return nil
 		}
 
-		// Skip the root directory itself
-		if path == rootDir {
-			return nil
+		matcher := matcherByDir[filepath.Dir(path)]
+		if matcher == nil {
+			matcher = baseMatcher
 		}
 
-		checkedDirs++
-
-		// Convert absolute path to relative path from repository root
-		relativePath, err := filepath.Rel(rootDir, path)
-		if err != nil {
+		relativePath, relErr := filepath.Rel(repoRoot, path)
+		if relErr != nil {
 			return nil
 		}
-
-		// Use the relative path for pattern matching
 		relativeNormalizedPath := filepath.ToSlash(relativePath)
 
-		for _, assignmentPattern := range assignmentPatterns {
-			if assignmentPattern.MatchString(relativeNormalizedPath) {
-				assignments = append(assignments, path)
-				matchedDirs++
-				break // Don't check other patterns for this path
-			} 
+		if matcher.matches(relativeNormalizedPath, true) {
+			return filepath.SkipDir
+		}
+		if ap.ExcludePattern.Excludes(relativeNormalizedPath) {
+			return filepath.SkipDir
+		}
+		if !ap.assignmentPattern.ChildMayMatch(relativeNormalizedPath) {
+			return filepath.SkipDir
 		}
 
+		// Descendants inherit this directory's own .gitignore rules on top of its parent's
+		matcherByDir[path] = matcher.withRules(loadIgnoreFile(filepath.Join(path, ".gitignore")))
+
+		dirs = append(dirs, path)
 		return nil
 	})
 
-	if err != nil {
-		return nil, fmt.Errorf("error finding assignments: %w", err)
-	}
-
-	// Sort assignments
-	sort.Strings(assignments)
-
-	return assignments, nil
+	return dirs, err
 }
 
 // GetAssignmentRegexStrings returns the assignment regex patterns as strings
@@ -183,14 +458,35 @@ func (ap *Processor) GetAssignmentRegexStrings() []string {
 	return ap.assignmentPattern.Patterns()
 }
 
-// extractBranchNameFromPath extracts a branch name from a path using the processor's compiled patterns
-func (ap *Processor) extractBranchNameFromPath(assignmentPath string) (string, bool) {
+// ParseRegexPatterns parses a comma-separated string of regex patterns into a slice, recognizing
+// the same escaped-comma syntax as regex.ParseCommaSeparated plus an optional
+// "pattern => template" suffix on each entry (see regex.ParseRegexPatterns); only the pattern
+// half is returned here, so callers that don't care about per-pattern branch-name templates can
+// keep working with plain pattern strings.
+func ParseRegexPatterns(patterns string) []string {
+	parsed := regex.ParseRegexPatterns(patterns)
+	result := make([]string, len(parsed))
+	for i, pt := range parsed {
+		result[i] = pt.Pattern
+	}
+	return result
+}
+
+// extractBranchNameFromPath extracts a branch name from a path using the processor's compiled
+// patterns. overrideTemplate, if non-empty (see Override.BranchTemplate), takes precedence over
+// everything else. Otherwise, a pattern paired with its own template (via the "pattern =>
+// template" config syntax, see regex.ParseRegexPatterns) renders that template; lacking one, it
+// falls back to the processor's DefaultTemplate (e.g. BRANCH_TEMPLATE), and only when neither is
+// set does it fall back further to the alphabetical auto-join below.
+func (ap *Processor) extractBranchNameFromPath(assignmentPath, overrideTemplate string) (string, bool) {
 
 	assignmentPatterns, err := ap.assignmentPattern.Compiled()
 	if err != nil {
 		fmt.Printf("    Error: Failed to compile patterns: %v\n", err)
 		return "", false
 	}
+	templates := ap.assignmentPattern.Templates()
+	defaultTemplate := ap.assignmentPattern.DefaultTemplate()
 
 	// Convert absolute path to relative path from repository root
 	relativePath, err := filepath.Rel(ap.repositoryRoot, assignmentPath)
@@ -202,72 +498,299 @@ func (ap *Processor) extractBranchNameFromPath(assignmentPath string) (string, b
 	// Normalize path to use forward slashes for pattern matching
 	normalizedPath := filepath.ToSlash(relativePath)
 
+	for i, pattern := range assignmentPatterns {
+		if pattern == nil {
+			continue
+		}
+
+		template := defaultTemplate
+		if i < len(templates) && templates[i] != "" {
+			template = templates[i]
+		}
+		if overrideTemplate != "" {
+			template = overrideTemplate
+		}
+
+		if template != "" {
+			branchName, ok := renderTemplateFromMatch(pattern, template, normalizedPath)
+			if !ok {
+				continue
+			}
+			return SanitizeBranchName(branchName), true
+		}
+
+		if branchName, ok := autoJoinBranchName(pattern, normalizedPath); ok {
+			return branchName, true
+		}
+	}
+
+	return "", false
+}
+
+// ExtractBodyFromPath renders the PR-body template paired (via AddPatternConfigs) with the first
+// compiled pattern that both has a body template and matches assignmentPath; patterns without a
+// body template are skipped. It returns false if no such pattern exists -- callers should fall
+// back to their own default PR body content in that case (see Creator.createPullRequestBody).
+func (ap *Processor) ExtractBodyFromPath(assignmentPath string) (string, bool) {
+	assignmentPatterns, err := ap.assignmentPattern.Compiled()
+	if err != nil {
+		return "", false
+	}
+	bodyTemplates := ap.assignmentPattern.BodyTemplates()
+
+	relativePath, err := filepath.Rel(ap.repositoryRoot, assignmentPath)
+	if err != nil {
+		return "", false
+	}
+	normalizedPath := filepath.ToSlash(relativePath)
+
+	for i, pattern := range assignmentPatterns {
+		if pattern == nil || i >= len(bodyTemplates) || bodyTemplates[i] == "" {
+			continue
+		}
+		if !pattern.MatchString(normalizedPath) {
+			continue
+		}
+		if body, ok := renderTemplateFromMatch(pattern, bodyTemplates[i], normalizedPath); ok {
+			return body, true
+		}
+	}
+
+	return "", false
+}
+
+// MatchGroupsForPath returns the named capture groups (see MatchGroups) of the first compiled
+// pattern that matches assignmentPath, for callers rendering their own template against an
+// assignment's captures -- e.g. creator.Creator's PR_TITLE_TEMPLATE/COMMIT_MESSAGE_TEMPLATE --
+// instead of one of the branch-name/PR-body templates the Processor already renders itself.
+func (ap *Processor) MatchGroupsForPath(assignmentPath string) (map[string]string, bool) {
+	assignmentPatterns, err := ap.assignmentPattern.Compiled()
+	if err != nil {
+		return nil, false
+	}
+
+	relativePath, err := filepath.Rel(ap.repositoryRoot, assignmentPath)
+	if err != nil {
+		return nil, false
+	}
+	normalizedPath := filepath.ToSlash(relativePath)
+
 	for _, pattern := range assignmentPatterns {
 		if pattern == nil {
 			continue
 		}
+		if groups, ok := MatchGroups(pattern, normalizedPath); ok {
+			return groups, true
+		}
+	}
+	return nil, false
+}
+
+// ExtractBranchNameFromPath matches path against each of patterns in turn, returning the branch
+// name produced by the first one that matches: named capture groups joined alphabetically by
+// name, followed by any unnamed groups in match order (see SanitizeBranchName for the exact
+// naming rules applied to the joined result). A pattern that fails to compile is skipped. This
+// is the alphabetical auto-join fallback; callers wanting control over the output order should
+// use ExtractBranchNameWithTemplate instead.
+func ExtractBranchNameFromPath(path string, patterns []string) (string, bool) {
+	for _, pattern := range patterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		if branchName, ok := autoJoinBranchName(compiled, path); ok {
+			return branchName, true
+		}
+	}
+	return "", false
+}
 
-		matches := pattern.FindStringSubmatch(normalizedPath)
-		if matches != nil {
-			names := pattern.SubexpNames()
-			var branchParts []string
+// autoJoinBranchName matches path against pattern and, on a match, joins its named capture
+// groups alphabetically by name followed by any unnamed groups in match order, returning the
+// sanitized result. It returns false if pattern doesn't match path or the match yields no
+// non-empty capture groups at all.
+func autoJoinBranchName(pattern *regexp.Regexp, path string) (string, bool) {
+	matches := pattern.FindStringSubmatch(path)
+	if matches == nil {
+		return "", false
+	}
 
-			// Collect named groups and their values, sorted alphabetically by name
-			namedGroups := make(map[string]string)
-			var namedGroupNames []string
+	names := pattern.SubexpNames()
+	var branchParts []string
 
-			for i, name := range names {
-				if name != "" && i < len(matches) && matches[i] != "" {
-					part := strings.TrimSpace(matches[i])
-					if part != "" {
-						namedGroups[name] = part
-						namedGroupNames = append(namedGroupNames, name)
-					}
-				}
-			}
+	// Collect named groups and their values, sorted alphabetically by name
+	namedGroups := make(map[string]string)
+	var namedGroupNames []string
 
-			// Sort named group names alphabetically
-			if len(namedGroupNames) > 0 {
-				sort.Strings(namedGroupNames)
-				// Add named groups in alphabetical order
-				for _, name := range namedGroupNames {
-					branchParts = append(branchParts, namedGroups[name])
-				}
+	for i, name := range names {
+		if name != "" && i < len(matches) && matches[i] != "" {
+			part := strings.TrimSpace(matches[i])
+			if part != "" {
+				namedGroups[name] = part
+				namedGroupNames = append(namedGroupNames, name)
 			}
+		}
+	}
 
-			// Collect unnamed groups in order of appearance
-			var unnamedParts []string
-			for i := 1; i < len(matches); i++ { // Skip index 0 (full match)
-				// Skip if this index corresponds to a named group
-				isNamedGroup := false
-				if i < len(names) && names[i] != "" {
-					isNamedGroup = true
-				}
+	// Sort named group names alphabetically
+	if len(namedGroupNames) > 0 {
+		sort.Strings(namedGroupNames)
+		// Add named groups in alphabetical order
+		for _, name := range namedGroupNames {
+			branchParts = append(branchParts, namedGroups[name])
+		}
+	}
 
-				if !isNamedGroup && matches[i] != "" {
-					part := strings.TrimSpace(matches[i])
-					if part != "" {
-						unnamedParts = append(unnamedParts, part)
-					}
-				}
+	// Collect unnamed groups in order of appearance
+	var unnamedParts []string
+	for i := 1; i < len(matches); i++ { // Skip index 0 (full match)
+		// Skip if this index corresponds to a named group
+		isNamedGroup := false
+		if i < len(names) && names[i] != "" {
+			isNamedGroup = true
+		}
+
+		if !isNamedGroup && matches[i] != "" {
+			part := strings.TrimSpace(matches[i])
+			if part != "" {
+				unnamedParts = append(unnamedParts, part)
 			}
+		}
+	}
 
-			// Add unnamed groups after named groups
-			branchParts = append(branchParts, unnamedParts...)
+	// Add unnamed groups after named groups
+	branchParts = append(branchParts, unnamedParts...)
 
-			if len(branchParts) == 0 {
-				continue
-			}
+	if len(branchParts) == 0 {
+		return "", false
+	}
 
-			// Combine parts and sanitize
-			branchName := strings.Join(branchParts, "-")
-			branchName = ap.sanitizeBranchName(branchName)
+	return SanitizeBranchName(strings.Join(branchParts, "-")), true
+}
 
-			return branchName, true
+// ExtractBranchNameWithTemplate matches path against pattern and renders template (a
+// text/template string referencing pattern's named capture groups, e.g.
+// "{{.course}}-hw{{.number}}") from their matched values. It returns false if pattern fails to
+// compile, doesn't match path, or template fails to parse or execute, letting the caller fall
+// back to extractBranchNameFromPath's alphabetical auto-join instead.
+func ExtractBranchNameWithTemplate(path, pattern, template string) (string, bool) {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", false
+	}
+
+	branchName, ok := renderTemplateFromMatch(compiled, template, path)
+	if !ok {
+		return "", false
+	}
+	return SanitizeBranchName(branchName), true
+}
+
+// renderTemplateFromMatch matches path against pattern and renders template from the named
+// capture groups it finds, returning false without sanitizing if anything along the way fails.
+func renderTemplateFromMatch(pattern *regexp.Regexp, template, path string) (string, bool) {
+	groups, ok := MatchGroups(pattern, path)
+	if !ok {
+		return "", false
+	}
+
+	rendered, err := RenderTemplate(template, groups)
+	if err != nil {
+		return "", false
+	}
+
+	return rendered, true
+}
+
+// MatchGroups matches path against pattern and returns its named capture groups as a map keyed by
+// group name, or false if pattern doesn't match path at all. Unnamed groups aren't included, since
+// a text/template referencing them by name has nothing to bind to.
+func MatchGroups(pattern *regexp.Regexp, path string) (map[string]string, bool) {
+	matches := pattern.FindStringSubmatch(path)
+	if matches == nil {
+		return nil, false
+	}
+
+	groups := make(map[string]string)
+	for i, name := range pattern.SubexpNames() {
+		if name != "" && i < len(matches) {
+			groups[name] = matches[i]
 		}
 	}
+	return groups, true
+}
 
-	return "", false
+// RenderTemplate parses and executes tmpl, a text/template string (e.g. "{{.course}}-wk{{.week}}")
+// against vars, reporting an error if tmpl fails to parse, references a key vars doesn't have, or
+// fails to execute -- callers that already validated tmpl's fields against the matching pattern's
+// capture groups (see validateTemplateGroups) shouldn't normally hit the undefined-key case.
+func RenderTemplate(tmpl string, vars map[string]string) (string, error) {
+	parsed, err := texttemplate.New("template").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid template %q: %w", tmpl, err)
+	}
+
+	var rendered strings.Builder
+	if err := parsed.Execute(&rendered, vars); err != nil {
+		return "", fmt.Errorf("error rendering template %q: %w", tmpl, err)
+	}
+	return rendered.String(), nil
+}
+
+// validateTemplateGroups parses template (if non-empty) and reports an error if it references a
+// field, e.g. "{{.course}}", that isn't one of pattern's named capture groups -- letting NewProcessor
+// reject a typo'd template at construction time rather than having it silently render blank (or
+// fail) the first time a matching assignment is actually processed. Only plain field references
+// are checked; a template using pipelines, conditionals, or functions is left to fail at render
+// time instead, same as before this validation existed.
+func validateTemplateGroups(pattern *regexp.Regexp, template string) error {
+	if template == "" {
+		return nil
+	}
+
+	tmpl, err := texttemplate.New("validate").Parse(template)
+	if err != nil {
+		return fmt.Errorf("invalid template '%s': %w", template, err)
+	}
+
+	groups := make(map[string]bool)
+	for _, name := range pattern.SubexpNames() {
+		if name != "" {
+			groups[name] = true
+		}
+	}
+
+	return findUndefinedField(tmpl.Root, groups, template)
+}
+
+// findUndefinedField walks the parsed template nodes reachable from a plain "{{.field}}" action,
+// returning an error naming the first field referenced that isn't in groups
+func findUndefinedField(node parse.Node, groups map[string]bool, template string) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *parse.ListNode:
+		for _, child := range n.Nodes {
+			if err := findUndefinedField(child, groups, template); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return findUndefinedField(n.Pipe, groups, template)
+	case *parse.PipeNode:
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				if err := findUndefinedField(arg, groups, template); err != nil {
+					return err
+				}
+			}
+		}
+	case *parse.FieldNode:
+		if len(n.Ident) > 0 && !groups[n.Ident[0]] {
+			return fmt.Errorf("references undefined capture group %q in %q", n.Ident[0], template)
+		}
+	}
+	return nil
 }
 
 // hasCapturingGroups checks if a compiled regex pattern has at least one capturing group (named or unnamed)
@@ -278,9 +801,9 @@ func hasCapturingGroups(regex *regexp.Regexp) bool {
 	return len(names) > 1
 }
 
-// sanitizeBranchName sanitizes a branch name to match Creator's original behavior
+// SanitizeBranchName sanitizes a branch name to match Creator's original behavior
 // Only sanitizes spaces and slashes, preserves other special characters
-func (ap *Processor) sanitizeBranchName(name string) string {
+func SanitizeBranchName(name string) string {
 	// Remove leading/trailing whitespace
 	branchName := strings.TrimSpace(name)
 
@@ -301,3 +824,23 @@ func (ap *Processor) sanitizeBranchName(name string) string {
 
 	return branchName
 }
+
+// MultiError aggregates the independent failures encountered while scanning top-level folders
+// concurrently in scanRootFoldersConcurrently
+type MultiError struct {
+	Errors []error
+}
+
+// Error joins the individual error messages, one per line
+func (m *MultiError) Error() string {
+	messages := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d error(s) occurred while scanning for assignments:\n  %s", len(m.Errors), strings.Join(messages, "\n  "))
+}
+
+// Unwrap exposes the individual errors for errors.Is/errors.As
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
@@ -0,0 +1,228 @@
+package assignment
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"assignment-pull-request/internal/regex"
+)
+
+// buildSyntheticTree creates numCourses top-level folders, each with weeksPerCourse
+// subdirectories containing one assignment-N directory, for a total of
+// numCourses*weeksPerCourse folders — enough to exercise the per-root-folder worker pool on a
+// tree north of 1k directories
+func buildSyntheticTree(b *testing.B, numCourses, weeksPerCourse int) string {
+	b.Helper()
+	root := b.TempDir()
+
+	for course := 0; course < numCourses; course++ {
+		for week := 0; week < weeksPerCourse; week++ {
+			dir := filepath.Join(root, fmt.Sprintf("course-%d", course), fmt.Sprintf("week-%d", week), fmt.Sprintf("assignment-%d", week))
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				b.Fatalf("failed to create synthetic tree: %v", err)
+			}
+		}
+	}
+
+	return root
+}
+
+// BenchmarkProcessAssignments measures the cost of a full, uncached scan over a synthetic tree
+// of 1k+ assignment folders
+func BenchmarkProcessAssignments(b *testing.B) {
+	root := buildSyntheticTree(b, 50, 25) // 1,250 assignment folders
+
+	pattern := regex.NewWithPatterns([]string{`^course-\d+/week-\d+/(?P<assignment>assignment-\d+)$`})
+
+	for i := 0; i < b.N; i++ {
+		processor, err := NewProcessor(root, pattern)
+		if err != nil {
+			b.Fatalf("failed to create processor: %v", err)
+		}
+
+		if _, err := processor.ProcessAssignments(); err != nil {
+			b.Fatalf("ProcessAssignments failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkProcessAssignmentsMemoized measures the cost of repeated calls on the same Processor,
+// which should be dominated by the first call since subsequent calls hit the memoized result
+func BenchmarkProcessAssignmentsMemoized(b *testing.B) {
+	root := buildSyntheticTree(b, 50, 25)
+
+	pattern := regex.NewWithPatterns([]string{`^course-\d+/week-\d+/(?P<assignment>assignment-\d+)$`})
+	processor, err := NewProcessor(root, pattern)
+	if err != nil {
+		b.Fatalf("failed to create processor: %v", err)
+	}
+
+	// Prime the memoized scan once before timing repeated calls
+	if _, err := processor.ProcessAssignments(); err != nil {
+		b.Fatalf("ProcessAssignments failed: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := processor.ProcessAssignments(); err != nil {
+			b.Fatalf("ProcessAssignments failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkProcessAssignmentsSingleWorker isolates the benefit of the per-root-folder worker
+// pool by comparing against MaxWorkers=1 on the same synthetic tree
+func BenchmarkProcessAssignmentsSingleWorker(b *testing.B) {
+	root := buildSyntheticTree(b, 50, 25)
+
+	pattern := regex.NewWithPatterns([]string{`^course-\d+/week-\d+/(?P<assignment>assignment-\d+)$`})
+
+	for i := 0; i < b.N; i++ {
+		processor, err := NewProcessor(root, pattern)
+		if err != nil {
+			b.Fatalf("failed to create processor: %v", err)
+		}
+		processor.MaxWorkers = 1
+
+		if _, err := processor.ProcessAssignmentsContext(context.Background()); err != nil {
+			b.Fatalf("ProcessAssignmentsContext failed: %v", err)
+		}
+	}
+}
+
+// buildPruningSyntheticTree creates one "assignments/target/leaf" directory -- the only path
+// "assignments/target/{name}" matches -- alongside numBuckets non-matching sibling subtrees,
+// each depth levels deep, for roughly numBuckets*(depth+1) total directories. Since every bucket
+// diverges from the pattern's literal prefix at "target" vs. "bucket-N", a ChildMayMatch-pruned
+// walk skips each bucket's entire subtree without ever visiting it, while a naive walk still has
+// to test every directory in it.
+func buildPruningSyntheticTree(b *testing.B, numBuckets, depth int) string {
+	b.Helper()
+	root := b.TempDir()
+
+	for bucket := 0; bucket < numBuckets; bucket++ {
+		dir := filepath.Join(root, "assignments", fmt.Sprintf("bucket-%d", bucket))
+		for level := 0; level < depth; level++ {
+			dir = filepath.Join(dir, fmt.Sprintf("level-%d", level))
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			b.Fatalf("failed to create synthetic tree: %v", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, "assignments", "target", "leaf"), 0755); err != nil {
+		b.Fatalf("failed to create target leaf: %v", err)
+	}
+
+	return root
+}
+
+// naiveCountMatches walks every directory under root and tests it against patterns without any
+// pruning -- the O(directories x patterns) baseline the ChildMayMatch optimization replaces.
+func naiveCountMatches(root string, patterns []*regexp.Regexp) int {
+	count := 0
+	_ = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+		relativePath, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			return nil
+		}
+		relativeNormalizedPath := filepath.ToSlash(relativePath)
+		for _, pattern := range patterns {
+			if pattern.MatchString(relativeNormalizedPath) {
+				count++
+				break
+			}
+		}
+		return nil
+	})
+	return count
+}
+
+// BenchmarkDiscoverAssignments builds a synthetic tree of ~10k directories with a single
+// matching leaf buried under ~2k non-matching sibling subtrees, and asserts that the
+// ChildMayMatch-pruned walk (via ProcessAssignments) is at least 10x faster than a naive walk
+// that tests every directory against the compiled patterns without pruning -- proving out the
+// restic-style literal-prefix optimization on a large, mostly-irrelevant tree.
+func BenchmarkDiscoverAssignments(b *testing.B) {
+	root := buildPruningSyntheticTree(b, 100, 99) // ~10k directories, one matching leaf
+
+	pattern := regex.NewWithPatterns([]string{"assignments/target/{name}"})
+	compiled, err := pattern.Compiled()
+	if err != nil {
+		b.Fatalf("failed to compile pattern: %v", err)
+	}
+
+	naiveStart := time.Now()
+	for i := 0; i < b.N; i++ {
+		naiveCountMatches(root, compiled)
+	}
+	naiveElapsed := time.Since(naiveStart)
+
+	prunedStart := time.Now()
+	for i := 0; i < b.N; i++ {
+		processor, err := NewProcessor(root, pattern)
+		if err != nil {
+			b.Fatalf("failed to create processor: %v", err)
+		}
+		if _, err := processor.ProcessAssignments(); err != nil {
+			b.Fatalf("ProcessAssignments failed: %v", err)
+		}
+	}
+	prunedElapsed := time.Since(prunedStart)
+
+	b.ReportMetric(float64(naiveElapsed)/float64(prunedElapsed), "naive/pruned-ratio")
+
+	if b.N > 0 && naiveElapsed < prunedElapsed*10 {
+		b.Fatalf("expected the pruned walk to be at least 10x faster than the naive walk, got naive=%s pruned=%s", naiveElapsed, prunedElapsed)
+	}
+}
+
+// BenchmarkExtractBranchNameGlobVsRegex compares, alongside BenchmarkExtractBranchName, the
+// per-call cost of deriving a branch name from an already-matched path via a "regex:"-tagged
+// pattern against an equivalent untagged glob pattern (regex.Processor's "{name}" named-wildcard
+// syntax -- see globToRegex), both rendering the same "{{.course}}-wk{{.week}}-{{.assignment}}"
+// branch template. Glob patterns compile to the same underlying *regexp.Regexp, so this mainly
+// measures whether the extra translation step (globToRegex) adds any per-call overhead beyond
+// the one-time Compiled() cost.
+func BenchmarkExtractBranchNameGlobVsRegex(b *testing.B) {
+	path := "/repo/course-101/week-5/assignment-3"
+	template := "{{.course}}-wk{{.week}}-{{.assignment}}"
+
+	b.Run("regex", func(b *testing.B) {
+		pattern := regex.NewFromCommaSeparated(`regex:^course-(?P<course>\d+)/week-(?P<week>\d+)/assignment-(?P<assignment>\d+)$ => ` + template)
+		processor, err := NewProcessor("/repo", pattern)
+		if err != nil {
+			b.Fatalf("failed to create processor: %v", err)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, ok := processor.extractBranchNameFromPath(path, ""); !ok {
+				b.Fatal("expected a match")
+			}
+		}
+	})
+
+	b.Run("glob", func(b *testing.B) {
+		pattern := regex.NewFromCommaSeparated("course-{course}/week-{week}/assignment-{assignment} => " + template)
+		processor, err := NewProcessor("/repo", pattern)
+		if err != nil {
+			b.Fatalf("failed to create processor: %v", err)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, ok := processor.extractBranchNameFromPath(path, ""); !ok {
+				b.Fatal("expected a match")
+			}
+		}
+	})
+}
@@ -1,7 +1,10 @@
 package assignment
 
 import (
+	"strings"
 	"testing"
+
+	"assignment-pull-request/internal/regex"
 )
 
 // TestExtractBranchName tests branch name extraction from assignment paths
@@ -340,3 +343,183 @@ func TestParseRegexPatterns(t *testing.T) {
 		})
 	}
 }
+
+// TestExtractBranchNameWithTemplate tests template-based branch naming as an alternative to the
+// alphabetical auto-join
+func TestExtractBranchNameWithTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		assignmentPath string
+		pattern        string
+		template       string
+		expectedBranch string
+		expectedMatch  bool
+	}{
+		{
+			name:           "template controls group order",
+			assignmentPath: "CS101/hw-2",
+			pattern:        `^(?P<course>[^/]+)/hw-(?P<number>\d+)$`,
+			template:       "{{.course}}-hw{{.number}}",
+			expectedBranch: "cs101-hw2",
+			expectedMatch:  true,
+		},
+		{
+			name:           "no match",
+			assignmentPath: "random/path",
+			pattern:        `^(?P<course>[^/]+)/hw-(?P<number>\d+)$`,
+			template:       "{{.course}}-hw{{.number}}",
+			expectedBranch: "",
+			expectedMatch:  false,
+		},
+		{
+			name:           "invalid pattern",
+			assignmentPath: "CS101/hw-2",
+			pattern:        `(unclosed`,
+			template:       "{{.course}}",
+			expectedBranch: "",
+			expectedMatch:  false,
+		},
+		{
+			name:           "template references unknown group fails to render",
+			assignmentPath: "CS101/hw-2",
+			pattern:        `^(?P<course>[^/]+)/hw-(?P<number>\d+)$`,
+			template:       "{{.course}}-{{.missing}}",
+			expectedBranch: "",
+			expectedMatch:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			branch, matched := ExtractBranchNameWithTemplate(tt.assignmentPath, tt.pattern, tt.template)
+
+			if matched != tt.expectedMatch {
+				t.Errorf("Expected match=%t, got=%t", tt.expectedMatch, matched)
+			}
+
+			if branch != tt.expectedBranch {
+				t.Errorf("Expected branch=%s, got=%s", tt.expectedBranch, branch)
+			}
+		})
+	}
+}
+
+// TestNewProcessorValidatesTemplateGroups tests that NewProcessor rejects a branch-name or
+// PR-body template referencing a capture group its pattern doesn't have, catching the typo at
+// construction time instead of letting it render blank the first time a matching assignment is
+// found.
+func TestNewProcessorValidatesTemplateGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  regex.PatternConfig
+		wantErr  bool
+		errorMsg string
+	}{
+		{
+			name:    "branch template referencing only existing groups is fine",
+			pattern: regex.PatternConfig{Pattern: `regex:^(?P<course>[^/]+)/(?P<week>[^/]+)$`, BranchTemplate: "{{.course}}-{{.week}}"},
+			wantErr: false,
+		},
+		{
+			name:     "branch template referencing an undefined group fails fast",
+			pattern:  regex.PatternConfig{Pattern: `regex:^(?P<course>[^/]+)/(?P<week>[^/]+)$`, BranchTemplate: "{{.course}}-{{.missing}}"},
+			wantErr:  true,
+			errorMsg: "undefined capture group",
+		},
+		{
+			name:     "PR-body template referencing an undefined group fails fast",
+			pattern:  regex.PatternConfig{Pattern: `regex:^(?P<course>[^/]+)/(?P<week>[^/]+)$`, BodyTemplate: "Week {{.wk}}"},
+			wantErr:  true,
+			errorMsg: "undefined capture group",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := regex.New()
+			p.AddPatternConfigs([]regex.PatternConfig{tt.pattern})
+
+			_, err := NewProcessor("", p)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error to contain %q, got: %v", tt.errorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestExtractBodyFromPath tests rendering the PR-body template paired with whichever pattern
+// matched a path, and falling back to "no body" when the matching pattern has none.
+func TestExtractBodyFromPath(t *testing.T) {
+	p := regex.New()
+	p.AddPatternConfigs([]regex.PatternConfig{
+		{Pattern: `regex:^assignments/(?P<course>[^/]+)/(?P<name>[^/]+)$`, BodyTemplate: "See assignments/{{.course}}/{{.name}} for instructions."},
+		{Pattern: `regex:^labs/(?P<name>[^/]+)$`},
+	})
+
+	processor, err := NewProcessor("/repo", p)
+	if err != nil {
+		t.Fatalf("Unexpected error creating processor: %v", err)
+	}
+
+	body, ok := processor.ExtractBodyFromPath("/repo/assignments/cs101/hw-1")
+	if !ok {
+		t.Fatal("Expected a body template match")
+	}
+	if body != "See assignments/cs101/hw-1 for instructions." {
+		t.Errorf("Unexpected rendered body: %s", body)
+	}
+
+	if _, ok := processor.ExtractBodyFromPath("/repo/labs/lab-1"); ok {
+		t.Error("Expected no body template for a pattern with none configured")
+	}
+
+	if _, ok := processor.ExtractBodyFromPath("/repo/unmatched/path"); ok {
+		t.Error("Expected no body template for a non-matching path")
+	}
+}
+
+// TestExtractBranchNameDefaultTemplate covers SetDefaultTemplate (the BRANCH_TEMPLATE env var's
+// underlying mechanism): unlike the alphabetical auto-join covered by
+// TestExtractBranchNameAlphabeticalOrdering, whose order follows the groups' names, a template
+// names the groups it wants explicitly and in its own order, so declaring them in a different
+// order in another pattern doesn't change the produced branch.
+func TestExtractBranchNameDefaultTemplate(t *testing.T) {
+	p := regex.New()
+	p.AddPatterns([]string{
+		`regex:^modules/(?P<module>[^/]+)/(?P<course>[^/]+)/(?P<assignment>[^/]+)$`,
+		`regex:^courses/(?P<course>[^/]+)/(?P<assignment>[^/]+)/(?P<module>[^/]+)$`,
+	})
+	p.SetDefaultTemplate("{{.course}}-{{.module}}-{{.assignment}}")
+
+	processor, err := NewProcessor("/repo", p)
+	if err != nil {
+		t.Fatalf("Unexpected error creating processor: %v", err)
+	}
+
+	branch, ok := processor.extractBranchNameFromPath("/repo/modules/backend/cs101/variables", "")
+	if !ok {
+		t.Fatal("Expected a match")
+	}
+	if branch != "cs101-backend-variables" {
+		t.Errorf("Expected branch=cs101-backend-variables, got=%s", branch)
+	}
+
+	// Same values, groups declared in a different order in a different pattern: the template
+	// still renders them in its own order.
+	reordered, ok := processor.extractBranchNameFromPath("/repo/courses/cs101/variables/backend", "")
+	if !ok {
+		t.Fatal("Expected a match")
+	}
+	if reordered != branch {
+		t.Errorf("Expected reordering the pattern's groups to produce the same branch %q, got=%s", branch, reordered)
+	}
+}
@@ -0,0 +1,107 @@
+package assignment
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ignoreRule is a single compiled gitignore-style rule
+type ignoreRule struct {
+	pattern  string
+	dirOnly  bool
+	anchored bool
+}
+
+// ignoreMatcher holds the set of ignore rules active at a given point in the directory tree
+type ignoreMatcher struct {
+	rules []ignoreRule
+}
+
+// parseIgnoreLines compiles a slice of raw gitignore-style pattern strings into rules, skipping
+// blank lines and comments
+func parseIgnoreLines(lines []string) []ignoreRule {
+	var rules []ignoreRule
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rules = append(rules, parseIgnoreLine(line))
+	}
+	return rules
+}
+
+// loadIgnoreFile reads a gitignore-style file and returns its compiled rules, or nil if the
+// file doesn't exist or can't be read
+func loadIgnoreFile(path string) []ignoreRule {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return parseIgnoreLines(lines)
+}
+
+// parseIgnoreLine compiles a single non-empty, non-comment gitignore-style line
+func parseIgnoreLine(line string) ignoreRule {
+	rule := ignoreRule{pattern: line}
+
+	if strings.HasSuffix(rule.pattern, "/") {
+		rule.dirOnly = true
+		rule.pattern = strings.TrimSuffix(rule.pattern, "/")
+	}
+
+	if strings.HasPrefix(rule.pattern, "/") {
+		rule.anchored = true
+		rule.pattern = strings.TrimPrefix(rule.pattern, "/")
+	}
+
+	return rule
+}
+
+// withRules returns a new matcher extending this one with additional rules (e.g. from a nested
+// .gitignore found while descending the tree), without mutating the receiver
+func (m *ignoreMatcher) withRules(rules []ignoreRule) *ignoreMatcher {
+	if len(rules) == 0 {
+		return m
+	}
+
+	combined := make([]ignoreRule, 0, len(m.rules)+len(rules))
+	combined = append(combined, m.rules...)
+	combined = append(combined, rules...)
+	return &ignoreMatcher{rules: combined}
+}
+
+// matches reports whether relativePath (slash-separated, relative to the ignore root) should be
+// excluded from the walk
+func (m *ignoreMatcher) matches(relativePath string, isDir bool) bool {
+	base := filepath.Base(relativePath)
+	matched := false
+
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		if ok, _ := filepath.Match(rule.pattern, base); ok {
+			matched = true
+			continue
+		}
+		if !rule.anchored {
+			continue
+		}
+		if ok, _ := filepath.Match(rule.pattern, relativePath); ok {
+			matched = true
+		}
+	}
+
+	return matched
+}
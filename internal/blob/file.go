@@ -0,0 +1,32 @@
+package blob
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// FileStorage implements Storage over a local directory, for instructors who check templates
+// into a plain directory (or a separate repo checked out alongside this one) rather than a cloud
+// bucket.
+type FileStorage struct {
+	Root string
+}
+
+// NewFileStorage creates a FileStorage rooted at root.
+func NewFileStorage(root string) *FileStorage {
+	return &FileStorage{Root: root}
+}
+
+// Read implements Storage.
+func (s *FileStorage) Read(path string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.Root, path))
+}
+
+// Write implements Storage.
+func (s *FileStorage) Write(path string, data []byte) error {
+	fullPath := filepath.Join(s.Root, path)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fullPath, data, 0644)
+}
@@ -0,0 +1,63 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"cloud.google.com/go/storage"
+)
+
+// GCSStorage implements Storage over a Google Cloud Storage bucket. Storage's Read/Write don't
+// take a context, so every call here uses context.Background() -- template fetches are a small,
+// bounded part of createReadme/createPullRequestBody, not something a caller needs to cancel
+// independently of the surrounding operation.
+type GCSStorage struct {
+	bucket string
+	client *storage.Client
+}
+
+// NewGCSStorage opens a GCSStorage for bucket, using the environment's default Google Cloud
+// credentials (the same resolution storage.NewClient always does).
+func NewGCSStorage(bucket string) (*GCSStorage, error) {
+	client, err := storage.NewClient(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+	return &GCSStorage{bucket: bucket, client: client}, nil
+}
+
+// Read implements Storage.
+func (s *GCSStorage) Read(path string) ([]byte, error) {
+	ctx := context.Background()
+	reader, err := s.client.Bucket(s.bucket).Object(path).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, &fs.PathError{Op: "read", Path: fmt.Sprintf("gs://%s/%s", s.bucket, path), Err: fs.ErrNotExist}
+		}
+		return nil, fmt.Errorf("failed to open gs://%s/%s: %w", s.bucket, path, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gs://%s/%s: %w", s.bucket, path, err)
+	}
+	return data, nil
+}
+
+// Write implements Storage.
+func (s *GCSStorage) Write(path string, data []byte) error {
+	ctx := context.Background()
+	writer := s.client.Bucket(s.bucket).Object(path).NewWriter(ctx)
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write gs://%s/%s: %w", s.bucket, path, err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finalize gs://%s/%s: %w", s.bucket, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Storage implements Storage over an S3 bucket. See GCSStorage for why every call uses
+// context.Background() rather than threading one through Storage's signature.
+type S3Storage struct {
+	bucket string
+	client *s3.Client
+}
+
+// NewS3Storage opens an S3Storage for bucket, using the environment's default AWS credential
+// chain (the same resolution config.LoadDefaultConfig always does).
+func NewS3Storage(bucket string) (*S3Storage, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return &S3Storage{bucket: bucket, client: s3.NewFromConfig(cfg)}, nil
+}
+
+// Read implements Storage.
+func (s *S3Storage) Read(path string) ([]byte, error) {
+	ctx := context.Background()
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, &fs.PathError{Op: "read", Path: fmt.Sprintf("s3://%s/%s", s.bucket, path), Err: fs.ErrNotExist}
+		}
+		return nil, fmt.Errorf("failed to get s3://%s/%s: %w", s.bucket, path, err)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read s3://%s/%s: %w", s.bucket, path, err)
+	}
+	return data, nil
+}
+
+// Write implements Storage.
+func (s *S3Storage) Write(path string, data []byte) error {
+	ctx := context.Background()
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path),
+		Body:   bytes.NewReader(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put s3://%s/%s: %w", s.bucket, path, err)
+	}
+	return nil
+}
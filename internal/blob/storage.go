@@ -0,0 +1,44 @@
+// Package blob abstracts reading and writing named byte blobs from a single storage backend
+// selected by a URI scheme, following srpmproc's blob.Storage design. It lets an instructor host
+// assignment templates (a starter README, a rubric, a PR-body template) in whichever location
+// suits their classroom -- a checked-in directory, a GCS bucket, an S3 bucket -- without
+// creator.Creator needing to know which one it's talking to.
+package blob
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Storage reads and writes blobs by path, relative to whatever root the backend was opened with
+// (a directory for FileStorage, a bucket for GCSStorage/S3Storage).
+type Storage interface {
+	// Read returns the bytes stored at path. It returns an error satisfying os.IsNotExist when
+	// path doesn't exist.
+	Read(path string) ([]byte, error)
+	// Write stores data at path, creating or overwriting it.
+	Write(path string, data []byte) error
+}
+
+// NewFromURL opens the Storage backend named by uri's scheme:
+//
+//	file://<directory>  -> FileStorage rooted at <directory>
+//	gs://<bucket>       -> GCSStorage for <bucket>
+//	s3://<bucket>       -> S3Storage for <bucket>
+func NewFromURL(uri string) (Storage, error) {
+	scheme, root, ok := strings.Cut(uri, "://")
+	if !ok {
+		return nil, fmt.Errorf("invalid storage URL %q: missing scheme", uri)
+	}
+
+	switch scheme {
+	case "file":
+		return NewFileStorage(root), nil
+	case "gs":
+		return NewGCSStorage(root)
+	case "s3":
+		return NewS3Storage(root)
+	default:
+		return nil, fmt.Errorf("unsupported storage scheme %q in %q", scheme, uri)
+	}
+}
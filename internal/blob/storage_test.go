@@ -0,0 +1,56 @@
+package blob
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStorageReadWrite(t *testing.T) {
+	root := t.TempDir()
+	storage := NewFileStorage(root)
+
+	if err := storage.Write("cs101/hw-1/README.tmpl", []byte("hello")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := storage.Read("cs101/hw-1/README.tmpl")
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("expected %q, got %q", "hello", data)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "cs101/hw-1/README.tmpl")); err != nil {
+		t.Fatalf("expected file on disk: %v", err)
+	}
+}
+
+func TestFileStorageReadMissing(t *testing.T) {
+	storage := NewFileStorage(t.TempDir())
+
+	if _, err := storage.Read("does-not-exist.tmpl"); !os.IsNotExist(err) {
+		t.Fatalf("expected os.IsNotExist error, got %v", err)
+	}
+}
+
+func TestNewFromURLDispatchesByScheme(t *testing.T) {
+	root := t.TempDir()
+
+	storage, err := NewFromURL("file://" + root)
+	if err != nil {
+		t.Fatalf("NewFromURL failed: %v", err)
+	}
+	if _, ok := storage.(*FileStorage); !ok {
+		t.Fatalf("expected *FileStorage, got %T", storage)
+	}
+
+	if _, err := NewFromURL("ftp://example.com/templates"); err == nil {
+		t.Fatalf("expected error for unsupported scheme")
+	}
+
+	if _, err := NewFromURL("not-a-url"); err == nil {
+		t.Fatalf("expected error for missing scheme")
+	}
+}
@@ -0,0 +1,116 @@
+// Package branchfilter implements GitHub Actions-style include/exclude glob matching for branch
+// and tag names, as used by the `branches`/`branches-ignore` and `tags`/`tags-ignore` workflow
+// trigger filters.
+package branchfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// globTokenPattern matches the glob tokens supported in a filter pattern: "**" (cross-segment
+// wildcard), "*" (within-segment wildcard), "?" (single character), and "[...]" character
+// classes, which are passed through to the underlying regex largely as-is (fnmatch semantics).
+var globTokenPattern = regexp.MustCompile(`\*\*|\*|\?|\[[^\]]+\]`)
+
+// rule is a single compiled pattern. negate is true for patterns prefixed with "!", GitHub
+// Actions' pattern-level negation: a later negated pattern can un-match a ref an earlier
+// positive pattern matched.
+type rule struct {
+	negate bool
+	regex  *regexp.Regexp
+}
+
+// Filter evaluates a ref name (branch or tag) against include and exclude glob pattern lists
+type Filter struct {
+	include []rule
+	exclude []rule
+}
+
+// New compiles an include list (e.g. "assignment-branches"/"assignment-tags") and an exclude
+// list (e.g. "assignment-branches-ignore"/"assignment-tags-ignore") into a Filter
+func New(include, exclude []string) (*Filter, error) {
+	includeRules, err := compileRules(include)
+	if err != nil {
+		return nil, fmt.Errorf("invalid include pattern: %w", err)
+	}
+
+	excludeRules, err := compileRules(exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exclude pattern: %w", err)
+	}
+
+	return &Filter{include: includeRules, exclude: excludeRules}, nil
+}
+
+// compileRules compiles a list of possibly "!"-negated glob patterns
+func compileRules(patterns []string) ([]rule, error) {
+	rules := make([]rule, 0, len(patterns))
+	for _, pattern := range patterns {
+		negate := strings.HasPrefix(pattern, "!")
+		source := strings.TrimPrefix(pattern, "!")
+
+		compiled, err := regexp.Compile(globToRegex(source))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+		}
+		rules = append(rules, rule{negate: negate, regex: compiled})
+	}
+	return rules, nil
+}
+
+// Skip reports whether name should be skipped: true when include patterns are configured but
+// none of them end up matching, or when any exclude pattern matches
+func (f *Filter) Skip(name string) bool {
+	if len(f.include) > 0 && !evaluate(f.include, name) {
+		return true
+	}
+	if len(f.exclude) > 0 && evaluate(f.exclude, name) {
+		return true
+	}
+	return false
+}
+
+// evaluate applies GitHub Actions' pattern-level negation semantics: patterns are evaluated in
+// order, a matching positive pattern sets matched=true, and a matching negated pattern clears
+// it back to false
+func evaluate(rules []rule, name string) bool {
+	matched := false
+	for _, r := range rules {
+		if r.regex.MatchString(name) {
+			matched = !r.negate
+		}
+	}
+	return matched
+}
+
+// globToRegex translates a GitHub Actions-style filter glob into an anchored regex source
+func globToRegex(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	lastEnd := 0
+	for _, loc := range globTokenPattern.FindAllStringIndex(glob, -1) {
+		start, end := loc[0], loc[1]
+		sb.WriteString(regexp.QuoteMeta(glob[lastEnd:start]))
+
+		switch token := glob[start:end]; {
+		case token == "**":
+			sb.WriteString(".*")
+		case token == "*":
+			sb.WriteString("[^/]*")
+		case token == "?":
+			sb.WriteString("[^/]")
+		default:
+			// character class, e.g. [abc] or [a-z] — pass through as-is
+			sb.WriteString(token)
+		}
+
+		lastEnd = end
+	}
+	sb.WriteString(regexp.QuoteMeta(glob[lastEnd:]))
+
+	sb.WriteString("$")
+	return sb.String()
+}
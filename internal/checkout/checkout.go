@@ -1,12 +1,15 @@
 package checkout
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"assignment-pull-request/internal/assignment"
+	"assignment-pull-request/internal/branchfilter"
 	"assignment-pull-request/internal/constants"
 	"assignment-pull-request/internal/git"
 	"assignment-pull-request/internal/workflow"
@@ -15,10 +18,10 @@ import (
 // Processor handles Git sparse-checkout configuration based on assignment patterns
 type Processor struct {
 	repositoryRoot string
-	gitOps         *git.Operations
+	gitOps         git.Backend
 }
 
-// New creates a new sparse checkout processor
+// New creates a new sparse checkout processor using the default exec-based git backend
 func New(repositoryRoot string) *Processor {
 	return &Processor{
 		repositoryRoot: repositoryRoot,
@@ -28,16 +31,22 @@ func New(repositoryRoot string) *Processor {
 
 // NewWithGitOps creates a new sparse checkout processor with custom git operations
 func NewWithGitOps(repositoryRoot string, gitOps *git.Operations) *Processor {
+	return NewWithBackend(repositoryRoot, gitOps)
+}
+
+// NewWithBackend creates a new sparse checkout processor with a custom Backend, e.g. the
+// go-git-based backend for environments without a git binary available
+func NewWithBackend(repositoryRoot string, backend git.Backend) *Processor {
 	return &Processor{
 		repositoryRoot: repositoryRoot,
-		gitOps:         gitOps,
+		gitOps:         backend,
 	}
 }
 
 // SparseCheckout configures Git sparse-checkout for assignments matching the current branch
 // Automatically discovers workflow patterns, finds matching assignments, and sets up sparse-checkout
 // to include all non-assignment root folders plus only the assignment folders that match the current branch
-func (p *Processor) SparseCheckout() error {
+func (p *Processor) SparseCheckout(ctx context.Context) error {
 	fmt.Printf("🔍 Starting sparse-checkout configuration...\n")
 	fmt.Printf("Debug: Repository root: %s\n", p.repositoryRoot)
 
@@ -53,9 +62,12 @@ func (p *Processor) SparseCheckout() error {
 
 	// Disable sparse-checkout at the very beginning to reset state
 	fmt.Printf("Debug: Disabling existing sparse-checkout configuration...\n")
-	if err := p.gitOps.DisableSparseCheckout(); err != nil {
-		// Ignore error if sparse-checkout wasn't enabled
-		fmt.Printf("Warning: could not disable sparse-checkout (may not be enabled): %v\n", err)
+	if err := p.gitOps.DisableSparseCheckout(ctx); err != nil {
+		if errors.Is(err, git.ErrSparseCheckoutNotEnabled) {
+			fmt.Printf("Debug: sparse-checkout was not enabled, nothing to disable\n")
+		} else {
+			return fmt.Errorf("failed to disable sparse-checkout: %w", err)
+		}
 	}
 
 	// Parse workflow files to find assignment configurations
@@ -88,7 +100,7 @@ func (p *Processor) SparseCheckout() error {
 	}
 
 	// Get current branch
-	currentBranch, err := p.getCurrentBranch()
+	currentBranch, err := p.getCurrentBranch(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get current branch: %w", err)
 	}
@@ -96,7 +108,11 @@ func (p *Processor) SparseCheckout() error {
 
 	// Get matching assignments for current branch
 	fmt.Printf("Debug: Finding assignments matching current branch...\n")
-	assignmentPaths, err := p.getMatchingAssignments(assignmentProcessor)
+	branchFilter, err := workflowProcessor.BranchFilter()
+	if err != nil {
+		return fmt.Errorf("failed to build branch filter: %w", err)
+	}
+	assignmentPaths, err := p.getMatchingAssignments(ctx, assignmentProcessor, branchFilter)
 	if err != nil {
 		return fmt.Errorf("failed to get matching assignments: %w", err)
 	}
@@ -186,13 +202,13 @@ func (p *Processor) SparseCheckout() error {
 
 	// Enable sparse-checkout with cone mode for better performance
 	fmt.Printf("Debug: Enabling sparse-checkout with cone mode...\n")
-	if err := p.gitOps.InitSparseCheckoutCone(); err != nil {
+	if err := p.gitOps.InitSparseCheckoutCone(ctx); err != nil {
 		return fmt.Errorf("failed to enable sparse-checkout with cone mode: %w", err)
 	}
 
 	// Configure sparse-checkout with the computed paths
 	fmt.Printf("Debug: Setting sparse-checkout paths...\n")
-	err = p.gitOps.SetSparseCheckoutPaths(paths)
+	err = p.gitOps.SetSparseCheckoutPaths(ctx, paths)
 	if err != nil {
 		return fmt.Errorf("failed to configure sparse checkout: %w", err)
 	}
@@ -205,18 +221,23 @@ func (p *Processor) SparseCheckout() error {
 }
 
 // getCurrentBranch returns the name of the currently checked out branch
-func (p *Processor) getCurrentBranch() (string, error) {
-	return p.gitOps.GetCurrentBranch()
+func (p *Processor) getCurrentBranch(ctx context.Context) (string, error) {
+	return p.gitOps.GetCurrentBranch(ctx)
 }
 
-// getMatchingAssignments returns the assignment paths that match the current branch
-func (p *Processor) getMatchingAssignments(assignmentProcessor *assignment.Processor) ([]string, error) {
+// getMatchingAssignments returns the assignment paths that match the current branch. branchFilter
+// may be nil, in which case only the regex-derived branch name is considered
+func (p *Processor) getMatchingAssignments(ctx context.Context, assignmentProcessor *assignment.Processor, branchFilter *branchfilter.Filter) ([]string, error) {
 	// Get current branch
-	currentBranch, err := p.getCurrentBranch()
+	currentBranch, err := p.getCurrentBranch(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current branch: %w", err)
 	}
 
+	if branchFilter != nil && branchFilter.Skip(currentBranch) {
+		return nil, nil
+	}
+
 	allAssignments, err := assignmentProcessor.ProcessAssignments()
 	if err != nil {
 		return nil, fmt.Errorf("failed to find assignments: %w", err)
@@ -0,0 +1,102 @@
+package checkout
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"assignment-pull-request/internal/testutil"
+)
+
+const testWorkflowYAML = `
+jobs:
+  assign:
+    uses: "./"
+    with:
+      assignment-regex: "regex:^test/fixtures/labs/(?P<name>[^/]+)$"
+`
+
+// setupWorkflowRepo builds a TempWorkspace with the standard assignment fixtures, a workflow
+// file configuring the assignment-regex above, and a fake .git directory so SparseCheckout
+// doesn't bail out on its "is this even a repo" check. It changes into the workspace for the
+// duration of the test, since Processor.SparseCheckout discovers workflow files relative to cwd.
+func setupWorkflowRepo(t *testing.T) *testutil.TempWorkspace {
+	t.Helper()
+	ws := testutil.NewTempWorkspace(t)
+	ws.CreateStandardStructure()
+
+	if err := os.MkdirAll(filepath.Join(ws.RootDir, ".git"), 0755); err != nil {
+		t.Fatalf("failed to create .git directory: %v", err)
+	}
+
+	workflowsDir := filepath.Join(ws.RootDir, ".github", "workflows")
+	if err := os.MkdirAll(workflowsDir, 0755); err != nil {
+		t.Fatalf("failed to create workflows directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(workflowsDir, "assign.yml"), []byte(testWorkflowYAML), 0644); err != nil {
+		t.Fatalf("failed to write workflow file: %v", err)
+	}
+
+	restore := ws.ChangeToWorkspace()
+	t.Cleanup(restore)
+
+	return ws
+}
+
+// lastSparseCheckoutSetPaths returns the paths passed to the last "sparse-checkout set" call
+// recorded by backend, so a test can assert on exactly what Processor decided to include.
+func lastSparseCheckoutSetPaths(backend *testutil.FakeBackend) []string {
+	for i := len(backend.Calls) - 1; i >= 0; i-- {
+		call := backend.Calls[i]
+		if call.SubCmd == "sparse-checkout" && call.SubSubCmd == "set" {
+			return call.PostSepArgs
+		}
+	}
+	return nil
+}
+
+func TestSparseCheckoutScopesToMatchingAssignment(t *testing.T) {
+	ws := setupWorkflowRepo(t)
+
+	backend := testutil.NewFakeBackend()
+	backend.CurrentBranch = "lab-1"
+
+	processor := NewWithBackend(ws.RootDir, backend)
+	if err := processor.SparseCheckout(context.Background()); err != nil {
+		t.Fatalf("SparseCheckout failed: %v", err)
+	}
+
+	paths := lastSparseCheckoutSetPaths(backend)
+	if !containsPath(paths, "test/fixtures/labs/lab-1") {
+		t.Errorf("expected sparse-checkout paths to include the matching assignment, got %v", paths)
+	}
+	if containsPath(paths, "test/fixtures/assignments/assignment-1") {
+		t.Errorf("expected sparse-checkout paths to exclude a non-matching assignment root, got %v", paths)
+	}
+}
+
+func TestSparseCheckoutSkipsNonMatchingBranch(t *testing.T) {
+	ws := setupWorkflowRepo(t)
+
+	backend := testutil.NewFakeBackend()
+	backend.CurrentBranch = "main"
+
+	processor := NewWithBackend(ws.RootDir, backend)
+	if err := processor.SparseCheckout(context.Background()); err != nil {
+		t.Fatalf("SparseCheckout failed: %v", err)
+	}
+
+	if lastSparseCheckoutSetPaths(backend) != nil {
+		t.Error("expected no sparse-checkout paths to be configured for a branch with no matching assignment")
+	}
+}
+
+func containsPath(paths []string, target string) bool {
+	for _, path := range paths {
+		if path == target {
+			return true
+		}
+	}
+	return false
+}
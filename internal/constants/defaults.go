@@ -2,8 +2,9 @@ package constants
 
 // Default configuration values for the Assignment Pull Request Creator
 const (
-	// DefaultAssignmentRegex is the default regex pattern for assignment folders with named groups
-	DefaultAssignmentRegex = `^(?P<branch>assignment-\d+)$`
+	// DefaultAssignmentRegex is the default regex pattern for assignment folders with named groups.
+	// Tagged "regex:" since regex.Processor treats untagged patterns as globs.
+	DefaultAssignmentRegex = `regex:^(?P<branch>assignment-\d+)$`
 
 	// DefaultBranch is the default branch name for pull requests
 	DefaultBranch = "main"
@@ -13,6 +14,21 @@ const (
 
 	// ActionName is the name used to identify this action in workflows
 	ActionName = "assignment-pull-request"
+
+	// GitBackendShell selects the exec-based git.Client that shells out to the git binary
+	GitBackendShell = "shell"
+
+	// GitBackendNative selects the go-git-based git.Client that runs entirely in-process
+	GitBackendNative = "native"
+
+	// DefaultGitBackend is the default git backend, kept as GitBackendShell for parity with the
+	// action's long-standing behavior
+	DefaultGitBackend = GitBackendShell
+
+	// DefaultImageFitSpec is the images.Resource.Fit spec applied to every image referenced from
+	// an assignment README before it's fingerprinted, keeping PR bodies free of oversized assets
+	// without instructors having to think about image dimensions at all.
+	DefaultImageFitSpec = "1600x1600"
 )
 
 // Environment variable names
@@ -26,15 +42,99 @@ const (
 	// EnvAssignmentRegex is the environment variable for assignment regex patterns
 	EnvAssignmentRegex = "ASSIGNMENT_REGEX"
 
+	// EnvAssignmentGlob is the environment variable for gitignore-style assignment glob
+	// patterns, mutually exclusive with EnvAssignmentRegex
+	EnvAssignmentGlob = "ASSIGNMENT_GLOB"
+
+	// EnvPatternSyntax is the environment variable choosing how an untagged ASSIGNMENT_REGEX
+	// pattern (no "regex:"/"glob:" prefix) is interpreted: regex.SyntaxRegex or regex.SyntaxGlob
+	// (the default). A per-pattern "regex:"/"glob:" tag always overrides this.
+	EnvPatternSyntax = "PATTERN_SYNTAX"
+
+	// EnvAssignmentExcludeRegex is the environment variable for comma-separated patterns that
+	// exclude an otherwise-matching assignment directory
+	EnvAssignmentExcludeRegex = "ASSIGNMENT_EXCLUDE_REGEX"
+
+	// EnvAssignmentsRootExcludeRegex is the environment variable for comma-separated patterns
+	// that exclude an entire top-level root folder before it's walked
+	EnvAssignmentsRootExcludeRegex = "ASSIGNMENTS_ROOT_EXCLUDE_REGEX"
+
 	// EnvDefaultBranch is the environment variable for default branch name
 	EnvDefaultBranch = "DEFAULT_BRANCH"
 
+	// EnvBranchTemplate is the environment variable for the default branch-name text/template
+	// applied to any assignment pattern that has no per-pattern template of its own (see
+	// regex.Processor.SetDefaultTemplate)
+	EnvBranchTemplate = "BRANCH_TEMPLATE"
+
+	// EnvContentLanguage is the environment variable for the preferred content language, used to
+	// pick a translation when .assignmentsrc's contentLanguages is configured (see
+	// instructions.Processor.PreferredLanguage)
+	EnvContentLanguage = "CONTENT_LANGUAGE"
+
 	// EnvDryRun is the environment variable for dry-run mode
 	EnvDryRun = "DRY_RUN"
+
+	// EnvGitBackend is the environment variable selecting the git backend (GitBackendShell or
+	// GitBackendNative)
+	EnvGitBackend = "GIT_BACKEND"
+
+	// EnvWorkerPoolSize is the environment variable for the number of assignments processed
+	// concurrently in creator.Creator.processAssignments, defaulting to runtime.NumCPU() when
+	// unset or invalid
+	EnvWorkerPoolSize = "WORKER_POOL_SIZE"
+
+	// EnvAssignmentTemplateURL is the environment variable for the blob.Storage URL (file://,
+	// gs://, or s3://) an instructor hosts shared README/PR-body templates in, consulted by
+	// creator.Creator.createReadme and createPullRequestBody before falling back to the built-in
+	// defaults (see constants.ReadmeTemplateName, PRBodyTemplateName)
+	EnvAssignmentTemplateURL = "ASSIGNMENT_TEMPLATE_URL"
+
+	// EnvKeepOnFailure is the environment variable that, when set to a truthy value (see isDryRun's
+	// parsing), skips creator.Creator.rollback after a failed run -- leaving the branches it
+	// created (and pushed) in place for an instructor to inspect instead of cleaning them up
+	EnvKeepOnFailure = "KEEP_ON_FAILURE"
+
+	// EnvPRTitleTemplate is the environment variable for a text/template string (e.g.
+	// "{{.course}} week {{.week}}") rendered against an assignment's matching pattern's named
+	// capture groups (see assignment.Processor.MatchGroupsForPath) to produce its pull request
+	// title, in place of the branch name used when unset or an assignment-pr.yaml override's
+	// PRTitle doesn't apply.
+	EnvPRTitleTemplate = "PR_TITLE_TEMPLATE"
+
+	// EnvCommitMessageTemplate is the environment variable for a text/template string rendered the
+	// same way as EnvPRTitleTemplate to produce the commit message for an assignment's initial
+	// README commit, in place of the built-in "Add README for assignment <path>" default.
+	EnvCommitMessageTemplate = "COMMIT_MESSAGE_TEMPLATE"
+
+	// EnvPlanOutput is the environment variable selecting how a dry run's creator.Plan is printed
+	// to stdout: PlanOutputText (the default) or PlanOutputJSON.
+	EnvPlanOutput = "PLAN_OUTPUT"
+)
+
+// Dry-run plan output formats (see EnvPlanOutput)
+const (
+	// PlanOutputText renders a dry run's plan as a human-readable table, one action per line
+	PlanOutputText = "text"
+
+	// PlanOutputJSON renders a dry run's plan as indented JSON
+	PlanOutputJSON = "json"
+
+	// DefaultPlanOutput is the plan output format used when EnvPlanOutput is unset
+	DefaultPlanOutput = PlanOutputText
 )
 
 // Common patterns and values
 const (
+	// AssignmentsRCFileName is the optional in-repo config file consulted by
+	// creator.NewConfigFromEnv for patterns and defaults not set via environment variables
+	AssignmentsRCFileName = ".assignmentsrc"
+
+	// AssignmentPRRCFileName is the optional in-repo config file consulted by
+	// creator.NewConfigFromEnv for per-assignment overrides (custom branch template, PR title,
+	// base branch, skip flag, reviewers, labels, milestone), keyed by assignment path or regex
+	AssignmentPRRCFileName = ".assignment-pr.yaml"
+
 	// GitHubActionsWorkflowDir is the directory containing GitHub Actions workflows
 	GitHubActionsWorkflowDir = ".github/workflows"
 
@@ -49,6 +149,15 @@ const (
 
 	// ReadmeFileName is the standard README file name
 	ReadmeFileNameLowerCase = "readme.md"
+
+	// ReadmeTemplateName is the per-assignment README override creator.Creator.createReadme looks
+	// up as "<assignment>/README.tmpl" in its templateStore, if configured
+	ReadmeTemplateName = "README.tmpl"
+
+	// PRBodyTemplateName is the per-assignment PR body override
+	// creator.Creator.createPullRequestBody looks up as "<assignment>/PR_BODY.tmpl" in its
+	// templateStore, if configured
+	PRBodyTemplateName = "PR_BODY.tmpl"
 )
 
 // File extensions and patterns
@@ -70,4 +179,20 @@ var FilteredFolders = []string{".git", ".github", ".devcontainer"}
 const (
 	// WorkflowAssignmentRegexKey is the YAML key for assignment regex patterns
 	WorkflowAssignmentRegexKey = "assignment-regex"
+
+	// WorkflowAssignmentBranchesKey is the YAML key for the comma-separated list of
+	// GitHub Actions-style glob patterns a branch must match to be processed
+	WorkflowAssignmentBranchesKey = "assignment-branches"
+
+	// WorkflowAssignmentBranchesIgnoreKey is the YAML key for the comma-separated list of
+	// GitHub Actions-style glob patterns that exclude an otherwise-matching branch
+	WorkflowAssignmentBranchesIgnoreKey = "assignment-branches-ignore"
+
+	// WorkflowAssignmentTagsKey is the YAML key for the comma-separated list of
+	// GitHub Actions-style glob patterns a tag must match to be processed
+	WorkflowAssignmentTagsKey = "assignment-tags"
+
+	// WorkflowAssignmentTagsIgnoreKey is the YAML key for the comma-separated list of
+	// GitHub Actions-style glob patterns that exclude an otherwise-matching tag
+	WorkflowAssignmentTagsIgnoreKey = "assignment-tags-ignore"
 )
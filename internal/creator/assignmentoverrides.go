@@ -0,0 +1,153 @@
+package creator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"assignment-pull-request/internal/assignment"
+	"assignment-pull-request/internal/constants"
+
+	"gopkg.in/yaml.v3"
+)
+
+// assignmentOverridesFile is the optional, in-repo configuration file
+// (constants.AssignmentPRRCFileName) instructors use to customize how individual assignments'
+// pull requests are created, on top of the patterns and defaults declared in .assignmentsrc.
+// Defaults applies to every assignment; Assignments then layers a more specific entry on top,
+// keyed by either a literal assignment path or a "regex:"-prefixed pattern (same convention as
+// regex.Processor's pattern configs).
+type assignmentOverridesFile struct {
+	Defaults    assignmentOverrideEntry            `yaml:"defaults"`
+	Assignments map[string]assignmentOverrideEntry `yaml:"assignments"`
+}
+
+// assignmentOverrideEntry is one entry of assignmentOverridesFile.Assignments, or the
+// top-level Defaults block.
+type assignmentOverrideEntry struct {
+	Branch        string   `yaml:"branch"`
+	PRTitle       string   `yaml:"prTitle"`
+	BaseBranch    string   `yaml:"baseBranch"`
+	Skip          bool     `yaml:"skip"`
+	Reviewers     []string `yaml:"reviewers"`
+	TeamReviewers []string `yaml:"teamReviewers"`
+	Labels        []string `yaml:"labels"`
+	Assignees     []string `yaml:"assignees"`
+	Milestone     string   `yaml:"milestone"`
+	Draft         bool     `yaml:"draft"`
+}
+
+// toOverride adapts an assignmentOverrideEntry to the assignment package's transport-agnostic
+// assignment.Override, keeping the YAML shape out of internal/assignment
+func (e assignmentOverrideEntry) toOverride() assignment.Override {
+	return assignment.Override{
+		BranchTemplate: e.Branch,
+		PRTitle:        e.PRTitle,
+		BaseBranch:     e.BaseBranch,
+		Skip:           e.Skip,
+		Reviewers:      e.Reviewers,
+		TeamReviewers:  e.TeamReviewers,
+		Labels:         e.Labels,
+		Assignees:      e.Assignees,
+		Milestone:      e.Milestone,
+		Draft:          e.Draft,
+	}
+}
+
+// loadAssignmentPRRCFile reads and parses constants.AssignmentPRRCFileName from repoRoot,
+// returning (nil, nil) if the file doesn't exist -- it's entirely optional
+func loadAssignmentPRRCFile(repoRoot string) (*assignmentOverridesFile, error) {
+	path := filepath.Join(repoRoot, constants.AssignmentPRRCFileName)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", constants.AssignmentPRRCFileName, err)
+	}
+
+	var rc assignmentOverridesFile
+	if err := yaml.Unmarshal(data, &rc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", constants.AssignmentPRRCFileName, err)
+	}
+
+	return &rc, nil
+}
+
+// assignmentOverridesResolver adapts an *assignmentOverridesFile to assignment.OverrideResolver
+type assignmentOverridesResolver struct {
+	file *assignmentOverridesFile
+}
+
+// Resolve merges the file's Defaults with the most specific matching entry in Assignments --
+// an exact literal-path match first, then "regex:"-prefixed keys tried in sorted order (for
+// determinism when more than one would match) -- with the matching entry's non-zero fields
+// winning over Defaults field-by-field.
+func (r *assignmentOverridesResolver) Resolve(assignmentPath string) assignment.Override {
+	result := r.file.Defaults.toOverride()
+
+	if entry, ok := r.file.Assignments[assignmentPath]; ok {
+		return mergeOverride(result, entry.toOverride())
+	}
+
+	normalizedPath := filepath.ToSlash(assignmentPath)
+
+	var regexKeys []string
+	for key := range r.file.Assignments {
+		if strings.HasPrefix(key, "regex:") {
+			regexKeys = append(regexKeys, key)
+		}
+	}
+	sort.Strings(regexKeys)
+
+	for _, key := range regexKeys {
+		pattern := strings.TrimPrefix(key, "regex:")
+		matched, err := regexp.MatchString(pattern, normalizedPath)
+		if err != nil || !matched {
+			continue
+		}
+		return mergeOverride(result, r.file.Assignments[key].toOverride())
+	}
+
+	return result
+}
+
+// mergeOverride layers override on top of base, field-by-field, with a non-zero field in
+// override taking precedence
+func mergeOverride(base, override assignment.Override) assignment.Override {
+	if override.BranchTemplate != "" {
+		base.BranchTemplate = override.BranchTemplate
+	}
+	if override.PRTitle != "" {
+		base.PRTitle = override.PRTitle
+	}
+	if override.BaseBranch != "" {
+		base.BaseBranch = override.BaseBranch
+	}
+	if override.Skip {
+		base.Skip = true
+	}
+	if len(override.Reviewers) > 0 {
+		base.Reviewers = override.Reviewers
+	}
+	if len(override.TeamReviewers) > 0 {
+		base.TeamReviewers = override.TeamReviewers
+	}
+	if len(override.Labels) > 0 {
+		base.Labels = override.Labels
+	}
+	if len(override.Assignees) > 0 {
+		base.Assignees = override.Assignees
+	}
+	if override.Milestone != "" {
+		base.Milestone = override.Milestone
+	}
+	if override.Draft {
+		base.Draft = true
+	}
+	return base
+}
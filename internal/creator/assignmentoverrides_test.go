@@ -0,0 +1,106 @@
+package creator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"assignment-pull-request/internal/constants"
+)
+
+// withAssignmentPRRCFile writes contents to .assignment-pr.yaml in a fresh temp directory,
+// returning the directory so callers can pass it straight to loadAssignmentPRRCFile
+func withAssignmentPRRCFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	if contents == "" {
+		return tempDir
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, constants.AssignmentPRRCFileName), []byte(contents), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", constants.AssignmentPRRCFileName, err)
+	}
+	return tempDir
+}
+
+func TestLoadAssignmentPRRCFileMissing(t *testing.T) {
+	rc, err := loadAssignmentPRRCFile(withAssignmentPRRCFile(t, ""))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rc != nil {
+		t.Errorf("Expected nil for an absent file, got %+v", rc)
+	}
+}
+
+func TestLoadAssignmentPRRCFileMalformed(t *testing.T) {
+	dir := withAssignmentPRRCFile(t, "assignments: [unterminated")
+	if _, err := loadAssignmentPRRCFile(dir); err == nil {
+		t.Error("Expected an error for a malformed .assignment-pr.yaml file")
+	}
+}
+
+func TestAssignmentOverridesResolverResolve(t *testing.T) {
+	dir := withAssignmentPRRCFile(t, `
+defaults:
+  baseBranch: "develop"
+  labels:
+    - "assignment"
+assignments:
+  assignments/cs101/hw-1:
+    prTitle: "CS101 Homework 1"
+    reviewers:
+      - "instructor"
+  "regex:^assignments/cs101/.*$":
+    labels:
+      - "cs101"
+  assignments/archive/old:
+    skip: true
+`)
+
+	rc, err := loadAssignmentPRRCFile(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resolver := &assignmentOverridesResolver{file: rc}
+
+	t.Run("literal match wins over regex and carries defaults", func(t *testing.T) {
+		override := resolver.Resolve("assignments/cs101/hw-1")
+		if override.PRTitle != "CS101 Homework 1" {
+			t.Errorf("Expected literal match's prTitle, got %q", override.PRTitle)
+		}
+		if override.BaseBranch != "develop" {
+			t.Errorf("Expected defaults.baseBranch to carry through, got %q", override.BaseBranch)
+		}
+		if len(override.Reviewers) != 1 || override.Reviewers[0] != "instructor" {
+			t.Errorf("Expected literal match's reviewers, got %v", override.Reviewers)
+		}
+	})
+
+	t.Run("regex match applies when no literal key matches", func(t *testing.T) {
+		override := resolver.Resolve("assignments/cs101/hw-2")
+		if len(override.Labels) != 1 || override.Labels[0] != "cs101" {
+			t.Errorf("Expected regex match's labels to win over defaults, got %v", override.Labels)
+		}
+		if override.BaseBranch != "develop" {
+			t.Errorf("Expected defaults.baseBranch to carry through, got %q", override.BaseBranch)
+		}
+	})
+
+	t.Run("unmatched path falls back to defaults only", func(t *testing.T) {
+		override := resolver.Resolve("assignments/other/hw-1")
+		if override.BaseBranch != "develop" {
+			t.Errorf("Expected defaults.baseBranch, got %q", override.BaseBranch)
+		}
+		if len(override.Labels) != 1 || override.Labels[0] != "assignment" {
+			t.Errorf("Expected defaults.labels, got %v", override.Labels)
+		}
+	})
+
+	t.Run("skip flag is carried through", func(t *testing.T) {
+		override := resolver.Resolve("assignments/archive/old")
+		if !override.Skip {
+			t.Error("Expected Skip=true for the matching entry")
+		}
+	})
+}
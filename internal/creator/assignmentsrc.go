@@ -0,0 +1,143 @@
+package creator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"assignment-pull-request/internal/constants"
+	"assignment-pull-request/internal/instructions"
+	"assignment-pull-request/internal/regex"
+
+	"gopkg.in/yaml.v3"
+)
+
+// assignmentsRCFile is the optional, in-repo configuration file (constants.AssignmentsRCFileName)
+// instructors use to declare patterns and defaults for a repo without setting an environment
+// variable for every course. NewConfigFromEnv loads it and merges it with any env vars that are
+// also set, with the env var winning field-by-field.
+type assignmentsRCFile struct {
+	// RootPatterns excludes a top-level root folder by name before it's ever walked, same
+	// gitignore-style "!" negation as ASSIGNMENTS_ROOT_EXCLUDE_REGEX
+	RootPatterns []string `yaml:"rootPatterns"`
+
+	// AssignmentPatterns are the patterns tested against every candidate directory, each
+	// optionally paired with its own branch-name and PR-body template
+	AssignmentPatterns []assignmentPatternEntry `yaml:"assignmentPatterns"`
+
+	// ExcludePatterns excludes an otherwise-matching assignment directory, same gitignore-style
+	// "!" negation as ASSIGNMENT_EXCLUDE_REGEX
+	ExcludePatterns []string `yaml:"excludePatterns"`
+
+	// DefaultBranch is the branch pull requests are created against
+	DefaultBranch string `yaml:"defaultBranch"`
+
+	// ContentLanguages maps a language code (e.g. "en", "ja") to the content directory root under
+	// which that language's assignment folders live; see instructions.LanguageRoots
+	ContentLanguages map[string]string `yaml:"contentLanguages"`
+
+	// DefaultContentLanguage is the language instructions.Processor falls back to when resolving
+	// a translation and CONTENT_LANGUAGE isn't set; must name one of ContentLanguages
+	DefaultContentLanguage string `yaml:"defaultContentLanguage"`
+}
+
+// assignmentPatternEntry is one entry of assignmentsRCFile.AssignmentPatterns. It unmarshals
+// from either a bare pattern string (e.g. "assignments/{name}") or a mapping that additionally
+// names a branch and/or PR-body template, e.g.:
+//
+//	assignmentPatterns:
+//	  - assignments/standalone/{name}
+//	  - pattern: "regex:^assignments/(?P<course>cs101)/(?P<name>hw-\\d+)$"
+//	    branch: "{{.course}}-{{.name}}"
+//	    prBodyTemplate: "See assignments/{{.course}}/{{.name}} for instructions."
+type assignmentPatternEntry struct {
+	Pattern        string `yaml:"pattern"`
+	Branch         string `yaml:"branch"`
+	PRBodyTemplate string `yaml:"prBodyTemplate"`
+}
+
+// UnmarshalYAML lets an assignmentPatternEntry be written as a bare pattern string when it has
+// no branch or PR-body template to configure
+func (e *assignmentPatternEntry) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&e.Pattern)
+	}
+	type plain assignmentPatternEntry
+	return value.Decode((*plain)(e))
+}
+
+// loadAssignmentsRCFile reads and parses constants.AssignmentsRCFileName from repoRoot, returning
+// (nil, nil) if the file doesn't exist -- it's entirely optional
+func loadAssignmentsRCFile(repoRoot string) (*assignmentsRCFile, error) {
+	path := filepath.Join(repoRoot, constants.AssignmentsRCFileName)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", constants.AssignmentsRCFileName, err)
+	}
+
+	var rc assignmentsRCFile
+	if err := yaml.Unmarshal(data, &rc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", constants.AssignmentsRCFileName, err)
+	}
+
+	return &rc, nil
+}
+
+// buildAssignmentPattern picks the assignment pattern source in the same precedence
+// NewConfigFromEnv documents: ASSIGNMENT_GLOB or ASSIGNMENT_REGEX if set (mutually exclusive,
+// checked by the caller), else the .assignmentsrc entries if any were declared, else
+// constants.DefaultAssignmentRegex
+func buildAssignmentPattern(assignmentRegex, assignmentGlob string, fileEntries []assignmentPatternEntry) *regex.Processor {
+	switch {
+	case assignmentGlob != "":
+		return regex.NewFromCommaSeparated(assignmentGlob)
+	case assignmentRegex != "":
+		return regex.NewFromCommaSeparated(assignmentRegex)
+	case len(fileEntries) > 0:
+		p := regex.New()
+		p.AddPatternConfigs(toPatternConfigs(fileEntries))
+		return p
+	default:
+		return regex.NewFromCommaSeparated(constants.DefaultAssignmentRegex)
+	}
+}
+
+// toPatternConfigs adapts .assignmentsrc's YAML-shaped entries to regex.PatternConfig
+func toPatternConfigs(entries []assignmentPatternEntry) []regex.PatternConfig {
+	configs := make([]regex.PatternConfig, len(entries))
+	for i, entry := range entries {
+		configs[i] = regex.PatternConfig{
+			Pattern:        entry.Pattern,
+			BranchTemplate: entry.Branch,
+			BodyTemplate:   entry.PRBodyTemplate,
+		}
+	}
+	return configs
+}
+
+// buildLanguageRoots builds an instructions.LanguageRoots from the .assignmentsrc
+// contentLanguages/defaultContentLanguage fields, returning (nil, nil) if no content languages
+// were declared -- multi-language content is entirely optional.
+func buildLanguageRoots(contentLanguages map[string]string, defaultContentLanguage string) (*instructions.LanguageRoots, error) {
+	if len(contentLanguages) == 0 {
+		return nil, nil
+	}
+	return instructions.NewLanguageRoots(contentLanguages, defaultContentLanguage)
+}
+
+// buildExcludeMatcher builds an ExcludeMatcher from envValue if set, else from fileValues (joined
+// as a single comma-separated pattern list), else an empty matcher that excludes nothing
+func buildExcludeMatcher(envValue string, fileValues []string) (*regex.ExcludeMatcher, error) {
+	if envValue != "" {
+		return regex.NewExcludeMatcher(envValue)
+	}
+	if len(fileValues) > 0 {
+		return regex.NewExcludeMatcher(strings.Join(fileValues, ","))
+	}
+	return regex.NewExcludeMatcher("")
+}
@@ -0,0 +1,144 @@
+package creator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"assignment-pull-request/internal/constants"
+)
+
+// withAssignmentsRCFile writes contents to .assignmentsrc in a fresh temp directory, chdirs into
+// it for the duration of the test, and restores the original working directory on cleanup
+func withAssignmentsRCFile(t *testing.T, contents string) {
+	t.Helper()
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+
+	tempDir := t.TempDir()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Logf("Warning: failed to restore original directory: %v", err)
+		}
+	})
+
+	if contents == "" {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, constants.AssignmentsRCFileName), []byte(contents), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", constants.AssignmentsRCFileName, err)
+	}
+}
+
+// TestNewConfigFromEnvAssignmentsRCFile covers merging the optional .assignmentsrc file with
+// environment variables: file-only config, env-only config, env overriding the file, and a
+// malformed file surfacing a clear error.
+func TestNewConfigFromEnvAssignmentsRCFile(t *testing.T) {
+	defer cleanupEnv()
+
+	t.Run("file-only config populates patterns and defaults", func(t *testing.T) {
+		cleanupEnv()
+		os.Setenv("GITHUB_TOKEN", "test-token")
+		os.Setenv("GITHUB_REPOSITORY", "owner/repo")
+		withAssignmentsRCFile(t, `
+rootPatterns:
+  - "archive"
+assignmentPatterns:
+  - pattern: "assignments/{course}/{week}/{name}"
+    branch: "{{.course}}-wk{{.week}}-{{.name}}"
+excludePatterns:
+  - "**/solutions/**"
+defaultBranch: "develop"
+`)
+
+		config, err := NewConfigFromEnv()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.defaultBranch != "develop" {
+			t.Errorf("Expected defaultBranch='develop', got=%s", config.defaultBranch)
+		}
+
+		patterns := config.assignmentPattern.Patterns()
+		if len(patterns) != 1 || patterns[0] != "assignments/{course}/{week}/{name}" {
+			t.Errorf("Expected pattern from file, got %v", patterns)
+		}
+		templates := config.assignmentPattern.Templates()
+		if len(templates) != 1 || templates[0] != "{{.course}}-wk{{.week}}-{{.name}}" {
+			t.Errorf("Expected branch template from file, got %v", templates)
+		}
+
+		if !config.assignmentExclude.Excludes("hw-1/solutions/answer.go") {
+			t.Error("Expected excludePatterns from file to exclude a matching path")
+		}
+		if !config.rootExclude.Excludes("archive") {
+			t.Error("Expected rootPatterns from file to exclude a matching root folder")
+		}
+	})
+
+	t.Run("env-only config ignores an absent file", func(t *testing.T) {
+		cleanupEnv()
+		os.Setenv("GITHUB_TOKEN", "test-token")
+		os.Setenv("GITHUB_REPOSITORY", "owner/repo")
+		os.Setenv("ASSIGNMENT_GLOB", "assignments/{name}")
+		os.Setenv("DEFAULT_BRANCH", "trunk")
+		withAssignmentsRCFile(t, "")
+
+		config, err := NewConfigFromEnv()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.defaultBranch != "trunk" {
+			t.Errorf("Expected defaultBranch='trunk', got=%s", config.defaultBranch)
+		}
+		patterns := config.assignmentPattern.Patterns()
+		if len(patterns) != 1 || patterns[0] != "assignments/{name}" {
+			t.Errorf("Expected env-provided pattern, got %v", patterns)
+		}
+	})
+
+	t.Run("env overrides the file field-by-field", func(t *testing.T) {
+		cleanupEnv()
+		os.Setenv("GITHUB_TOKEN", "test-token")
+		os.Setenv("GITHUB_REPOSITORY", "owner/repo")
+		os.Setenv("ASSIGNMENT_GLOB", "coursework/{name}")
+		os.Setenv("DEFAULT_BRANCH", "trunk")
+		withAssignmentsRCFile(t, `
+assignmentPatterns:
+  - "assignments/{name}"
+defaultBranch: "develop"
+`)
+
+		config, err := NewConfigFromEnv()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.defaultBranch != "trunk" {
+			t.Errorf("Expected env DEFAULT_BRANCH to win, got=%s", config.defaultBranch)
+		}
+		patterns := config.assignmentPattern.Patterns()
+		if len(patterns) != 1 || patterns[0] != "coursework/{name}" {
+			t.Errorf("Expected env ASSIGNMENT_GLOB to win over the file's assignmentPatterns, got %v", patterns)
+		}
+	})
+
+	t.Run("malformed file surfaces a clear error", func(t *testing.T) {
+		cleanupEnv()
+		os.Setenv("GITHUB_TOKEN", "test-token")
+		os.Setenv("GITHUB_REPOSITORY", "owner/repo")
+		withAssignmentsRCFile(t, "assignmentPatterns: [unterminated")
+
+		if _, err := NewConfigFromEnv(); err == nil {
+			t.Error("Expected an error for a malformed .assignmentsrc file")
+		}
+	})
+}
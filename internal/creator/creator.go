@@ -1,18 +1,28 @@
 package creator
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 
 	"assignment-pull-request/internal/assignment"
+	"assignment-pull-request/internal/blob"
 	"assignment-pull-request/internal/constants"
+	"assignment-pull-request/internal/errs"
+	"assignment-pull-request/internal/fsx"
 	"assignment-pull-request/internal/git"
 	"assignment-pull-request/internal/github"
+	"assignment-pull-request/internal/images"
 	"assignment-pull-request/internal/instructions"
 	"assignment-pull-request/internal/regex"
+	"assignment-pull-request/internal/sanitize"
 )
 
 // PullRequestInfo holds information about a created pull request
@@ -21,13 +31,57 @@ type PullRequestInfo struct {
 	Title  string `json:"title"`
 }
 
+// FailedAssignment records an assignment whose branch, push, or pull request failed with a
+// classified error (see internal/errs), surfaced as the failed-assignments GitHub Actions output
+// so the workflow can post a comment or fail the run selectively instead of only seeing it in
+// logs.
+type FailedAssignment struct {
+	Path string    `json:"path"`
+	Code errs.Code `json:"code"`
+	Hint string    `json:"hint"`
+}
+
 // Config holds configuration for the PR creator
 type Config struct {
 	gitHubToken       string
 	assignmentPattern *regex.Processor
+	assignmentExclude *regex.ExcludeMatcher
+	rootExclude       *regex.ExcludeMatcher
 	repositoryName    string
 	defaultBranch     string
 	dryRun            bool
+	gitBackend        string
+	languageRoots     *instructions.LanguageRoots
+	preferredLanguage string
+
+	// workerPoolSize bounds how many assignments processAssignments' Phase 2 (local processing)
+	// and Phase 4 (PR creation) work on concurrently, each in its own git worktree.
+	workerPoolSize int
+
+	// templateURL, if set, is the blob.Storage URL createReadme/createPullRequestBody fetch
+	// per-assignment template overrides from (see constants.EnvAssignmentTemplateURL).
+	templateURL string
+
+	// assignmentOverrides, if the optional constants.AssignmentPRRCFileName file was found, is
+	// wired into the assignment.Processor built by NewWithConfig as its Overrides resolver.
+	assignmentOverrides *assignmentOverridesFile
+
+	// keepOnFailure, if set (see constants.EnvKeepOnFailure), skips Creator.rollback after a
+	// failed run so an instructor can inspect the branches/PRs a broken run left behind.
+	keepOnFailure bool
+
+	// prTitleTemplate and commitMessageTemplate, if set (see constants.EnvPRTitleTemplate,
+	// EnvCommitMessageTemplate), are text/templates rendered against an assignment's matching
+	// pattern's named capture groups (see assignment.Processor.MatchGroupsForPath) to produce its
+	// pull request title and initial README commit message, respectively.
+	prTitleTemplate       string
+	commitMessageTemplate string
+
+	// fs is the filesystem createReadme reads and writes README content through. Left nil by
+	// every exported constructor except NewFromEnvWithFS, and NewWithConfig defaults a nil fs to
+	// fsx.NewOsFs() -- tests that want to avoid touching the real filesystem go through
+	// NewFromEnvWithFS with an *fsx.MemFs instead.
+	fs fsx.Fs
 }
 
 // NewConfig creates a new Config with the given parameters
@@ -38,29 +92,205 @@ func NewConfig(gitHubToken, repositoryName, defaultBranch string, assignmentRege
 		defaultBranch:     defaultBranch,
 		assignmentPattern: regex.NewWithPatterns(assignmentRegex),
 		dryRun:            dryRun,
+		gitBackend:        constants.DefaultGitBackend,
+		workerPoolSize:    runtime.NumCPU(),
 	}
 }
 
-// NewConfigFromEnv creates a new Config from environment variables
-func NewConfigFromEnv() *Config {
+// NewConfigFromEnv creates a new Config from environment variables, merged with the optional
+// constants.AssignmentsRCFileName config file at the repository root (see assignmentsrc.go): a
+// field set via environment variable always wins over the same field declared in the file, so an
+// instructor can check in shared defaults and still override one of them for a single run.
+//
+// ASSIGNMENT_REGEX and ASSIGNMENT_GLOB are mutually exclusive ways of supplying the assignment
+// pattern: regex.Processor already treats an untagged pattern as a gitignore-style glob (literal
+// segments, "?", "*" for one path segment, "**" across "/", and "{name}" named wildcards) and a
+// "regex:"-tagged one as a raw regex, so ASSIGNMENT_GLOB is sugar for "write your patterns
+// without the regex: tag" rather than a separate parsing path. Either one, if set, is used as-is
+// and the file's assignmentPatterns are ignored entirely.
+//
+// ASSIGNMENT_EXCLUDE_REGEX and ASSIGNMENTS_ROOT_EXCLUDE_REGEX are comma-separated lists of
+// patterns (same regex:/glob: tagging, same "!" negation as regex.ExcludeMatcher) that prune a
+// directory before it's ever tested against the assignment pattern: the former against every
+// candidate directory, the latter against top-level root folder names only. The file's
+// excludePatterns/rootPatterns are used in their place when the corresponding env var is unset.
+//
+// PATTERN_SYNTAX (regex.SyntaxRegex or regex.SyntaxGlob) picks how an untagged
+// ASSIGNMENT_REGEX/ASSIGNMENT_GLOB pattern is interpreted when it carries no "regex:"/"glob:"
+// prefix of its own -- see regex.Processor.SetDefaultSyntax. Unset preserves today's default of
+// treating an untagged pattern as a glob.
+//
+// BRANCH_TEMPLATE is a text/template string (e.g. "{{.course}}-wk{{.week}}") applied to any
+// assignment pattern that has no per-pattern template of its own -- either the "pattern =>
+// template" syntax on ASSIGNMENT_REGEX/ASSIGNMENT_GLOB or a .assignmentsrc entry's branch field --
+// preserving today's alphabetical named-group auto-join as the default when neither is set.
+//
+// CONTENT_LANGUAGE picks the preferred translation among the file's contentLanguages, if any are
+// declared; see instructions.Processor.PreferredLanguage. There is no env var equivalent of
+// contentLanguages itself -- mapping language codes to content directories only makes sense as a
+// checked-in, multi-line config, not a single env var.
+//
+// WORKER_POOL_SIZE caps the number of assignments processAssignments works on concurrently,
+// falling back to runtime.NumCPU() when unset or not a positive integer.
+//
+// ASSIGNMENT_TEMPLATE_URL, if set, points createReadme/createPullRequestBody at a blob.Storage
+// (file://, gs://, or s3://) holding per-assignment README.tmpl/PR_BODY.tmpl overrides, so an
+// instructor can host a canonical starter README and PR-body template shared across many
+// classroom repos instead of relying only on the built-in generic defaults.
+//
+// PR_TITLE_TEMPLATE and COMMIT_MESSAGE_TEMPLATE are text/template strings rendered against an
+// assignment's matching pattern's named capture groups (the same machinery BRANCH_TEMPLATE uses,
+// see assignment.Processor.MatchGroupsForPath), producing its pull request title and initial
+// README commit message respectively. There's no equivalent BRANCH_NAME_TEMPLATE here since
+// BRANCH_TEMPLATE (see regex.Processor.SetDefaultTemplate) already covers that case.
+//
+// KEEP_ON_FAILURE, if set to a truthy value, skips Creator.rollback when processAssignments
+// returns an error, leaving whatever branches/pushes the run already made in place for an
+// instructor to inspect instead of cleaning them up automatically.
+//
+// The optional constants.AssignmentPRRCFileName file (.assignment-pr.yaml) is loaded separately
+// from .assignmentsrc and layered the other way around: since it exists purely to override
+// individual assignments' PR creation (branch template, PR title, base branch, skip, reviewers,
+// labels, milestone), it has no environment-variable equivalent of its own to be overridden by --
+// NewWithConfig wires it into the assignment.Processor as an assignment.OverrideResolver, applied
+// per assignment on top of whatever pattern/template the rest of this function resolves. There is
+// no further "flags" layer above the file, since this binary (cmd/assignment-pr-creator) takes no
+// command-line flags at all.
+func NewConfigFromEnv() (*Config, error) {
+	rc, err := loadAssignmentsRCFile(".")
+	if err != nil {
+		return nil, err
+	}
+
+	prrc, err := loadAssignmentPRRCFile(".")
+	if err != nil {
+		return nil, err
+	}
+	var fileEntries []assignmentPatternEntry
+	var fileRootPatterns, fileExcludePatterns []string
+	var fileDefaultBranch string
+	var fileContentLanguages map[string]string
+	var fileDefaultContentLanguage string
+	if rc != nil {
+		fileEntries = rc.AssignmentPatterns
+		fileRootPatterns = rc.RootPatterns
+		fileExcludePatterns = rc.ExcludePatterns
+		fileDefaultBranch = rc.DefaultBranch
+		fileContentLanguages = rc.ContentLanguages
+		fileDefaultContentLanguage = rc.DefaultContentLanguage
+	}
+
+	assignmentRegex := os.Getenv(constants.EnvAssignmentRegex)
+	assignmentGlob := os.Getenv(constants.EnvAssignmentGlob)
+	if assignmentRegex != "" && assignmentGlob != "" {
+		return nil, fmt.Errorf("%s and %s are mutually exclusive; set only one", constants.EnvAssignmentRegex, constants.EnvAssignmentGlob)
+	}
+
+	assignmentExclude, err := buildExcludeMatcher(os.Getenv(constants.EnvAssignmentExcludeRegex), fileExcludePatterns)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", constants.EnvAssignmentExcludeRegex, err)
+	}
+
+	rootExclude, err := buildExcludeMatcher(os.Getenv(constants.EnvAssignmentsRootExcludeRegex), fileRootPatterns)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", constants.EnvAssignmentsRootExcludeRegex, err)
+	}
+
+	defaultBranch := constants.DefaultBranch
+	if fileDefaultBranch != "" {
+		defaultBranch = fileDefaultBranch
+	}
+	defaultBranch = getEnvWithDefault(constants.EnvDefaultBranch, defaultBranch)
+
+	assignmentPattern := buildAssignmentPattern(assignmentRegex, assignmentGlob, fileEntries)
+	assignmentPattern.SetDefaultTemplate(os.Getenv(constants.EnvBranchTemplate))
+	assignmentPattern.SetDefaultSyntax(os.Getenv(constants.EnvPatternSyntax))
+
+	languageRoots, err := buildLanguageRoots(fileContentLanguages, fileDefaultContentLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contentLanguages in %s: %w", constants.AssignmentsRCFileName, err)
+	}
+
 	return &Config{
-		gitHubToken:       os.Getenv(constants.EnvGitHubToken),
-		repositoryName:    os.Getenv(constants.EnvGitHubRepository),
-		defaultBranch:     getEnvWithDefault(constants.EnvDefaultBranch, constants.DefaultBranch),
-		assignmentPattern: regex.NewFromCommaSeparated(getEnvWithDefault(constants.EnvAssignmentRegex, constants.DefaultAssignmentRegex)),
-		dryRun:            isDryRun(getEnvWithDefault(constants.EnvDryRun, constants.DefaultDryRun)),
+		gitHubToken:           os.Getenv(constants.EnvGitHubToken),
+		repositoryName:        os.Getenv(constants.EnvGitHubRepository),
+		defaultBranch:         defaultBranch,
+		assignmentPattern:     assignmentPattern,
+		assignmentExclude:     assignmentExclude,
+		rootExclude:           rootExclude,
+		dryRun:                isDryRun(getEnvWithDefault(constants.EnvDryRun, constants.DefaultDryRun)),
+		gitBackend:            getEnvWithDefault(constants.EnvGitBackend, constants.DefaultGitBackend),
+		languageRoots:         languageRoots,
+		preferredLanguage:     os.Getenv(constants.EnvContentLanguage),
+		workerPoolSize:        getWorkerPoolSize(os.Getenv(constants.EnvWorkerPoolSize)),
+		templateURL:           os.Getenv(constants.EnvAssignmentTemplateURL),
+		assignmentOverrides:   prrc,
+		keepOnFailure:         isDryRun(os.Getenv(constants.EnvKeepOnFailure)),
+		prTitleTemplate:       os.Getenv(constants.EnvPRTitleTemplate),
+		commitMessageTemplate: os.Getenv(constants.EnvCommitMessageTemplate),
+	}, nil
+}
+
+// getWorkerPoolSize parses workerPoolSizeStr as a positive integer, falling back to
+// runtime.NumCPU() when it's empty or not a positive integer.
+func getWorkerPoolSize(workerPoolSizeStr string) int {
+	size, err := strconv.Atoi(workerPoolSizeStr)
+	if err != nil || size <= 0 {
+		return runtime.NumCPU()
 	}
+	return size
 }
 
 // Creator is the main Assignment PR Creator
 type Creator struct {
 	config              *Config
-	gitOps              *git.Operations
+	gitOps              git.Client
 	githubClient        *github.Client
 	assignmentProcessor *assignment.Processor
+	imageProcessor      *images.Processor
+
+	// templateStore, if configured (see constants.EnvAssignmentTemplateURL), is consulted by
+	// templateOverride for instructor-hosted README/PR-body template overrides before falling
+	// back to the built-in defaults. Left nil when config.templateURL is unset.
+	templateStore blob.Storage
+
+	// mu guards createdBranches, createdPullRequests and pendingPushes, which are appended to
+	// concurrently by the Phase 2/Phase 4 worker pools in processAssignments.
+	mu                  sync.Mutex
 	createdBranches     []string
 	createdPullRequests []PullRequestInfo
 	pendingPushes       []string
+	failedAssignments   []FailedAssignment
+
+	// createdDirectories records assignment directories that didn't exist on disk before this run
+	// created them (see createReadme), so rollback can remove whatever's left of them -- usually
+	// nothing, since switching back to the default branch already deletes the tracked README, but
+	// git doesn't clean up the directory itself if that leaves it empty.
+	createdDirectories []string
+
+	// plan, if non-nil, collects the Actions a dry run would have performed (see Creator.Plan,
+	// recordPlanAction). Left nil during a normal Run, so recordPlanAction's append is skipped
+	// and nothing is retained past the usual "[DRY RUN] Would ..." log line.
+	plan *Plan
+
+	// SanitizerConfig configures the HTML sanitization policy (see sanitize.NewPolicy) applied
+	// to every pull request body before it's posted. Exported, unlike the rest of Creator's
+	// configuration, so a downstream consumer can tighten or loosen the default policy -- e.g.
+	// allowlist an extra URL scheme -- without forking this package.
+	SanitizerConfig sanitize.Config
+
+	// RawBaseURL, if set, points rewritten image links at a rawassets.Handler serving this
+	// origin instead of the in-tree GitHub blob URL (see instructions.Processor.RawBaseURL).
+	RawBaseURL string
+
+	// BasePath, if set, is prefixed onto every path createReadme resolves against fs -- the
+	// fsx equivalent of chdir-ing into a sandbox root, for a caller (e.g. a test using
+	// fsx.NewMemFs) that wants every assignment path rooted somewhere other than fs's own root.
+	BasePath string
+
+	// fs is the filesystem createReadme performs its file I/O through, defaulting to
+	// fsx.NewOsFs() in NewWithConfig when config.fs is unset.
+	fs fsx.Fs
 }
 
 // NewWithConfig creates a new Assignment PR Creator with the given configuration
@@ -77,54 +307,205 @@ func NewWithConfig(config *Config) (*Creator, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create assignment processor: %w", err)
 	}
+	assignmentProc.ExcludePattern = config.assignmentExclude
+	assignmentProc.RootExcludePattern = config.rootExclude
+	if config.assignmentOverrides != nil {
+		assignmentProc.Overrides = &assignmentOverridesResolver{file: config.assignmentOverrides}
+	}
+
+	gitOps, err := git.NewClientForBackend(config.gitBackend, ".", config.dryRun, config.gitHubToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git client: %w", err)
+	}
+
+	var templateStore blob.Storage
+	if config.templateURL != "" {
+		templateStore, err = blob.NewFromURL(config.templateURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open template store: %w", err)
+		}
+	}
+
+	fs := config.fs
+	if fs == nil {
+		fs = fsx.NewOsFs()
+	}
 
 	creator := &Creator{
 		config:              config,
-		gitOps:              git.NewOperations(config.dryRun),
+		gitOps:              gitOps,
 		githubClient:        github.NewClient(config.gitHubToken, config.repositoryName, config.dryRun),
 		assignmentProcessor: assignmentProc,
+		imageProcessor:      images.NewProcessor(),
+		templateStore:       templateStore,
 		createdBranches:     make([]string, 0),
 		createdPullRequests: make([]PullRequestInfo, 0),
+		fs:                  fs,
 	}
 
 	return creator, nil
 }
 
+// templateOverride returns the instructor-hosted override named templateName for assignmentPath
+// (see constants.ReadmeTemplateName, PRBodyTemplateName), and whether one was found. It reports
+// not-found (rather than an error) whenever templateStore is unset or the lookup fails, since a
+// missing override just means "use the built-in default" to every caller.
+func (c *Creator) templateOverride(assignmentPath, templateName string) (string, bool) {
+	if c.templateStore == nil {
+		return "", false
+	}
+	data, err := c.templateStore.Read(filepath.Join(assignmentPath, templateName))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
 // NewFromEnv creates a new Assignment PR Creator with environment variables
 func NewFromEnv() (*Creator, error) {
-	config := NewConfigFromEnv()
+	config, err := NewConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
 	return NewWithConfig(config)
 }
 
-// createBranch creates a new branch from the default branch locally
-func (c *Creator) createBranch(branchName string) error {
+// NewFromEnvWithFS is NewFromEnv, but createReadme performs its file I/O through fs instead of
+// fsx.NewOsFs() -- for a caller that wants to exercise a run against an fsx.NewMemFs() fixture
+// instead of the real filesystem.
+func NewFromEnvWithFS(fs fsx.Fs) (*Creator, error) {
+	config, err := NewConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	config.fs = fs
+	return NewWithConfig(config)
+}
+
+// addCreatedBranch records branchName as created and pending push, guarded by mu so it's safe to
+// call from a Phase 2 worker pool goroutine.
+func (c *Creator) addCreatedBranch(branchName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.createdBranches = append(c.createdBranches, branchName)
+	c.pendingPushes = append(c.pendingPushes, branchName)
+}
+
+// addCreatedPullRequest records info as created, guarded by mu so it's safe to call from a
+// Phase 4 worker pool goroutine.
+func (c *Creator) addCreatedPullRequest(info PullRequestInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.createdPullRequests = append(c.createdPullRequests, info)
+}
+
+// takePendingPushes returns the accumulated pendingPushes and clears it, guarded by mu for
+// consistency with the Phase 2 worker pool even though Phase 3 itself runs serially.
+func (c *Creator) takePendingPushes() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pushes := c.pendingPushes
+	c.pendingPushes = nil
+	return pushes
+}
+
+// addCreatedDirectory records dir as newly created by this run, guarded by mu so it's safe to
+// call from the Phase 2 worker pool.
+func (c *Creator) addCreatedDirectory(dir string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.createdDirectories = append(c.createdDirectories, dir)
+}
+
+// addFailedAssignment records path's failure for the failed-assignments output, guarded by mu so
+// it's safe to call from the Phase 2/Phase 4 worker pools. err is unwrapped via errors.As into its
+// *errs.HintedError, if it is one, to capture a machine-readable code and remediation hint instead
+// of just the error string.
+func (c *Creator) addFailedAssignment(path string, err error) {
+	failure := FailedAssignment{Path: path}
+
+	var hinted *errs.HintedError
+	if errors.As(err, &hinted) {
+		failure.Code = hinted.Code
+		failure.Hint = hinted.Hint
+	} else {
+		failure.Hint = err.Error()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failedAssignments = append(c.failedAssignments, failure)
+}
+
+// renderAssignmentTemplate renders tmpl against assignmentPath's matching pattern's named capture
+// groups (see assignment.Processor.MatchGroupsForPath), returning fallback unchanged if tmpl is
+// empty, no pattern matches, or rendering fails (logging a warning in that last case, since an
+// empty/unset template is the common case and not worth mentioning).
+func (c *Creator) renderAssignmentTemplate(tmpl, assignmentPath, fallback string) string {
+	if tmpl == "" {
+		return fallback
+	}
+
+	groups, ok := c.assignmentProcessor.MatchGroupsForPath(assignmentPath)
+	if !ok {
+		return fallback
+	}
+
+	rendered, err := assignment.RenderTemplate(tmpl, groups)
+	if err != nil {
+		fmt.Printf("Warning: failed to render template %q for '%s', using default: %v\n", tmpl, assignmentPath, err)
+		return fallback
+	}
+	return rendered
+}
+
+// createBranch creates a new branch from the default branch locally using gitOps, for the
+// sequential go-git-backend Phase 2 path that processes every assignment in c.gitOps' shared
+// working directory (see processAssignmentLocally's branchPreCreated parameter for the other
+// path, where the branch is already created and checked out by the time this would run).
+func (c *Creator) createBranch(ctx context.Context, gitOps git.Client, branchName string) error {
+	c.recordPlanAction(CreateBranch{Name: branchName, From: c.config.defaultBranch})
+
 	// First, ensure we're on the default branch
-	if err := c.gitOps.SwitchToBranch(c.config.defaultBranch); err != nil {
+	if err := gitOps.Branches().SwitchToBranch(ctx, c.config.defaultBranch); err != nil {
 		return err
 	}
 
 	// Create and switch to new branch
-	if err := c.gitOps.CreateAndSwitchToBranch(branchName); err != nil {
+	if err := gitOps.Branches().CreateAndSwitchToBranch(ctx, branchName); err != nil {
 		return err
 	}
 
-	fmt.Printf("✅ Created branch: %s (local)\n", branchName)
-	c.createdBranches = append(c.createdBranches, branchName)
-	c.pendingPushes = append(c.pendingPushes, branchName)
+	c.recordCreatedBranch(branchName)
 	return nil
 }
 
-// createReadme creates or augments README.md file in the assignment folder locally
-func (c *Creator) createReadme(assignmentPath string) error {
-	readmePath := filepath.Join(assignmentPath, constants.ReadmeFileName)
+// recordCreatedBranch records branchName as created by this run, for Creator.rollback's cleanup
+// and Plan's branch-creation bookkeeping -- shared by createBranch and
+// processAssignmentLocally's branchPreCreated path, which creates the branch by a different
+// route (git worktree add -b) but still needs the same bookkeeping.
+func (c *Creator) recordCreatedBranch(branchName string) {
+	fmt.Printf("✅ Created branch: %s (local)\n", branchName)
+	c.addCreatedBranch(branchName)
+}
+
+// createReadme creates or augments README.md file in the assignment folder locally, using gitOps
+// and resolving assignmentPath under workDir -- "" for the main working directory, or a worker's
+// isolated worktree directory when called from processAssignments' Phase 2 worker pool.
+func (c *Creator) createReadme(ctx context.Context, gitOps git.Client, workDir, assignmentPath string) error {
+	assignmentDir := filepath.Join(c.BasePath, workDir, assignmentPath)
+	readmePath := filepath.Join(assignmentDir, constants.ReadmeFileName)
 
 	// Create assignment directory if it doesn't exist
 	if !c.config.dryRun {
-		if err := os.MkdirAll(assignmentPath, 0755); err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", assignmentPath, err)
+		if _, err := c.fs.Stat(assignmentDir); os.IsNotExist(err) {
+			c.addCreatedDirectory(assignmentDir)
+		}
+		if err := c.fs.MkdirAll(assignmentDir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", assignmentDir, err)
 		}
 	} else {
-		fmt.Printf("[DRY RUN] Would create directory: mkdir -p %s\n", assignmentPath)
+		fmt.Printf("[DRY RUN] Would create directory: mkdir -p %s\n", assignmentDir)
 	}
 
 	// Create processor for content generation
@@ -132,11 +513,13 @@ func (c *Creator) createReadme(assignmentPath string) error {
 
 	// Check if README already exists
 	var readmeContent string
-	if _, err := os.Stat(readmePath); err == nil {
+	readmeExisted := false
+	if _, err := c.fs.Stat(readmePath); err == nil {
+		readmeExisted = true
 		fmt.Printf("README already exists at %s, augmenting...\n", readmePath)
 
 		// Read existing content
-		existingBytes, err := os.ReadFile(readmePath)
+		existingBytes, err := c.fs.ReadFile(readmePath)
 		if err != nil {
 			return fmt.Errorf("failed to read existing README: %w", err)
 		}
@@ -146,63 +529,116 @@ func (c *Creator) createReadme(assignmentPath string) error {
 		readmeContent = instructionsProcessor.AugmentExistingReadmeContent(existingContent)
 
 		if !c.config.dryRun {
-			if err := os.WriteFile(readmePath, []byte(readmeContent), 0644); err != nil {
+			if err := c.fs.WriteFile(readmePath, []byte(readmeContent), 0644); err != nil {
 				return fmt.Errorf("failed to write augmented README: %w", err)
 			}
 			fmt.Printf("✅ Augmented %s at %s (local)\n", constants.ReadmeFileName, readmePath)
 		} else {
+			c.recordPlanAction(WriteFile{Path: readmePath, Bytes: []byte(readmeContent), Mode: 0644})
 			fmt.Printf("[DRY RUN] Would augment README at %s\n", readmePath)
 		}
 	} else {
-		// Use processor to create new README content
-		readmeContent = instructionsProcessor.CreateNewReadmeContent()
+		// Use an instructor-hosted template override if one is configured for this assignment,
+		// otherwise fall back to the processor's generic new-README content.
+		if override, ok := c.templateOverride(assignmentPath, constants.ReadmeTemplateName); ok {
+			readmeContent = override
+		} else {
+			readmeContent = instructionsProcessor.CreateNewReadmeContent()
+		}
 
 		// Write new README
 		if !c.config.dryRun {
-			if err := os.WriteFile(readmePath, []byte(readmeContent), 0644); err != nil {
+			if err := c.fs.WriteFile(readmePath, []byte(readmeContent), 0644); err != nil {
 				return fmt.Errorf("failed to write new README: %w", err)
 			}
 			fmt.Printf("✅ Created %s at %s (local)\n", constants.ReadmeFileName, readmePath)
 		} else {
+			c.recordPlanAction(WriteFile{Path: readmePath, Bytes: []byte(readmeContent), Mode: 0644})
 			fmt.Printf("[DRY RUN] Would create README at %s\n", readmePath)
 		}
 	}
 
-	// Add and commit the README
-	if err := c.gitOps.AddFile(readmePath); err != nil {
+	// Add and commit the README. The path given to git is relative to gitOps' own repo root
+	// (workDir), not the filesystem path used above to read/write it.
+	readmeRelPath := filepath.Join(assignmentPath, constants.ReadmeFileName)
+	if err := gitOps.WorkTree().AddFile(ctx, readmeRelPath); err != nil {
 		return err
 	}
 
-	commitMessage := fmt.Sprintf("Add README for assignment %s", assignmentPath)
-	if _, err := os.Stat(readmePath); err == nil && !c.config.dryRun {
+	commitMessage := c.renderAssignmentTemplate(
+		c.config.commitMessageTemplate, assignmentPath, fmt.Sprintf("Add README for assignment %s", assignmentPath),
+	)
+	if readmeExisted {
 		commitMessage = fmt.Sprintf("Augment README for assignment %s", assignmentPath)
 	}
 
-	return c.gitOps.Commit(commitMessage)
+	return gitOps.Commits().Commit(ctx, commitMessage)
 }
 
-// createPullRequest creates a pull request for the assignment branch using GitHub API
-func (c *Creator) createPullRequest(assignmentPath, branchName string) error {
-	title := branchName
+// createPullRequest creates a pull request for the assignment branch using GitHub API, performing
+// any git work through gitOps in workDir -- a worker's isolated worktree when called from
+// processAssignments' Phase 4 worker pool. override carries any per-assignment PR title, base
+// branch, reviewers, labels, and milestone resolved via .assignment-pr.yaml (see
+// assignment.Override), applied on top of this method's usual defaults.
+func (c *Creator) createPullRequest(ctx context.Context, gitOps git.Client, workDir, assignmentPath, branchName string, override assignment.Override) error {
+	title := c.renderAssignmentTemplate(c.config.prTitleTemplate, assignmentPath, branchName)
+	if override.PRTitle != "" {
+		title = override.PRTitle
+	}
+	baseBranch := c.config.defaultBranch
+	if override.BaseBranch != "" {
+		baseBranch = override.BaseBranch
+	}
 
 	// Try to read README.md file for PR body content
-	body, err := c.createPullRequestBody(assignmentPath)
+	body, processedAssets, err := c.createPullRequestBody(workDir, assignmentPath)
 	if err != nil {
 		return fmt.Errorf("error creating pull request body for '%s': %w", assignmentPath, err)
 	}
 
-	prNumber, err := c.githubClient.CreatePullRequest(title, body, branchName, c.config.defaultBranch)
+	for _, assetPath := range processedAssets {
+		c.recordPlanAction(RewriteImageLink{File: assignmentPath, From: assetPath, To: assetPath})
+	}
+
+	// Commit and push any images the body's pipeline resized/fingerprinted (see
+	// instructions.Processor.ProcessedAssets) so the links rewritten into body resolve once the
+	// PR is visible.
+	if err := c.commitProcessedAssets(ctx, gitOps, branchName, processedAssets); err != nil {
+		fmt.Printf("Warning: failed to commit processed images for %s: %v\n", assignmentPath, err)
+	}
+
+	c.recordPlanAction(OpenPullRequest{Title: title, Head: branchName, Base: baseBranch, Body: body})
+
+	prNumber, err := c.githubClient.CreatePullRequestWithRequest(github.CreatePullRequestRequest{
+		Title:         title,
+		Body:          body,
+		Head:          branchName,
+		Base:          baseBranch,
+		Draft:         override.Draft,
+		Reviewers:     override.Reviewers,
+		TeamReviewers: override.TeamReviewers,
+		Labels:        override.Labels,
+		Assignees:     override.Assignees,
+	})
 	if err != nil {
 		return fmt.Errorf("error creating pull request for '%s': %w", assignmentPath, err)
 	}
 
-	c.createdPullRequests = append(c.createdPullRequests, PullRequestInfo{
+	c.addCreatedPullRequest(PullRequestInfo{
 		Number: prNumber,
 		Title:  title,
 	})
 
+	// Milestone isn't part of CreatePullRequestWithRequest's request (it takes a milestone number,
+	// while an override names one by title), so it's still applied as a follow-up call.
+	if override.Milestone != "" {
+		if err := c.githubClient.SetMilestone(prNumber, override.Milestone); err != nil {
+			fmt.Printf("Warning: failed to set milestone for %s: %v\n", prNumber, err)
+		}
+	}
+
 	// Add PR link to README after the branch has been pushed
-	if err := c.addPullRequestLinkAfterPush(assignmentPath, branchName, prNumber); err != nil {
+	if err := c.addPullRequestLinkAfterPush(ctx, gitOps, workDir, assignmentPath, branchName, prNumber); err != nil {
 		fmt.Printf("Warning: failed to add PR link after push for %s: %v\n", prNumber, err)
 		// Continue even if PR link addition fails
 	}
@@ -213,20 +649,20 @@ func (c *Creator) createPullRequest(assignmentPath, branchName string) error {
 }
 
 // addPullRequestLinkAfterPush adds PR link to README after the branch has been pushed
-func (c *Creator) addPullRequestLinkAfterPush(assignmentPath, branchName, prNumber string) error {
+func (c *Creator) addPullRequestLinkAfterPush(ctx context.Context, gitOps git.Client, workDir, assignmentPath, branchName, prNumber string) error {
 	// First, switch to the correct branch
-	if err := c.gitOps.SwitchToBranch(branchName); err != nil {
+	if err := gitOps.Branches().SwitchToBranch(ctx, branchName); err != nil {
 		return fmt.Errorf("failed to switch to branch %s: %w", branchName, err)
 	}
 
 	// Add PR link to the top of the README
-	if err := c.addPullRequestLinkToReadme(assignmentPath, branchName, prNumber); err != nil {
+	if err := c.addPullRequestLinkToReadme(ctx, gitOps, workDir, assignmentPath, branchName, prNumber); err != nil {
 		fmt.Printf("Warning: failed to add PR link to README: %v\n", err)
 		return err
 	}
 
 	// Push only the specific branch to avoid conflicts with main
-	if err := c.gitOps.PushBranch(branchName); err != nil {
+	if err := gitOps.Remotes().PushBranch(ctx, branchName); err != nil {
 		fmt.Printf("Warning: failed to push branch %s with PR link update: %v\n", branchName, err)
 		return err
 	}
@@ -247,15 +683,75 @@ func (c *Creator) mergePullRequestAfterLink(prNumber, title string) error {
 	return nil
 }
 
-// createPullRequestBody creates the pull request body content using the instructions processor
-func (c *Creator) createPullRequestBody(assignmentPath string) (string, error) {
-	instructionsProcessor := instructions.NewWithDefaults(c.config.defaultBranch, assignmentPath)
-	return instructionsProcessor.CreatePullRequestBody()
+// createPullRequestBody creates the pull request body content, preferring the PR-body template
+// configured (via .assignmentsrc) for whichever assignment pattern matched assignmentPath, and
+// otherwise falling back to the instructions processor's README-derived content -- sanitized
+// either way (see sanitize.NewPolicy) since both sources are assignment-authored content.
+// workDir roots where the processor actually reads/writes files on disk (see
+// instructions.Processor.Root), while assignmentPath itself stays repo-relative so generated
+// links aren't polluted by a worker's worktree directory. The second return value lists any new
+// image artifacts written to disk while building that content (see
+// instructions.Processor.ProcessedAssets), empty when a template was used instead.
+func (c *Creator) createPullRequestBody(workDir, assignmentPath string) (string, []string, error) {
+	sanitizer := sanitize.New(c.SanitizerConfig)
+
+	if body, ok := c.assignmentProcessor.ExtractBodyFromPath(assignmentPath); ok {
+		return sanitizer.Sanitize(body), nil, nil
+	}
+
+	if override, ok := c.templateOverride(assignmentPath, constants.PRBodyTemplateName); ok {
+		return sanitizer.Sanitize(override), nil, nil
+	}
+
+	instructionsProcessor := instructions.NewWithRepository(c.config.defaultBranch, assignmentPath, c.config.repositoryName)
+	instructionsProcessor.Languages = c.config.languageRoots
+	instructionsProcessor.PreferredLanguage = c.config.preferredLanguage
+	instructionsProcessor.Images = c.imageProcessor
+	instructionsProcessor.RawBaseURL = c.RawBaseURL
+	instructionsProcessor.Root = workDir
+	body, err := instructionsProcessor.CreatePullRequestBody()
+	if err != nil {
+		return "", nil, err
+	}
+	return sanitizer.Sanitize(body), instructionsProcessor.ProcessedAssets, nil
 }
 
-// addPullRequestLinkToReadme adds a link to the pull request at the top of the README file
-func (c *Creator) addPullRequestLinkToReadme(assignmentPath, branchName, prNumber string) error {
-	readmePath := filepath.Join(assignmentPath, constants.ReadmeFileName)
+// commitProcessedAssets stages and commits any new image artifacts produced while building a
+// pull request body (see createPullRequestBody) using gitOps, then pushes branchName again so the
+// links rewritten into the body resolve as soon as the PR is visible.
+func (c *Creator) commitProcessedAssets(ctx context.Context, gitOps git.Client, branchName string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	if err := gitOps.Branches().SwitchToBranch(ctx, branchName); err != nil {
+		return fmt.Errorf("failed to switch to branch %s: %w", branchName, err)
+	}
+
+	for _, path := range paths {
+		if err := gitOps.WorkTree().AddFile(ctx, path); err != nil {
+			return fmt.Errorf("failed to add processed image %s: %w", path, err)
+		}
+	}
+
+	commitMessage := fmt.Sprintf("Add %d processed image(s) for pull request body", len(paths))
+	if err := gitOps.Commits().Commit(ctx, commitMessage); err != nil {
+		return fmt.Errorf("failed to commit processed images: %w", err)
+	}
+
+	if err := gitOps.Remotes().PushBranch(ctx, branchName); err != nil {
+		return fmt.Errorf("failed to push processed images for branch %s: %w", branchName, err)
+	}
+
+	fmt.Printf("✅ Committed and pushed %d processed image(s) for %s\n", len(paths), branchName)
+	return nil
+}
+
+// addPullRequestLinkToReadme adds a link to the pull request at the top of the README file, using
+// gitOps and resolving assignmentPath under workDir the same way createReadme does.
+func (c *Creator) addPullRequestLinkToReadme(ctx context.Context, gitOps git.Client, workDir, assignmentPath, branchName, prNumber string) error {
+	readmeRelPath := filepath.Join(assignmentPath, constants.ReadmeFileName)
+	readmePath := filepath.Join(workDir, readmeRelPath)
 
 	// Check if README exists
 	if _, err := os.Stat(readmePath); os.IsNotExist(err) {
@@ -281,12 +777,12 @@ func (c *Creator) addPullRequestLinkToReadme(assignmentPath, branchName, prNumbe
 		fmt.Printf("✅ Added PR link %s to README at %s\n", prNumber, readmePath)
 
 		// Add and commit the updated README
-		if err := c.gitOps.AddFile(readmePath); err != nil {
+		if err := gitOps.WorkTree().AddFile(ctx, readmeRelPath); err != nil {
 			return fmt.Errorf("failed to add updated README to git: %w", err)
 		}
 
 		commitMessage := fmt.Sprintf("Add pull request link %s to README", prNumber)
-		if err := c.gitOps.Commit(commitMessage); err != nil {
+		if err := gitOps.Commits().Commit(ctx, commitMessage); err != nil {
 			return fmt.Errorf("failed to commit updated README: %w", err)
 		}
 	} else {
@@ -296,8 +792,155 @@ func (c *Creator) addPullRequestLinkToReadme(assignmentPath, branchName, prNumbe
 	return nil
 }
 
+// runAssignmentWorkerPool runs work for each item in jobs across a bounded pool of goroutines
+// sized c.config.workerPoolSize, dispatching one goroutine per job and collecting the first
+// error, per the worker-pool pattern used for Phase 2/Phase 4 of processAssignments.
+func (c *Creator) runAssignmentWorkerPool(ctx context.Context, jobs []assignment.Info, work func(ctx context.Context, job assignment.Info) error) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	poolSize := c.config.workerPoolSize
+	if poolSize <= 0 {
+		poolSize = 1
+	}
+
+	sem := make(chan struct{}, poolSize)
+	errs := make(chan error, len(jobs))
+	var wg sync.WaitGroup
+
+	for _, job := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(job assignment.Info) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs <- work(ctx, job)
+		}(job)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	var firstErr error
+	for err := range errs {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// processAssignmentLocally creates branchName's README using gitOps, rooted at workDir.
+// branchPreCreated is false for the sequential go-git-backend Phase 2 path, where branchName
+// still needs to be created via createBranch; it's true for processAssignmentInWorktree's path,
+// where CreateWorktreeForNewBranch already created and checked out branchName in this worktree --
+// re-running createBranch there would fail (git refuses to check the default branch out a second
+// time in the primary worktree, and branchName already exists), so only the bookkeeping runs.
+// Failures creating the branch or README are logged and skipped rather than propagated, the same
+// per-assignment continue-on-error behavior processAssignments had before Phase 2 was pooled.
+func (c *Creator) processAssignmentLocally(ctx context.Context, gitOps git.Client, workDir string, assignmentInfo assignment.Info, branchPreCreated bool) error {
+	assignmentPath := assignmentInfo.Path
+	branchName := assignmentInfo.BranchName
+
+	fmt.Printf("\nProcessing assignment: %s\n", assignmentPath)
+	fmt.Printf("Branch '%s' doesn't exist anywhere and no PR exists, creating branch...\n", branchName)
+
+	if branchPreCreated {
+		c.recordPlanAction(CreateBranch{Name: branchName, From: c.config.defaultBranch})
+		c.recordCreatedBranch(branchName)
+	} else if err := c.createBranch(ctx, gitOps, branchName); err != nil {
+		fmt.Printf("❌ Failed to create branch '%s', skipping: %v\n", branchName, err)
+		return nil
+	}
+
+	fmt.Printf("Creating README content for assignment '%s'...\n", assignmentPath)
+	if err := c.createReadme(ctx, gitOps, workDir, assignmentPath); err != nil {
+		fmt.Printf("❌ Failed to create README for '%s', skipping: %v\n", assignmentPath, err)
+	}
+
+	return nil
+}
+
+// processAssignmentInWorktree isolates processAssignmentLocally in its own linked git worktree so
+// Phase 2's worker pool can run concurrently without racing checkouts on the shared working
+// directory. Only the worktree machinery itself (CreateWorktreeForNewBranch, RemoveWorktree,
+// InWorktree) returns an error here for runAssignmentWorkerPool to collect -- per-assignment
+// branch/README failures stay non-fatal inside processAssignmentLocally.
+func (c *Creator) processAssignmentInWorktree(ctx context.Context, assignmentInfo assignment.Info) error {
+	branchName := assignmentInfo.BranchName
+
+	workDir, err := os.MkdirTemp("", "assignment-worktree-*")
+	if err != nil {
+		return fmt.Errorf("failed to create worktree directory for branch '%s': %w", branchName, err)
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := c.gitOps.Branches().CreateWorktreeForNewBranch(ctx, workDir, branchName, c.config.defaultBranch); err != nil {
+		return fmt.Errorf("failed to add worktree for branch '%s': %w", branchName, err)
+	}
+	defer func() {
+		if err := c.gitOps.Branches().RemoveWorktree(ctx, workDir); err != nil {
+			fmt.Printf("Warning: failed to remove worktree at %s: %v\n", workDir, err)
+		}
+	}()
+
+	worktreeGitOps, err := c.gitOps.InWorktree(workDir)
+	if err != nil {
+		return fmt.Errorf("failed to scope git client to worktree %s: %w", workDir, err)
+	}
+
+	return c.processAssignmentLocally(ctx, worktreeGitOps, workDir, assignmentInfo, true)
+}
+
+// createPullRequestForAssignment creates the pull request for assignmentInfo's branch using
+// gitOps, rooted at workDir. A failure is logged and skipped rather than propagated, the same
+// per-assignment continue-on-error behavior processAssignments had before Phase 4 was pooled.
+func (c *Creator) createPullRequestForAssignment(ctx context.Context, gitOps git.Client, workDir string, assignmentInfo assignment.Info) error {
+	branchName := assignmentInfo.BranchName
+
+	fmt.Printf("Creating pull request for branch '%s'...\n", branchName)
+	if err := c.createPullRequest(ctx, gitOps, workDir, assignmentInfo.Path, branchName, assignmentInfo.Override); err != nil {
+		fmt.Printf("❌ Failed to create PR for '%s': %v\n", branchName, err)
+		c.addFailedAssignment(assignmentInfo.Path, err)
+	}
+
+	return nil
+}
+
+// createPullRequestInWorktree isolates createPullRequestForAssignment in its own linked git
+// worktree checked out onto the assignment's already-pushed branch, so Phase 4's worker pool can
+// run concurrently without racing checkouts on the shared working directory. See
+// processAssignmentInWorktree for the error-propagation split between worktree machinery and
+// per-assignment failures.
+func (c *Creator) createPullRequestInWorktree(ctx context.Context, assignmentInfo assignment.Info) error {
+	branchName := assignmentInfo.BranchName
+
+	workDir, err := os.MkdirTemp("", "assignment-worktree-*")
+	if err != nil {
+		return fmt.Errorf("failed to create worktree directory for branch '%s': %w", branchName, err)
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := c.gitOps.Branches().AddWorktreeForBranch(ctx, workDir, branchName); err != nil {
+		return fmt.Errorf("failed to add worktree for branch '%s': %w", branchName, err)
+	}
+	defer func() {
+		if err := c.gitOps.Branches().RemoveWorktree(ctx, workDir); err != nil {
+			fmt.Printf("Warning: failed to remove worktree at %s: %v\n", workDir, err)
+		}
+	}()
+
+	worktreeGitOps, err := c.gitOps.InWorktree(workDir)
+	if err != nil {
+		return fmt.Errorf("failed to scope git client to worktree %s: %w", workDir, err)
+	}
+
+	return c.createPullRequestForAssignment(ctx, worktreeGitOps, workDir, assignmentInfo)
+}
+
 // processAssignments processes all found assignments and creates branches/PRs as needed
-func (c *Creator) processAssignments() error {
+func (c *Creator) processAssignments(ctx context.Context) error {
 	fmt.Printf("Looking for assignments matching '%s'\n", c.assignmentProcessor.GetAssignmentRegexStrings())
 
 	// Use assignment processor to discover and validate assignments
@@ -313,7 +956,7 @@ func (c *Creator) processAssignments() error {
 	}
 
 	// First, ensure we're on the default branch
-	if err := c.gitOps.SwitchToBranch(c.config.defaultBranch); err != nil {
+	if err := c.gitOps.Branches().SwitchToBranch(ctx, c.config.defaultBranch); err != nil {
 		return err
 	}
 
@@ -321,48 +964,59 @@ func (c *Creator) processAssignments() error {
 	fmt.Println("\n=== Phase 0: Syncing with remote ===")
 
 	// Fetch all remote branches to ensure complete local state
-	if err := c.gitOps.FetchAll(); err != nil {
+	if err := c.gitOps.Remotes().FetchAll(ctx); err != nil {
 		fmt.Println("❌ Failed to fetch remote branches, aborting")
 		return err
 	}
 
 	// Phase 1: Get current state after sync
-	localBranches, err := c.gitOps.GetLocalBranches()
+	localBranches, err := c.gitOps.Branches().GetLocalBranches(ctx)
 	if err != nil {
 		fmt.Println("❌ Failed to get local branches")
 		return err
 	}
 
 	// Get remote branches
-	remoteBranches, err := c.gitOps.GetRemoteBranches(c.config.defaultBranch)
+	remoteBranches, err := c.gitOps.Remotes().GetRemoteBranches(ctx, c.config.defaultBranch)
 	if err != nil {
 		fmt.Println("❌ Failed to get remote branches")
 		return err
 	}
 
-	existingPRs, err := c.githubClient.GetExistingPullRequests()
+	// Since the assignment branch names are already known at this point, look their pull requests
+	// up directly via GraphQL (LookupPullRequestsByHeads) rather than paging through every PR in
+	// the repo's history via GetExistingPullRequests -- the latter is still used as this call's
+	// own REST fallback when GraphQL is unavailable.
+	heads := make([]string, len(assignments))
+	for i, a := range assignments {
+		heads[i] = a.BranchName
+	}
+	prInfoByHead, err := c.githubClient.LookupPullRequestsByHeads(heads)
 	if err != nil {
 		fmt.Println("❌ Failed to get existing pull requests")
 		return err
 	}
+	existingPRs := make(map[string]string, len(prInfoByHead))
+	for head, info := range prInfoByHead {
+		existingPRs[head] = info.State
+	}
 
 	fmt.Printf("Found %d assignments to process\n", len(assignments))
 	fmt.Printf("Existing local branches: %d\n", len(localBranches))
 	fmt.Printf("Existing remote branches: %d\n", len(remoteBranches))
 	fmt.Printf("Existing PRs: %d\n", len(existingPRs))
 
-	// Phase 2: Process all assignments locally
+	// Phase 2: Process all assignments locally. Each job runs in its own git worktree (see
+	// processAssignmentInWorktree) across a bounded pool of c.config.workerPoolSize goroutines,
+	// since a single working tree isn't safe for parallel checkouts.
 	fmt.Println("\n=== Phase 2: Local processing ===")
 
 	prNeedsCreation := false
+	var phase2Jobs []assignment.Info
 
 	for _, assignmentInfo := range assignments {
-		assignmentPath := assignmentInfo.Path
 		branchName := assignmentInfo.BranchName
 
-		fmt.Printf("\nProcessing assignment: %s\n", assignmentPath)
-		fmt.Printf("Branch name: %s\n", branchName)
-
 		// Check if branch exists locally, remotely, and if PR exists (or has ever existed)
 		_, localBranchExists := localBranches[branchName]
 		_, remoteBranchExists := remoteBranches[branchName]
@@ -373,20 +1027,7 @@ func (c *Creator) processAssignments() error {
 		// 2. Branch doesn't exist remotely AND
 		// 3. No PR has ever existed for this assignment
 		if !localBranchExists && !remoteBranchExists && !prExists {
-			fmt.Printf("Branch '%s' doesn't exist anywhere and no PR exists, creating branch...\n", branchName)
-
-			// Create branch locally
-			if err := c.createBranch(branchName); err != nil {
-				fmt.Printf("❌ Failed to create branch '%s', skipping: %v\n", branchName, err)
-				continue
-			}
-
-			// Create README content locally
-			fmt.Printf("Creating README content for assignment '%s'...\n", assignmentPath)
-			if err := c.createReadme(assignmentPath); err != nil {
-				fmt.Printf("❌ Failed to create README for '%s', skipping: %v\n", assignmentPath, err)
-				continue
-			}
+			phase2Jobs = append(phase2Jobs, assignmentInfo)
 		}
 
 		// Track if any PRs need creation
@@ -397,36 +1038,55 @@ func (c *Creator) processAssignments() error {
 		}
 	}
 
+	if c.config.gitBackend == constants.GitBackendNative {
+		// The go-git backend has no linked-worktree support (see gogitBranchService), so Phase 2
+		// falls back to sequential processing on the shared working directory instead of racing it.
+		for _, assignmentInfo := range phase2Jobs {
+			if err := c.processAssignmentLocally(ctx, c.gitOps, "", assignmentInfo, false); err != nil {
+				return err
+			}
+		}
+	} else if err := c.runAssignmentWorkerPool(ctx, phase2Jobs, c.processAssignmentInWorktree); err != nil {
+		return err
+	}
+
 	// Phase 3: Push all changes atomically to remote
-	if len(c.pendingPushes) > 0 {
+	pendingPushes := c.takePendingPushes()
+	if len(pendingPushes) > 0 {
 		fmt.Printf("\n=== Phase 3: Atomic push to remote ===\n")
-		fmt.Printf("Pushing all local branches (including %d new branches) to remote atomically...\n", len(c.pendingPushes))
+		fmt.Printf("Pushing all local branches (including %d new branches) to remote atomically...\n", len(pendingPushes))
 
-		if err := c.gitOps.PushAllBranches(); err != nil {
+		if err := c.gitOps.Remotes().PushAllBranches(ctx); err != nil {
 			fmt.Println("❌ Failed to push branches to remote, aborting PR creation")
+			for _, branchName := range pendingPushes {
+				c.addFailedAssignment(branchName, err)
+			}
 			return err
 		}
 
 		fmt.Printf("✅ Successfully pushed all local branches to remote atomically\n")
-		c.pendingPushes = c.pendingPushes[:0] // Clear the slice
 	}
 
-	// Phase 4: Create pull requests
+	// Phase 4: Create pull requests. Each job runs in its own git worktree checked out onto the
+	// already-pushed branch (see createPullRequestInWorktree), across the same bounded pool.
 	if prNeedsCreation {
 		fmt.Printf("\n=== Phase 4: Pull request creation ===\n")
 
+		var phase4Jobs []assignment.Info
 		for _, assignmentInfo := range assignments {
-			assignmentPath := assignmentInfo.Path
-			branchName := assignmentInfo.BranchName
-
-			// Only create PR if no pull request exists for this branch name
-			if _, prExists := existingPRs[branchName]; !prExists {
-				fmt.Printf("Creating pull request for branch '%s'...\n", branchName)
-				if err := c.createPullRequest(assignmentPath, branchName); err != nil {
-					fmt.Printf("❌ Failed to create PR for '%s': %v\n", branchName, err)
-					continue
+			if _, prExists := existingPRs[assignmentInfo.BranchName]; !prExists {
+				phase4Jobs = append(phase4Jobs, assignmentInfo)
+			}
+		}
+
+		if c.config.gitBackend == constants.GitBackendNative {
+			for _, assignmentInfo := range phase4Jobs {
+				if err := c.createPullRequestForAssignment(ctx, c.gitOps, "", assignmentInfo); err != nil {
+					return err
 				}
 			}
+		} else if err := c.runAssignmentWorkerPool(ctx, phase4Jobs, c.createPullRequestInWorktree); err != nil {
+			return err
 		}
 	} else {
 		fmt.Println("\n=== No new assignments to process ===")
@@ -436,6 +1096,46 @@ func (c *Creator) processAssignments() error {
 	return nil
 }
 
+// rollback undoes whatever this run created after processAssignments returns an error: every
+// branch in c.createdBranches is deleted both locally and from the remote (DeleteRemoteBranch is
+// a no-op if a branch was never pushed), and every directory in c.createdDirectories is removed if
+// it's still empty -- switching back to the default branch first already deletes the tracked
+// README files via git itself, so this is just cleaning up what git leaves behind. Skipped
+// entirely when c.config.keepOnFailure is set, so an instructor can inspect the broken state.
+func (c *Creator) rollback(ctx context.Context) {
+	if c.config.keepOnFailure {
+		fmt.Println("\n⚠️  KEEP_ON_FAILURE is set, leaving this run's branches and pushes in place for inspection")
+		return
+	}
+
+	if len(c.createdBranches) == 0 && len(c.createdDirectories) == 0 {
+		return
+	}
+
+	fmt.Println("\n=== Rolling back partial run ===")
+
+	if err := c.gitOps.Branches().SwitchToBranch(ctx, c.config.defaultBranch); err != nil {
+		fmt.Printf("Warning: failed to switch to '%s' before rollback, local branch deletion may fail: %v\n", c.config.defaultBranch, err)
+	}
+
+	for _, branchName := range c.createdBranches {
+		if err := c.gitOps.Remotes().DeleteRemoteBranch(ctx, branchName); err != nil {
+			fmt.Printf("Warning: failed to delete remote branch '%s' during rollback: %v\n", branchName, err)
+		}
+		if err := c.gitOps.Branches().DeleteBranch(ctx, branchName); err != nil {
+			fmt.Printf("Warning: failed to delete local branch '%s' during rollback: %v\n", branchName, err)
+			continue
+		}
+		fmt.Printf("🧹 Rolled back branch '%s'\n", branchName)
+	}
+
+	for _, dir := range c.createdDirectories {
+		if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("Warning: failed to remove directory '%s' during rollback: %v\n", dir, err)
+		}
+	}
+}
+
 // setOutputs sets GitHub Actions outputs
 func (c *Creator) setOutputs() error {
 	// Set outputs for GitHub Actions
@@ -467,6 +1167,15 @@ func (c *Creator) setOutputs() error {
 		if _, err := fmt.Fprintf(file, "created-pull-requests=%s\n", prsJSON); err != nil {
 			return fmt.Errorf("failed to write created-pull-requests output: %w", err)
 		}
+
+		failedJSON, err := json.Marshal(c.failedAssignments)
+		if err != nil {
+			return fmt.Errorf("failed to marshal failed assignments: %w", err)
+		}
+
+		if _, err := fmt.Fprintf(file, "failed-assignments=%s\n", failedJSON); err != nil {
+			return fmt.Errorf("failed to write failed-assignments output: %w", err)
+		}
 	}
 
 	// Format output with each item on separate lines
@@ -492,11 +1201,21 @@ func (c *Creator) setOutputs() error {
 		fmt.Println("  none")
 	}
 
+	// Print each failed assignment on its own line with its classified code and hint
+	fmt.Printf("Failed assignments (%d):\n", len(c.failedAssignments))
+	if len(c.failedAssignments) > 0 {
+		for _, failure := range c.failedAssignments {
+			fmt.Printf("  - %s [%s]: %s\n", failure.Path, failure.Code, failure.Hint)
+		}
+	} else {
+		fmt.Println("  none")
+	}
+
 	return nil
 }
 
 // Run is the main execution method using local git with atomic remote operations
-func (c *Creator) Run() error {
+func (c *Creator) Run(ctx context.Context) error {
 	fmt.Println("Starting Assignment Pull Request Creator")
 	if c.config.dryRun {
 		fmt.Println("🏃 DRY RUN MODE: Simulating local git operations without making actual changes")
@@ -508,14 +1227,32 @@ func (c *Creator) Run() error {
 	fmt.Printf("Default branch: %s\n", c.config.defaultBranch)
 	fmt.Printf("Dry run mode: %t\n", c.config.dryRun)
 
-	if err := c.processAssignments(); err != nil {
-		return err
+	// setOutputs runs even if processAssignments failed partway through, so the
+	// failed-assignments output (and whatever branches/PRs did get created) are still emitted
+	// for the workflow to act on instead of being lost to a bare early return.
+	var processErr error
+	if c.config.dryRun {
+		var plan *Plan
+		plan, processErr = c.Plan(ctx)
+		if writeErr := writePlan(plan, os.Stdout); writeErr != nil {
+			fmt.Printf("Warning: failed to print plan: %v\n", writeErr)
+		}
+	} else {
+		processErr = c.processAssignments(ctx)
+	}
+
+	if processErr != nil {
+		c.rollback(ctx)
 	}
 
 	if err := c.setOutputs(); err != nil {
 		return err
 	}
 
+	if processErr != nil {
+		return processErr
+	}
+
 	if c.config.dryRun {
 		fmt.Println("\n🏃 DRY RUN MODE: Assignment Pull Request Creator simulation completed")
 		fmt.Println("In real mode, all local changes would be pushed atomically to remote")
@@ -6,13 +6,16 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+
+	"assignment-pull-request/internal/constants"
 )
 
 // cleanupEnv clears environment variables that might affect tests
 func cleanupEnv() {
 	clearEnvVars := []string{
 		"GITHUB_TOKEN", "GITHUB_REPOSITORY", "ASSIGNMENTS_ROOT_REGEX",
-		"ASSIGNMENT_REGEX", "DEFAULT_BRANCH", "DRY_RUN",
+		"ASSIGNMENT_REGEX", "ASSIGNMENT_GLOB", "DEFAULT_BRANCH", "DRY_RUN",
+		"ASSIGNMENT_EXCLUDE_REGEX", "ASSIGNMENTS_ROOT_EXCLUDE_REGEX",
 	}
 	for _, key := range clearEnvVars {
 		_ = os.Unsetenv(key)
@@ -156,6 +159,120 @@ func TestNew(t *testing.T) {
 	cleanupEnv()
 }
 
+// TestNewConfigFromEnvGlobRegexExclusivity tests that ASSIGNMENT_REGEX and ASSIGNMENT_GLOB are
+// mutually exclusive, and that whichever one is set populates the assignment pattern
+func TestNewConfigFromEnvGlobRegexExclusivity(t *testing.T) {
+	defer cleanupEnv()
+
+	t.Run("both set is an error", func(t *testing.T) {
+		cleanupEnv()
+		os.Setenv("GITHUB_TOKEN", "test-token")
+		os.Setenv("GITHUB_REPOSITORY", "owner/repo")
+		os.Setenv("ASSIGNMENT_REGEX", `regex:^(?P<branch>assignment-\d+)$`)
+		os.Setenv("ASSIGNMENT_GLOB", "assignments/{name}")
+
+		if _, err := NewConfigFromEnv(); err == nil {
+			t.Error("Expected an error when both ASSIGNMENT_REGEX and ASSIGNMENT_GLOB are set")
+		}
+	})
+
+	t.Run("glob alone populates the assignment pattern", func(t *testing.T) {
+		cleanupEnv()
+		os.Setenv("GITHUB_TOKEN", "test-token")
+		os.Setenv("GITHUB_REPOSITORY", "owner/repo")
+		os.Setenv("ASSIGNMENT_GLOB", "assignments/{name}")
+
+		config, err := NewConfigFromEnv()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		patterns := config.assignmentPattern.Patterns()
+		if len(patterns) != 1 || patterns[0] != "assignments/{name}" {
+			t.Errorf("Expected assignment pattern [\"assignments/{name}\"], got %v", patterns)
+		}
+
+		compiled, err := config.assignmentPattern.Compiled()
+		if err != nil {
+			t.Fatalf("Unexpected compile error: %v", err)
+		}
+		if !compiled[0].MatchString("assignments/hw-1") {
+			t.Error("Expected the glob pattern to match a path in its shape")
+		}
+	})
+
+	t.Run("neither set falls back to the default regex", func(t *testing.T) {
+		cleanupEnv()
+		os.Setenv("GITHUB_TOKEN", "test-token")
+		os.Setenv("GITHUB_REPOSITORY", "owner/repo")
+
+		config, err := NewConfigFromEnv()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		patterns := config.assignmentPattern.Patterns()
+		if len(patterns) != 1 || patterns[0] != constants.DefaultAssignmentRegex {
+			t.Errorf("Expected default assignment pattern [%q], got %v", constants.DefaultAssignmentRegex, patterns)
+		}
+	})
+}
+
+// TestNewConfigFromEnvExcludePatterns tests that ASSIGNMENT_EXCLUDE_REGEX and
+// ASSIGNMENTS_ROOT_EXCLUDE_REGEX are parsed into the Config's exclude matchers, and that an
+// exclude pattern which fails to compile surfaces a helpful error instead of being ignored.
+func TestNewConfigFromEnvExcludePatterns(t *testing.T) {
+	defer cleanupEnv()
+
+	t.Run("exclude pattern suppresses a would-be match", func(t *testing.T) {
+		cleanupEnv()
+		os.Setenv("GITHUB_TOKEN", "test-token")
+		os.Setenv("GITHUB_REPOSITORY", "owner/repo")
+		os.Setenv("ASSIGNMENT_EXCLUDE_REGEX", "assignments/template-*")
+
+		config, err := NewConfigFromEnv()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !config.assignmentExclude.Excludes("assignments/template-1") {
+			t.Error("Expected the exclude pattern to suppress a matching path")
+		}
+	})
+
+	t.Run("re-inclusion via ! overrides an earlier exclude", func(t *testing.T) {
+		cleanupEnv()
+		os.Setenv("GITHUB_TOKEN", "test-token")
+		os.Setenv("GITHUB_REPOSITORY", "owner/repo")
+		os.Setenv("ASSIGNMENT_EXCLUDE_REGEX", "assignments/template-*,!assignments/template-keep")
+
+		config, err := NewConfigFromEnv()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.assignmentExclude.Excludes("assignments/template-keep") {
+			t.Error("Expected the ! pattern to re-include the overridden path")
+		}
+		if !config.assignmentExclude.Excludes("assignments/template-1") {
+			t.Error("Expected the original exclude to still apply to paths the ! pattern doesn't match")
+		}
+	})
+
+	t.Run("root exclude that fails to compile surfaces a helpful error", func(t *testing.T) {
+		cleanupEnv()
+		os.Setenv("GITHUB_TOKEN", "test-token")
+		os.Setenv("GITHUB_REPOSITORY", "owner/repo")
+		os.Setenv("ASSIGNMENTS_ROOT_EXCLUDE_REGEX", "regex:(unterminated")
+
+		if _, err := NewConfigFromEnv(); err == nil {
+			t.Error("Expected an error for an invalid ASSIGNMENTS_ROOT_EXCLUDE_REGEX pattern")
+		} else if !strings.Contains(err.Error(), "ASSIGNMENTS_ROOT_EXCLUDE_REGEX") {
+			t.Errorf("Expected error to name the offending env var, got: %v", err)
+		}
+	})
+}
+
 // TestRegexValidation tests that regex patterns are validated for named groups
 func TestRegexValidation(t *testing.T) {
 	// Clean up any existing environment variables
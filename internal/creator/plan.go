@@ -0,0 +1,163 @@
+package creator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"assignment-pull-request/internal/constants"
+)
+
+// Action is one planned side effect of a run, recorded instead of carried out while c.config.dryRun
+// is set and c.plan is non-nil (see Creator.Plan). Each concrete type below corresponds to one of
+// the side effects processAssignments can otherwise perform for real: creating a branch, writing a
+// file, rewriting an image link in a PR body, or opening a pull request.
+type Action interface {
+	// Kind is the action's type, used as the JSON "type" field and the text table's first column.
+	Kind() string
+	// Describe is a single human-readable line summarizing the action, used by Plan.WriteText.
+	Describe() string
+}
+
+// CreateBranch is the planned creation of a new branch from From.
+type CreateBranch struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+}
+
+func (a CreateBranch) Kind() string { return "CreateBranch" }
+func (a CreateBranch) Describe() string {
+	return fmt.Sprintf("create branch %q from %q", a.Name, a.From)
+}
+
+// WriteFile is the planned creation or update of a file's contents at Path.
+type WriteFile struct {
+	Path  string      `json:"path"`
+	Bytes []byte      `json:"bytes"`
+	Mode  fs.FileMode `json:"mode"`
+}
+
+func (a WriteFile) Kind() string { return "WriteFile" }
+func (a WriteFile) Describe() string {
+	return fmt.Sprintf("write %d byte(s) to %q (mode %s)", len(a.Bytes), a.Path, a.Mode)
+}
+
+// RewriteImageLink is the planned rewrite of an image reference within File, from a link pointing
+// at From to one pointing at To (see instructions.Processor.ProcessedAssets).
+type RewriteImageLink struct {
+	File string `json:"file"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (a RewriteImageLink) Kind() string { return "RewriteImageLink" }
+func (a RewriteImageLink) Describe() string {
+	return fmt.Sprintf("rewrite image link in %q from %q to %q", a.File, a.From, a.To)
+}
+
+// OpenPullRequest is the planned creation of a pull request.
+type OpenPullRequest struct {
+	Title string `json:"title"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Body  string `json:"body"`
+}
+
+func (a OpenPullRequest) Kind() string { return "OpenPullRequest" }
+func (a OpenPullRequest) Describe() string {
+	return fmt.Sprintf("open pull request %q: %s -> %s", a.Title, a.Head, a.Base)
+}
+
+// Plan is the ordered list of side effects a dry run would have performed, built by Creator.Plan
+// instead of Creator.Run actually performing them.
+type Plan struct {
+	Actions []Action `json:"actions"`
+}
+
+// planAction is the JSON wire shape for an Action, carrying its Kind() alongside its own fields so
+// a plan can round-trip through encoding/json despite Action being an interface.
+type planAction struct {
+	Type   string `json:"type"`
+	Action Action `json:"action"`
+}
+
+// MarshalJSON renders p as a list of {"type": ..., "action": {...}} objects, since Action's
+// concrete type would otherwise be lost marshaling through the bare interface.
+func (p *Plan) MarshalJSON() ([]byte, error) {
+	wrapped := make([]planAction, 0, len(p.Actions))
+	for _, action := range p.Actions {
+		wrapped = append(wrapped, planAction{Type: action.Kind(), Action: action})
+	}
+	return json.Marshal(struct {
+		Actions []planAction `json:"actions"`
+	}{Actions: wrapped})
+}
+
+// WriteJSON writes p to w as indented JSON (see constants.EnvPlanOutput).
+func (p *Plan) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(p)
+}
+
+// WriteText writes p to w as a human-readable table: one "KIND  description" line per action.
+func (p *Plan) WriteText(w io.Writer) error {
+	if len(p.Actions) == 0 {
+		_, err := fmt.Fprintln(w, "(no planned actions)")
+		return err
+	}
+	for _, action := range p.Actions {
+		if _, err := fmt.Fprintf(w, "%-18s %s\n", action.Kind(), action.Describe()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordPlanAction appends action to c.plan if one is being built (see Creator.Plan), guarded by
+// mu so it's safe to call from the Phase 2/Phase 4 worker pools alongside the other
+// concurrently-appended Creator slices.
+func (c *Creator) recordPlanAction(action Action) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.plan != nil {
+		c.plan.Actions = append(c.plan.Actions, action)
+	}
+}
+
+// Plan builds and returns the Plan a run would execute: it sets c.config.dryRun for the duration
+// of the call (restoring the previous setting once done), so the Creator's own steps capture their
+// side effects as typed Actions instead of carrying them out or only printing "[DRY RUN] Would ..."
+// log lines. The git.Client and github.Client keep the dry-run setting they were constructed with,
+// so Plan pushes no branch and calls no GitHub API only on a Creator built with dry run on, as it
+// is whenever Run calls Plan.
+func (c *Creator) Plan(ctx context.Context) (*Plan, error) {
+	c.mu.Lock()
+	c.plan = &Plan{}
+	c.mu.Unlock()
+
+	previousDryRun := c.config.dryRun
+	c.config.dryRun = true
+	defer func() { c.config.dryRun = previousDryRun }()
+
+	if err := c.processAssignments(ctx); err != nil {
+		return c.plan, err
+	}
+	return c.plan, nil
+}
+
+// writePlan prints plan to w as either JSON or a text table, per constants.EnvPlanOutput
+// (constants.DefaultPlanOutput when unset or unrecognized).
+func writePlan(plan *Plan, w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "\n=== Dry run plan ==="); err != nil {
+		return err
+	}
+
+	if os.Getenv(constants.EnvPlanOutput) == constants.PlanOutputJSON {
+		return plan.WriteJSON(w)
+	}
+	return plan.WriteText(w)
+}
@@ -0,0 +1,81 @@
+package creator
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"assignment-pull-request/internal/assignment"
+	"assignment-pull-request/internal/fsx"
+	"assignment-pull-request/internal/git"
+)
+
+// initRealGitRepo creates a real git repository (via the git binary) with a "main" branch and one
+// commit, matching the state processAssignments expects before Phase 2 runs.
+func initRealGitRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	run("init", "-b", "main")
+	run("config", "user.email", "creator-test@example.com")
+	run("config", "user.name", "Creator Test")
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("root\n"), 0644); err != nil {
+		t.Fatalf("failed to write README.md: %v", err)
+	}
+	run("add", ".")
+	run("commit", "-m", "seed main")
+
+	return dir
+}
+
+// TestProcessAssignmentInWorktreeAgainstRealGit drives processAssignmentInWorktree against a real
+// git repository rather than testutil.FakeClient, which doesn't enforce git's one-checkout-per-
+// branch rule. It covers that the worktree path no longer re-switches/re-creates a branch that
+// CreateWorktreeForNewBranch already checked out, which previously made createBranch fail with
+// "already checked out" and left processAssignmentLocally silently skipping every assignment.
+func TestProcessAssignmentInWorktreeAgainstRealGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available, skipping real-git worktree test")
+	}
+
+	repoDir := initRealGitRepo(t)
+
+	c := &Creator{
+		config: &Config{
+			defaultBranch: "main",
+		},
+		gitOps: git.NewClient(false).WithRepo(repoDir),
+		fs:     fsx.NewOsFs(),
+	}
+
+	assignmentInfo := assignment.Info{
+		Path:       "assignment-1",
+		BranchName: "assignment-1-branch",
+	}
+
+	if err := c.processAssignmentInWorktree(context.Background(), assignmentInfo); err != nil {
+		t.Fatalf("processAssignmentInWorktree failed: %v", err)
+	}
+
+	if len(c.createdBranches) != 1 || c.createdBranches[0] != assignmentInfo.BranchName {
+		t.Fatalf("expected branch '%s' to be recorded as created, got %v", assignmentInfo.BranchName, c.createdBranches)
+	}
+
+	readmePath := filepath.Join(repoDir, assignmentInfo.Path, "README.md")
+	cmd := exec.Command("git", "show", assignmentInfo.BranchName+":"+filepath.Join(assignmentInfo.Path, "README.md"))
+	cmd.Dir = repoDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("expected '%s' committed on branch '%s': %v\n%s", readmePath, assignmentInfo.BranchName, err, out)
+	}
+}
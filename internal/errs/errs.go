@@ -0,0 +1,85 @@
+// Package errs defines a small, typed hierarchy for the handful of git/GitHub failure modes
+// classroom instructors actually need to tell apart -- a merge conflict, a non-fast-forward push,
+// a protected branch rejection, or a branch that already exists remotely -- each carrying a
+// machine-readable Code plus a human-readable remediation Hint, instead of a bare wrapped error
+// string that only a human reading CI logs can make sense of.
+package errs
+
+import "fmt"
+
+// Code identifies a specific, known failure mode so callers (and downstream tooling, e.g. the
+// action's failed-assignments output) can branch on it instead of matching error strings.
+type Code string
+
+const (
+	// CodeMergeConflict indicates a merge or pull request couldn't be completed because of
+	// conflicting changes
+	CodeMergeConflict Code = "merge_conflict"
+
+	// CodeNonFastForward indicates a push was rejected because the remote branch has commits
+	// the local branch doesn't know about
+	CodeNonFastForward Code = "non_fast_forward"
+
+	// CodeProtectedBranch indicates a push or merge was rejected by a branch protection rule
+	CodeProtectedBranch Code = "protected_branch"
+
+	// CodeBranchExistsRemotely indicates branch creation or push failed because a branch with
+	// that name already exists on the remote
+	CodeBranchExistsRemotely Code = "branch_exists_remotely"
+)
+
+// HintedError pairs a machine-readable Code with a human-readable Hint describing how to recover,
+// wrapping the underlying error that triggered it. Task names the operation that failed (e.g. an
+// assignment path or branch name) for use in log lines and the failed-assignments output.
+type HintedError struct {
+	Task string
+	Code Code
+	Hint string
+	Err  error
+}
+
+// NewErrorWithHint builds a HintedError from the operation that failed, its classified Code, a
+// remediation Hint, and the underlying error
+func NewErrorWithHint(task string, code Code, hint string, err error) *HintedError {
+	return &HintedError{Task: task, Code: code, Hint: hint, Err: err}
+}
+
+// Error renders as "<task>: <underlying error> (hint: <hint>)"
+func (e *HintedError) Error() string {
+	return fmt.Sprintf("%s: %v (hint: %s)", e.Task, e.Err, e.Hint)
+}
+
+// Unwrap exposes the underlying error for errors.Is/errors.As
+func (e *HintedError) Unwrap() error {
+	return e.Err
+}
+
+// ErrMergeConflict classifies err as a merge conflict for task, e.g. a pull request GitHub
+// reports as not mergeable because of conflicting changes
+func ErrMergeConflict(task string, err error) *HintedError {
+	return NewErrorWithHint(task, CodeMergeConflict,
+		"resolve the conflicting files locally (merge the default branch into the assignment branch) and re-push, or close and let a future run recreate the pull request",
+		err)
+}
+
+// ErrNonFastForward classifies err as a push rejected because the remote branch has diverged
+func ErrNonFastForward(task string, err error) *HintedError {
+	return NewErrorWithHint(task, CodeNonFastForward,
+		"fetch and rebase onto the remote branch before re-pushing, or delete the remote branch if it's stale and safe to replace",
+		err)
+}
+
+// ErrProtectedBranch classifies err as a push or merge rejected by a branch protection rule
+func ErrProtectedBranch(task string, err error) *HintedError {
+	return NewErrorWithHint(task, CodeProtectedBranch,
+		"the target branch is protected; push to a differently named branch or ask a repository admin to adjust its branch protection rules",
+		err)
+}
+
+// ErrBranchExistsRemotely classifies err as a branch creation or push failing because the branch
+// already exists on the remote
+func ErrBranchExistsRemotely(task string, err error) *HintedError {
+	return NewErrorWithHint(task, CodeBranchExistsRemotely,
+		"a branch with this name already exists on the remote; delete it if it's stale, or let this run skip re-creating it",
+		err)
+}
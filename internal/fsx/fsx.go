@@ -0,0 +1,306 @@
+// Package fsx abstracts the filesystem operations creator.Creator needs behind a small
+// afero-style interface, so integration tests can build their fixtures on an in-memory MemFs
+// instead of os.Chdir-ing into a t.TempDir() and mutating the real working directory.
+package fsx
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fs is the filesystem surface creator.Creator depends on, narrow enough to be implemented by
+// both OsFs (production) and MemFs (tests).
+type Fs interface {
+	Open(name string) (fs.File, error)
+	Stat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+	Walk(root string, fn filepath.WalkFunc) error
+}
+
+// OsFs is the production Fs implementation, delegating directly to the os and path/filepath
+// packages.
+type OsFs struct{}
+
+// NewOsFs creates an OsFs.
+func NewOsFs() OsFs { return OsFs{} }
+
+var _ Fs = OsFs{}
+
+func (OsFs) Open(name string) (fs.File, error)          { return os.Open(name) }
+func (OsFs) Stat(name string) (fs.FileInfo, error)      { return os.Stat(name) }
+func (OsFs) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (OsFs) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+
+func (OsFs) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (OsFs) MkdirAll(dir string, perm fs.FileMode) error {
+	return os.MkdirAll(dir, perm)
+}
+
+func (OsFs) Walk(root string, fn filepath.WalkFunc) error {
+	return filepath.Walk(root, fn)
+}
+
+// memEntry is a single file or directory held by MemFs.
+type memEntry struct {
+	data  []byte
+	mode  fs.FileMode
+	isDir bool
+}
+
+// MemFs is an in-memory Fs, holding every file/directory in a flat map keyed by its
+// slash-separated, path.Clean-ed path. Safe for concurrent use.
+type MemFs struct {
+	mu      sync.Mutex
+	entries map[string]*memEntry
+}
+
+// NewMemFs creates an empty MemFs, ready to have fixtures written into it via WriteFile/MkdirAll.
+func NewMemFs() *MemFs {
+	return &MemFs{entries: map[string]*memEntry{".": {isDir: true, mode: fs.ModeDir | 0755}}}
+}
+
+var _ Fs = (*MemFs)(nil)
+
+// cleanPath normalizes name to the slash-separated, "./"-stripped form MemFs keys its entries by.
+func cleanPath(name string) string {
+	cleaned := path.Clean(filepath.ToSlash(name))
+	return strings.TrimPrefix(cleaned, "./")
+}
+
+// WriteFile writes data to name, creating any missing parent directories first.
+func (m *MemFs) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	clean := cleanPath(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.mkdirAllLocked(path.Dir(clean), 0755); err != nil {
+		return err
+	}
+
+	cp := make([]byte, len(data))
+	copy(cp, data)
+	m.entries[clean] = &memEntry{data: cp, mode: perm}
+	return nil
+}
+
+// MkdirAll creates dir and any missing parents.
+func (m *MemFs) MkdirAll(dir string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.mkdirAllLocked(dir, perm)
+}
+
+func (m *MemFs) mkdirAllLocked(dir string, perm fs.FileMode) error {
+	clean := cleanPath(dir)
+	if clean == "." || clean == "" {
+		return nil
+	}
+
+	parts := strings.Split(clean, "/")
+	built := make([]string, 0, len(parts))
+	for _, part := range parts {
+		built = append(built, part)
+		p := strings.Join(built, "/")
+		if _, ok := m.entries[p]; !ok {
+			m.entries[p] = &memEntry{isDir: true, mode: perm | fs.ModeDir}
+		}
+	}
+	return nil
+}
+
+// ReadFile returns name's contents, or an fs.ErrNotExist-wrapping error if it doesn't exist or is
+// a directory.
+func (m *MemFs) ReadFile(name string) ([]byte, error) {
+	clean := cleanPath(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[clean]
+	if !ok || entry.isDir {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+
+	cp := make([]byte, len(entry.data))
+	copy(cp, entry.data)
+	return cp, nil
+}
+
+// Stat returns name's fs.FileInfo, or an fs.ErrNotExist-wrapping error if it doesn't exist.
+func (m *MemFs) Stat(name string) (fs.FileInfo, error) {
+	clean := cleanPath(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[clean]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return memFileInfo{name: path.Base(clean), entry: entry}, nil
+}
+
+// Open returns an fs.File for name: a readable, seekable handle for a regular file, or a
+// zero-length, read-only handle for a directory (mirroring os.Open's behavior closely enough for
+// the Stat/Close/IsDir checks creator.Creator's code paths actually do).
+func (m *MemFs) Open(name string) (fs.File, error) {
+	info, err := m.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return &memDirFile{info: info}, nil
+	}
+
+	data, err := m.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return &memFile{Reader: bytes.NewReader(data), info: info}, nil
+}
+
+// ReadDir returns name's immediate children, sorted by name.
+func (m *MemFs) ReadDir(name string) ([]fs.DirEntry, error) {
+	clean := cleanPath(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dirEntry, ok := m.entries[clean]
+	if !ok || !dirEntry.isDir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	prefix := clean + "/"
+	if clean == "." || clean == "" {
+		prefix = ""
+	}
+
+	seen := make(map[string]bool)
+	var result []fs.DirEntry
+	for p, entry := range m.entries {
+		if p == clean || !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(p, prefix)
+		isDir := entry.isDir
+		if idx := strings.Index(rest, "/"); idx >= 0 {
+			rest = rest[:idx]
+			isDir = true
+		}
+		if seen[rest] {
+			continue
+		}
+		seen[rest] = true
+		result = append(result, memDirEntry{name: rest, isDir: isDir})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name() < result[j].Name() })
+	return result, nil
+}
+
+// Walk visits root and every path beneath it in lexical order, the same contract as
+// filepath.Walk.
+func (m *MemFs) Walk(root string, fn filepath.WalkFunc) error {
+	clean := cleanPath(root)
+
+	m.mu.Lock()
+	paths := make([]string, 0, len(m.entries))
+	entries := make(map[string]*memEntry, len(m.entries))
+	for p, entry := range m.entries {
+		if clean == "." || p == clean || strings.HasPrefix(p, clean+"/") {
+			paths = append(paths, p)
+			entries[p] = entry
+		}
+	}
+	m.mu.Unlock()
+
+	sort.Strings(paths)
+	var skipPrefixes []string
+walk:
+	for _, p := range paths {
+		for _, prefix := range skipPrefixes {
+			if strings.HasPrefix(p, prefix) {
+				continue walk
+			}
+		}
+		info := memFileInfo{name: path.Base(p), entry: entries[p]}
+		if err := fn(p, info, nil); err != nil {
+			if err == filepath.SkipDir {
+				if p == clean {
+					return nil
+				}
+				if info.IsDir() {
+					skipPrefixes = append(skipPrefixes, p+"/")
+				}
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+type memFileInfo struct {
+	name  string
+	entry *memEntry
+}
+
+func (fi memFileInfo) Name() string       { return fi.name }
+func (fi memFileInfo) Size() int64        { return int64(len(fi.entry.data)) }
+func (fi memFileInfo) Mode() fs.FileMode  { return fi.entry.mode }
+func (fi memFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi memFileInfo) IsDir() bool        { return fi.entry.isDir }
+func (fi memFileInfo) Sys() any           { return nil }
+
+type memDirEntry struct {
+	name  string
+	isDir bool
+}
+
+func (de memDirEntry) Name() string { return de.name }
+func (de memDirEntry) IsDir() bool  { return de.isDir }
+
+func (de memDirEntry) Type() fs.FileMode {
+	if de.isDir {
+		return fs.ModeDir
+	}
+	return 0
+}
+
+func (de memDirEntry) Info() (fs.FileInfo, error) {
+	return memFileInfo{name: de.name, entry: &memEntry{isDir: de.isDir}}, nil
+}
+
+// memFile is the fs.File returned by MemFs.Open for a regular file.
+type memFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+// memDirFile is the fs.File returned by MemFs.Open for a directory.
+type memDirFile struct {
+	info fs.FileInfo
+}
+
+func (f *memDirFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memDirFile) Read([]byte) (int, error)   { return 0, io.EOF }
+func (f *memDirFile) Close() error               { return nil }
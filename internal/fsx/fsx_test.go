@@ -0,0 +1,105 @@
+package fsx
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+)
+
+func TestMemFsWriteReadRoundTrip(t *testing.T) {
+	memFs := NewMemFs()
+
+	if err := memFs.WriteFile("assignment-1/README.md", []byte("hello"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	data, err := memFs.ReadFile("assignment-1/README.md")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(data) != "hello" {
+		t.Errorf("ReadFile returned %q, want %q", data, "hello")
+	}
+}
+
+func TestMemFsStatMissingReturnsNotExist(t *testing.T) {
+	memFs := NewMemFs()
+
+	if _, err := memFs.Stat("missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat on missing file: got %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestMemFsMkdirAllThenStatReportsDir(t *testing.T) {
+	memFs := NewMemFs()
+
+	if err := memFs.MkdirAll("assignment-1/nested", 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	info, err := memFs.Stat("assignment-1/nested")
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if !info.IsDir() {
+		t.Errorf("Stat(%q).IsDir() = false, want true", "assignment-1/nested")
+	}
+}
+
+func TestMemFsWalkVisitsEveryWrittenFile(t *testing.T) {
+	memFs := NewMemFs()
+	if err := memFs.WriteFile("assignment-1/README.md", []byte("one"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := memFs.WriteFile("assignment-2/README.md", []byte("two"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	var visited []string
+	err := memFs.Walk(".", func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			visited = append(visited, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+
+	if len(visited) != 2 {
+		t.Errorf("Walk visited %v, want 2 files", visited)
+	}
+}
+
+func TestMemFsReadDirListsImmediateChildren(t *testing.T) {
+	memFs := NewMemFs()
+	if err := memFs.WriteFile("assignment-1/README.md", []byte("one"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := memFs.WriteFile("assignment-1/sub/NOTES.md", []byte("notes"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	entries, err := memFs.ReadDir("assignment-1")
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("ReadDir returned %d entries, want 2", len(entries))
+	}
+	if entries[0].Name() != "README.md" || entries[0].IsDir() {
+		t.Errorf("entries[0] = %+v, want file README.md", entries[0])
+	}
+	if entries[1].Name() != "sub" || !entries[1].IsDir() {
+		t.Errorf("entries[1] = %+v, want dir sub", entries[1])
+	}
+}
+
+func TestOsFsSatisfiesFs(t *testing.T) {
+	var _ Fs = NewOsFs()
+	_ = filepath.Separator
+}
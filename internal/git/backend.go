@@ -0,0 +1,36 @@
+package git
+
+import "context"
+
+// Backend is the set of git operations the rest of the codebase depends on. The default
+// implementation shells out to the git binary (see Operations); GoGitBackend implements the
+// same surface on top of go-git so callers can run purely in-process, without a git binary
+// available (e.g. in minimal containers or tests). Every method takes a context.Context so a
+// caller can enforce a deadline or cancel a long-running operation; GoGitBackend's in-process
+// calls don't honor it today, but Operations' exec-based calls do.
+type Backend interface {
+	SwitchToBranch(ctx context.Context, branchName string) error
+	CreateAndSwitchToBranch(ctx context.Context, branchName string) error
+	AddFile(ctx context.Context, filePath string) error
+	Commit(ctx context.Context, message string) error
+	FetchAll(ctx context.Context) error
+	PushAllBranches(ctx context.Context) error
+	PushBranch(ctx context.Context, branchName string) error
+	MergeBranchToMain(ctx context.Context, branchName string) error
+	UpdateBranchFromMain(ctx context.Context, branchName string) error
+	PullMainFromRemote(ctx context.Context) error
+	GetLocalBranches(ctx context.Context) (map[string]bool, error)
+	GetRemoteBranches(ctx context.Context, defaultBranch string) (map[string]bool, error)
+	GetCurrentBranch(ctx context.Context) (string, error)
+	InitSparseCheckout(ctx context.Context) error
+	InitSparseCheckoutCone(ctx context.Context) error
+	SetSparseCheckoutPaths(ctx context.Context, paths []string) error
+	DisableSparseCheckout(ctx context.Context) error
+	ApplyCheckout(ctx context.Context) error
+	IsRepository(ctx context.Context) (bool, error)
+	GetCommitHash(ctx context.Context) (string, error)
+	GetShortCommitHash(ctx context.Context) (string, error)
+}
+
+// Ensure the exec-based Operations satisfies Backend
+var _ Backend = (*Operations)(nil)
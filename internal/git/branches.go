@@ -0,0 +1,162 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// BranchService manages local branch creation, switching, and merging
+type BranchService interface {
+	SwitchToBranch(ctx context.Context, branchName string) error
+	CreateAndSwitchToBranch(ctx context.Context, branchName string) error
+	MergeBranchToMain(ctx context.Context, branchName string) error
+	UpdateBranchFromMain(ctx context.Context, branchName string) error
+	GetLocalBranches(ctx context.Context) (map[string]bool, error)
+	GetCurrentBranch(ctx context.Context) (string, error)
+
+	// DeleteBranch force-deletes the local branch branchName (see Creator.rollback), regardless
+	// of whether it's been merged
+	DeleteBranch(ctx context.Context, branchName string) error
+
+	// CreateWorktreeForNewBranch adds a linked worktree at dir, checked out onto a newly created
+	// branchName branched from startPoint. Unlike CreateAndSwitchToBranch, this doesn't touch the
+	// caller's own working directory, so multiple branches can be created and populated
+	// concurrently from the same repository (see creator.Creator's worker pool).
+	CreateWorktreeForNewBranch(ctx context.Context, dir, branchName, startPoint string) error
+
+	// AddWorktreeForBranch adds a linked worktree at dir, checked out onto the existing
+	// branchName, for the same reason CreateWorktreeForNewBranch exists: isolating a branch
+	// already pushed in an earlier phase so a later phase can touch it without racing other
+	// workers' checkouts.
+	AddWorktreeForBranch(ctx context.Context, dir, branchName string) error
+
+	// RemoveWorktree removes the linked worktree at dir (created by CreateWorktreeForNewBranch or
+	// AddWorktreeForBranch), freeing it once a worker is done with it.
+	RemoveWorktree(ctx context.Context, dir string) error
+}
+
+// branchService is the exec-based BranchService implementation
+type branchService struct{ base }
+
+var _ BranchService = branchService{}
+
+// SwitchToBranch switches to the specified branch
+func (s branchService) SwitchToBranch(ctx context.Context, branchName string) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("checkout").WithRevArgs(branchName), s.runOpts(),
+		fmt.Sprintf("Switch to branch '%s'", branchName),
+	)
+}
+
+// CreateAndSwitchToBranch creates a new branch and switches to it
+func (s branchService) CreateAndSwitchToBranch(ctx context.Context, branchName string) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("checkout").WithFlag("-b", branchName), s.runOpts(),
+		fmt.Sprintf("Create and switch to branch '%s'", branchName),
+	)
+}
+
+// MergeBranchToMain merges a specific branch into main
+func (s branchService) MergeBranchToMain(ctx context.Context, branchName string) error {
+	// First switch to main
+	if err := s.SwitchToBranch(ctx, "main"); err != nil {
+		return err
+	}
+
+	// Merge the branch
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("merge").WithFlag("--no-ff", "").WithRevArgs(branchName), s.runOpts(),
+		fmt.Sprintf("Merge branch '%s' into main", branchName),
+	)
+}
+
+// UpdateBranchFromMain updates a branch with the latest changes from main
+func (s branchService) UpdateBranchFromMain(ctx context.Context, branchName string) error {
+	// Switch to the branch
+	if err := s.SwitchToBranch(ctx, branchName); err != nil {
+		return err
+	}
+
+	// Merge main into this branch
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("merge").WithFlag("--no-ff", "").WithRevArgs("main"), s.runOpts(),
+		fmt.Sprintf("Update branch '%s' with latest changes from main", branchName),
+	)
+}
+
+// GetLocalBranches returns a map of local branch names
+func (s branchService) GetLocalBranches(ctx context.Context) (map[string]bool, error) {
+	branches := make(map[string]bool)
+
+	if s.commander.dryRun {
+		fmt.Println("[DRY RUN] Would check local branches with command:")
+		fmt.Println("  git branch")
+		// Return empty set for dry-run to simulate clean repository
+		return branches, nil
+	}
+
+	// Get local branches
+	output, err := s.commander.RunCommandWithOutput(ctx,
+		NewSafeCmd("branch"), s.runOpts(),
+		"Get local branches",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			// Format: "* main" or "  branch-name"
+			branchName := strings.TrimSpace(strings.TrimPrefix(line, "*"))
+			if branchName != "" {
+				branches[branchName] = true
+			}
+		}
+	}
+
+	fmt.Printf("Found %d local branches\n", len(branches))
+	return branches, nil
+}
+
+// GetCurrentBranch returns the name of the currently checked out branch
+func (s branchService) GetCurrentBranch(ctx context.Context) (string, error) {
+	return s.commander.RunCommandWithOutput(ctx,
+		NewSafeCmd("rev-parse").WithFlag("--abbrev-ref", "").WithRevArgs("HEAD"), s.runOpts(),
+		"Get current branch",
+	)
+}
+
+// DeleteBranch force-deletes the local branch branchName
+func (s branchService) DeleteBranch(ctx context.Context, branchName string) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("branch").WithFlag("-D", branchName), s.runOpts(),
+		fmt.Sprintf("Delete local branch '%s'", branchName),
+	)
+}
+
+// CreateWorktreeForNewBranch adds a linked worktree at dir on a newly created branchName,
+// branched from startPoint
+func (s branchService) CreateWorktreeForNewBranch(ctx context.Context, dir, branchName, startPoint string) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("worktree").WithSubSubCmd("add").WithFlag("-b", branchName).WithRevArgs(dir, startPoint), s.runOpts(),
+		fmt.Sprintf("Add worktree at %s for new branch '%s'", dir, branchName),
+	)
+}
+
+// AddWorktreeForBranch adds a linked worktree at dir, checked out onto the existing branchName
+func (s branchService) AddWorktreeForBranch(ctx context.Context, dir, branchName string) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("worktree").WithSubSubCmd("add").WithRevArgs(dir, branchName), s.runOpts(),
+		fmt.Sprintf("Add worktree at %s for branch '%s'", dir, branchName),
+	)
+}
+
+// RemoveWorktree removes the linked worktree at dir
+func (s branchService) RemoveWorktree(ctx context.Context, dir string) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("worktree").WithSubSubCmd("remove").WithFlag("--force", "").WithRevArgs(dir), s.runOpts(),
+		fmt.Sprintf("Remove worktree at %s", dir),
+	)
+}
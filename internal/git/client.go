@@ -0,0 +1,101 @@
+package git
+
+// base holds the state shared by every per-domain service carved out of the Operations god
+// object: the Commander used to execute git commands and the repository directory they run in.
+type base struct {
+	commander *Commander
+	repoDir   string
+}
+
+// runOpts builds the RunOpts every service method passes to the commander, scoping the command
+// to repoDir
+func (b base) runOpts() *RunOpts {
+	return &RunOpts{Dir: b.repoDir}
+}
+
+// Client aggregates the per-domain git services, following the lazygit refactor that broke up a
+// single "git" god object into branches.go/commits.go/files.go/remotes.go. Callers depend on
+// Client and the per-domain service interfaces rather than *ExecClient directly, so tests can
+// inject testutil.FakeClient instead of spinning up a real git binary.
+type Client interface {
+	Branches() BranchService
+	Remotes() RemoteService
+	Sparse() SparseService
+	Commits() CommitService
+	WorkTree() WorkTreeService
+	Lfs() LFSService
+
+	// InWorktree returns a Client scoped to dir, a linked worktree previously created via
+	// Branches().CreateWorktreeForNewBranch/AddWorktreeForBranch, so a caller (e.g. one worker in
+	// creator.Creator's pool) can run git operations there without disturbing the repository's
+	// primary working directory.
+	InWorktree(dir string) (Client, error)
+}
+
+// ExecClient is the default Client implementation, backed by a shared Commander that shells out
+// to the git binary.
+type ExecClient struct {
+	base
+	branches branchService
+	remotes  remoteService
+	sparse   sparseService
+	commits  commitService
+	workTree workTreeService
+	lfs      lfsService
+}
+
+var _ Client = (*ExecClient)(nil)
+
+// NewClient creates a Client that runs in the process's own working directory
+func NewClient(dryRun bool) *ExecClient {
+	return newExecClient(NewCommander(dryRun), "")
+}
+
+// newExecClient builds an ExecClient from an existing commander, wiring each per-domain service
+// to the same shared base so they all execute against the same commander/repoDir
+func newExecClient(commander *Commander, repoDir string) *ExecClient {
+	b := base{commander: commander, repoDir: repoDir}
+	branches := branchService{b}
+	return &ExecClient{
+		base:     b,
+		branches: branches,
+		remotes:  remoteService{base: b, branches: branches},
+		sparse:   sparseService{b},
+		commits:  commitService{b},
+		workTree: workTreeService{b},
+		lfs:      lfsService{b},
+	}
+}
+
+// WithRepo returns an ExecClient scoped to run every command in dir, leaving the receiver
+// untouched. This lets callers (e.g. TempWorkspace-based tests) drive several repositories
+// concurrently without os.Chdir.
+func (c *ExecClient) WithRepo(dir string) *ExecClient {
+	return newExecClient(c.commander, dir)
+}
+
+func (c *ExecClient) Branches() BranchService   { return c.branches }
+func (c *ExecClient) Remotes() RemoteService    { return c.remotes }
+func (c *ExecClient) Sparse() SparseService     { return c.sparse }
+func (c *ExecClient) Commits() CommitService    { return c.commits }
+func (c *ExecClient) WorkTree() WorkTreeService { return c.workTree }
+func (c *ExecClient) Lfs() LFSService           { return c.lfs }
+
+// InWorktree returns a Client running every command in dir instead of the receiver's repoDir,
+// sharing the same Commander (and so the same dry-run setting).
+func (c *ExecClient) InWorktree(dir string) (Client, error) {
+	return c.WithRepo(dir), nil
+}
+
+// NewClientForBackend builds a Client for the named backend, so callers can select it from
+// configuration (e.g. the GIT_BACKEND environment variable) instead of constructing a concrete
+// client directly. backend == "native" returns a GoGitClient rooted at repoDir (opened eagerly,
+// so a missing/invalid repository fails fast here rather than on first use); anything else,
+// including "shell", falls back to the exec-based ExecClient for parity with the action's
+// long-standing behavior.
+func NewClientForBackend(backend, repoDir string, dryRun bool, token string) (Client, error) {
+	if backend == "native" {
+		return NewGoGitClient(repoDir, dryRun, token)
+	}
+	return NewClient(dryRun), nil
+}
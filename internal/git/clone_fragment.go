@@ -0,0 +1,88 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// parseFragmentURL splits a Docker-build-context-style fragment URL into the plain repository
+// URL and its fragment, e.g. "https://host/org/repo.git#main:docs" becomes
+// ("https://host/org/repo.git", "main", "docs"). The fragment may be just a ref ("#v1.2.0",
+// "#a1b2c3d") or a "ref:subdir" pair; a missing fragment resolves to the remote's default branch
+// via HEAD, with no subdir scoping.
+func parseFragmentURL(rawURL string) (repoURL, ref, subdir string) {
+	repoURL, fragment, hasFragment := strings.Cut(rawURL, "#")
+	if !hasFragment || fragment == "" {
+		return repoURL, "HEAD", ""
+	}
+
+	ref, subdir, _ = strings.Cut(fragment, ":")
+	return repoURL, ref, subdir
+}
+
+// CloneFragment resolves a fragment-style assignment source URL of the form "<repo-url>#<ref>"
+// or "<repo-url>#<ref>:<subdir>" (ref may be a branch, tag, or commit sha) and pulls just that ref
+// into dest: git init, add origin, a shallow "fetch --depth 1" of ref, and a checkout of
+// FETCH_HEAD. When the fragment names a subdir, the checkout is then scoped to it with cone-mode
+// sparse-checkout, so a single monorepo of course material can be pulled without a full clone.
+// It returns the resolved commit sha, so callers can record exactly what was pulled even though
+// the URL only pinned a branch or tag name.
+func (o *Operations) CloneFragment(ctx context.Context, url string, dest string) (resolvedRef string, err error) {
+	repoURL, ref, subdir := parseFragmentURL(url)
+
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dest, err)
+	}
+
+	opts := &RunOpts{Dir: dest}
+
+	if err := o.commander.RunCommand(ctx,
+		NewSafeCmd("init"), opts,
+		"Initialize repository",
+	); err != nil {
+		return "", fmt.Errorf("failed to init %s: %w", dest, err)
+	}
+
+	if err := o.commander.RunCommand(ctx,
+		NewSafeCmd("remote").WithSubSubCmd("add").WithRevArgs("origin", repoURL), opts,
+		"Add origin remote",
+	); err != nil {
+		return "", fmt.Errorf("failed to add remote '%s': %w", repoURL, err)
+	}
+
+	if err := o.commander.RunCommand(ctx,
+		NewSafeCmd("fetch").WithFlag("--depth", "1").WithRevArgs("origin", ref), opts,
+		fmt.Sprintf("Shallow fetch '%s'", ref),
+	); err != nil {
+		return "", fmt.Errorf("ref '%s' not found in '%s': %w", ref, repoURL, err)
+	}
+
+	if err := o.commander.RunCommand(ctx,
+		NewSafeCmd("checkout").WithRevArgs("FETCH_HEAD"), opts,
+		"Checkout FETCH_HEAD",
+	); err != nil {
+		return "", fmt.Errorf("failed to checkout FETCH_HEAD: %w", err)
+	}
+
+	fragmentOps := o.WithRepo(dest)
+
+	if subdir != "" {
+		if err := fragmentOps.InitSparseCheckoutCone(ctx); err != nil {
+			return "", fmt.Errorf("failed to enable sparse-checkout: %w", err)
+		}
+		if err := fragmentOps.SetSparseCheckoutPaths(ctx, []string{subdir}); err != nil {
+			return "", fmt.Errorf("failed to scope sparse-checkout to '%s': %w", subdir, err)
+		}
+		if err := fragmentOps.ApplyCheckout(ctx); err != nil {
+			return "", fmt.Errorf("failed to apply sparse-checkout to '%s': %w", subdir, err)
+		}
+	}
+
+	resolvedRef, err = fragmentOps.GetCommitHash(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve commit sha for '%s': %w", ref, err)
+	}
+	return resolvedRef, nil
+}
@@ -0,0 +1,160 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initFragmentSourceRepo creates a real git repository (via the git binary) with a "main" branch
+// seeding two top-level entries, one of which is a subdirectory, plus a "coursework" branch with
+// its own extra file — enough to exercise ref selection and subdir scoping independently.
+func initFragmentSourceRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	run("init", "-b", "main")
+	run("config", "user.email", "fragment@example.com")
+	run("config", "user.name", "Fragment Test")
+
+	if err := os.MkdirAll(filepath.Join(dir, "assignment-1"), 0755); err != nil {
+		t.Fatalf("failed to create assignment-1: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "assignment-1", "README.md"), []byte("assignment 1\n"), 0644); err != nil {
+		t.Fatalf("failed to write assignment-1/README.md: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "other.txt"), []byte("unrelated\n"), 0644); err != nil {
+		t.Fatalf("failed to write other.txt: %v", err)
+	}
+	run("add", ".")
+	run("commit", "-m", "seed main")
+
+	run("checkout", "-b", "coursework")
+	if err := os.WriteFile(filepath.Join(dir, "assignment-1", "EXTRA.md"), []byte("extra\n"), 0644); err != nil {
+		t.Fatalf("failed to write assignment-1/EXTRA.md: %v", err)
+	}
+	run("add", ".")
+	run("commit", "-m", "coursework branch commit")
+	run("checkout", "main")
+
+	return dir
+}
+
+// TestCloneFragmentWithSubdir pulls a named branch scoped to a subdir and asserts the checkout
+// only contains that subdir's contents and resolves to the branch's actual commit sha.
+func TestCloneFragmentWithSubdir(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available, skipping fragment URL test")
+	}
+
+	sourceDir := initFragmentSourceRepo(t)
+	destDir := filepath.Join(t.TempDir(), "dest")
+
+	wantSHA := strings.TrimSpace(runGit(t, sourceDir, "rev-parse", "coursework"))
+
+	ops := NewOperations(false)
+	resolvedRef, err := ops.CloneFragment(context.Background(), sourceDir+"#coursework:assignment-1", destDir)
+	if err != nil {
+		t.Fatalf("CloneFragment failed: %v", err)
+	}
+
+	if resolvedRef != wantSHA {
+		t.Errorf("resolvedRef = %q, want %q", resolvedRef, wantSHA)
+	}
+
+	if _, err := os.Stat(filepath.Join(destDir, "assignment-1", "EXTRA.md")); err != nil {
+		t.Errorf("expected assignment-1/EXTRA.md to be checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "other.txt")); !os.IsNotExist(err) {
+		t.Errorf("expected other.txt to be excluded by sparse-checkout, stat err = %v", err)
+	}
+}
+
+// TestCloneFragmentDefaultsToHEAD pulls a bare repo URL with no fragment and expects it to
+// resolve the remote's default branch with no subdir scoping.
+func TestCloneFragmentDefaultsToHEAD(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available, skipping fragment URL test")
+	}
+
+	sourceDir := initFragmentSourceRepo(t)
+	destDir := filepath.Join(t.TempDir(), "dest")
+
+	ops := NewOperations(false)
+	resolvedRef, err := ops.CloneFragment(context.Background(), sourceDir, destDir)
+	if err != nil {
+		t.Fatalf("CloneFragment failed: %v", err)
+	}
+	if resolvedRef == "" {
+		t.Error("expected a non-empty resolved commit sha")
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "other.txt")); err != nil {
+		t.Errorf("expected full checkout without subdir scoping: %v", err)
+	}
+}
+
+// TestCloneFragmentMissingRef asserts a ref that doesn't exist on the remote fails clearly
+// instead of silently falling back to the default branch.
+func TestCloneFragmentMissingRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available, skipping fragment URL test")
+	}
+
+	sourceDir := initFragmentSourceRepo(t)
+	destDir := filepath.Join(t.TempDir(), "dest")
+
+	ops := NewOperations(false)
+	if _, err := ops.CloneFragment(context.Background(), sourceDir+"#does-not-exist", destDir); err == nil {
+		t.Error("expected an error for a nonexistent ref, got nil")
+	}
+}
+
+// TestParseFragmentURL covers the ref/subdir splitting rules CloneFragment relies on.
+func TestParseFragmentURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantRepo   string
+		wantRef    string
+		wantSubdir string
+	}{
+		{"no fragment", "https://host/org/repo.git", "https://host/org/repo.git", "HEAD", ""},
+		{"ref only", "https://host/org/repo.git#v1.2.0", "https://host/org/repo.git", "v1.2.0", ""},
+		{"ref and subdir", "https://host/org/repo.git#main:assignment-1", "https://host/org/repo.git", "main", "assignment-1"},
+		{"sha and nested subdir", "https://host/org/repo.git#a1b2c3d:course/week1", "https://host/org/repo.git", "a1b2c3d", "course/week1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoURL, ref, subdir := parseFragmentURL(tt.url)
+			if repoURL != tt.wantRepo || ref != tt.wantRef || subdir != tt.wantSubdir {
+				t.Errorf("parseFragmentURL(%q) = (%q, %q, %q), want (%q, %q, %q)",
+					tt.url, repoURL, ref, subdir, tt.wantRepo, tt.wantRef, tt.wantSubdir)
+			}
+		})
+	}
+}
+
+// runGit runs git with args in dir and returns its combined stdout/stderr, failing the test on
+// error.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+	return string(out)
+}
@@ -0,0 +1,75 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// CommitService creates commits and queries commit hashes
+type CommitService interface {
+	Commit(ctx context.Context, message string) error
+	GetCommitHash(ctx context.Context) (string, error)
+	GetShortCommitHash(ctx context.Context) (string, error)
+	GetCommitDifferences(ctx context.Context, local, upstream string) (ahead, behind string, err error)
+}
+
+// commitService is the exec-based CommitService implementation
+type commitService struct{ base }
+
+var _ CommitService = commitService{}
+
+// Commit creates a commit with the specified message
+func (s commitService) Commit(ctx context.Context, message string) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("commit").WithFlag("-m", message), s.runOpts(),
+		"Commit changes",
+	)
+}
+
+// GetCommitHash returns the current commit hash. This uses Commander.RunArgsWithOutput rather
+// than SafeCmd: SafeCmd.WithArgs always inserts a "--" separator before its positional arguments,
+// but "rev-parse -- HEAD" makes git treat HEAD as a pathspec instead of a revision, which breaks
+// the lookup entirely.
+func (s commitService) GetCommitHash(ctx context.Context) (string, error) {
+	return s.commander.RunArgsWithOutput(ctx,
+		[]string{"rev-parse", "HEAD"}, s.runOpts(),
+		"Get commit hash",
+	)
+}
+
+// GetShortCommitHash returns the short current commit hash. See GetCommitHash for why this uses
+// RunArgsWithOutput instead of SafeCmd.WithArgs.
+func (s commitService) GetShortCommitHash(ctx context.Context) (string, error) {
+	return s.commander.RunArgsWithOutput(ctx,
+		[]string{"rev-parse", "--short", "HEAD"}, s.runOpts(),
+		"Get short commit hash",
+	)
+}
+
+// GetCommitDifferences reports how far local and upstream have diverged: ahead is the commit
+// count reachable from local but not upstream, behind the reverse. Either count is "?" instead
+// of failing outright if its rev-list fails (e.g. upstream doesn't exist locally yet), so a
+// caller still gets useful information out of the side that succeeded.
+//
+// This uses Commander.RunArgsWithOutput rather than SafeCmd: SafeCmd.WithArgs always inserts a
+// "--" separator before its positional arguments, but "rev-list -- a..b" makes git treat a..b as
+// a pathspec instead of a revision range, which breaks --count entirely.
+func (s commitService) GetCommitDifferences(ctx context.Context, local, upstream string) (ahead, behind string, err error) {
+	ahead, aheadErr := s.commander.RunArgsWithOutput(ctx,
+		[]string{"rev-list", upstream + ".." + local, "--count"}, s.runOpts(),
+		fmt.Sprintf("Count commits ahead of '%s'", upstream),
+	)
+	if aheadErr != nil {
+		ahead = "?"
+	}
+
+	behind, behindErr := s.commander.RunArgsWithOutput(ctx,
+		[]string{"rev-list", local + ".." + upstream, "--count"}, s.runOpts(),
+		fmt.Sprintf("Count commits behind '%s'", upstream),
+	)
+	if behindErr != nil {
+		behind = "?"
+	}
+
+	return ahead, behind, nil
+}
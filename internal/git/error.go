@@ -0,0 +1,162 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GitError is a structured error returned by Operations methods, capturing enough context about
+// a failed git invocation to debug it from CI logs without having to reproduce it locally, and
+// enough structure for callers to tell failure kinds apart programmatically (via the IsXxx
+// helpers below) instead of matching on error strings.
+type GitError struct {
+	Root     string
+	Args     []string
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Err      error
+}
+
+// Error renders the failure as "'git <args>' failed in <root>: <exit>" followed by captured
+// stdout/stderr, if any
+func (e *GitError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "'git %s' failed in %s: exit status %d", strings.Join(e.Args, " "), e.Root, e.ExitCode)
+	if e.Stdout != "" {
+		fmt.Fprintf(&sb, "\nstdout:\n%s", e.Stdout)
+	}
+	if e.Stderr != "" {
+		fmt.Fprintf(&sb, "\nstderr:\n%s", e.Stderr)
+	}
+	return sb.String()
+}
+
+// Unwrap exposes the underlying process error (or sentinel, once classified) for errors.Is/As
+func (e *GitError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError aggregates the independent failures encountered by fan-out operations like
+// Operations.PushBranches, which push several branches concurrently and report every failure
+// instead of aborting on the first one
+type MultiError struct {
+	Errors []error
+}
+
+// Error joins the individual error messages, one per line
+func (m *MultiError) Error() string {
+	messages := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d error(s) occurred: %s", len(m.Errors), strings.Join(messages, "; "))
+}
+
+// Unwrap exposes the individual errors for errors.Is/errors.As
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
+
+// Sentinel errors identifying specific, recoverable git failure modes. Check a returned error
+// with errors.Is to distinguish these from generic command failures.
+var (
+	// ErrSparseCheckoutNotEnabled indicates a sparse-checkout command failed because
+	// sparse-checkout was never enabled in the first place
+	ErrSparseCheckoutNotEnabled = errors.New("sparse-checkout is not enabled")
+
+	// ErrUnsupported indicates an operation has no native go-git equivalent, e.g. sparse-checkout
+	// (which go-git doesn't implement at all) or merging (for which go-git has no API). Check a
+	// returned error with errors.Is to distinguish this from a real command failure.
+	ErrUnsupported = errors.New("operation not supported by the native go-git backend")
+)
+
+// UnsupportedOperationError wraps ErrUnsupported with the name of the operation that was
+// attempted, so callers see what was actually unsupported instead of a bare sentinel message.
+type UnsupportedOperationError struct {
+	Operation string
+}
+
+// Error renders as "<operation>: operation not supported by the native go-git backend"
+func (e *UnsupportedOperationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Operation, ErrUnsupported)
+}
+
+// Unwrap exposes ErrUnsupported for errors.Is
+func (e *UnsupportedOperationError) Unwrap() error {
+	return ErrUnsupported
+}
+
+// classifySparseCheckoutDisableError recognizes the "not enabled" failure mode of
+// `git sparse-checkout disable` and wraps ErrSparseCheckoutNotEnabled into the returned GitError
+// so callers can tell it apart from a real failure via errors.Is
+func classifySparseCheckoutDisableError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var gitErr *GitError
+	if errors.As(err, &gitErr) && strings.Contains(strings.ToLower(gitErr.Stderr), "sparse-checkout") {
+		gitErr.Err = fmt.Errorf("%w: %s", ErrSparseCheckoutNotEnabled, gitErr.Err)
+	}
+
+	return err
+}
+
+// IsNotRepository reports whether err failed because the working directory isn't a git
+// repository, e.g. `git rev-parse --git-dir` failing outside any repository
+func IsNotRepository(err error) bool {
+	var gitErr *GitError
+	if !errors.As(err, &gitErr) {
+		return false
+	}
+	return strings.Contains(strings.ToLower(gitErr.Stderr), "not a git repository")
+}
+
+// IsMergeConflict reports whether err is a failed merge due to a conflicting file, recognized by
+// git's "CONFLICT (" marker in stderr
+func IsMergeConflict(err error) bool {
+	var gitErr *GitError
+	if !errors.As(err, &gitErr) {
+		return false
+	}
+	return strings.Contains(gitErr.Stderr, "CONFLICT (")
+}
+
+// IsNonFastForward reports whether err is a push rejected because the remote has commits the
+// local branch doesn't know about
+func IsNonFastForward(err error) bool {
+	var gitErr *GitError
+	if !errors.As(err, &gitErr) {
+		return false
+	}
+	stderr := strings.ToLower(gitErr.Stderr)
+	return strings.Contains(stderr, "non-fast-forward") || strings.Contains(stderr, "fetch first")
+}
+
+// IsBranchExists reports whether err is a failed branch creation because a branch with that
+// name already exists
+func IsBranchExists(err error) bool {
+	var gitErr *GitError
+	if !errors.As(err, &gitErr) {
+		return false
+	}
+	return strings.Contains(strings.ToLower(gitErr.Stderr), "already exists")
+}
+
+// IsProtectedBranch reports whether err is a push rejected by a remote branch protection rule,
+// recognized by GitHub's "protected branch" hook-decline message
+func IsProtectedBranch(err error) bool {
+	var gitErr *GitError
+	if !errors.As(err, &gitErr) {
+		return false
+	}
+	return strings.Contains(strings.ToLower(gitErr.Stderr), "protected branch")
+}
+
+// IsUnsupported reports whether err is an *UnsupportedOperationError, e.g. a sparse-checkout or
+// merge call made against the native go-git backend
+func IsUnsupported(err error) bool {
+	return errors.Is(err, ErrUnsupported)
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// scriptedCall is one entry in a FakeRunner's expected call sequence: the argv it must see
+// next, and the canned stdout/stderr/error to hand back instead of actually invoking git.
+type scriptedCall struct {
+	args   []string
+	stdout string
+	stderr string
+	err    error
+}
+
+// FakeRunner is a CmdRunner that replays an ordered script of expected argv slices instead of
+// shelling out to git, modeled on lazygit's oscommands.FakeCmdObjRunner. It lets a test assert
+// on the exact git invocation a Commander method produces (e.g. "checkout -b <name>") rather
+// than relying on dry-run to swallow everything.
+type FakeRunner struct {
+	t        *testing.T
+	expected []scriptedCall
+	calls    int
+}
+
+var _ CmdRunner = (*FakeRunner)(nil)
+
+// NewFakeRunner creates an empty FakeRunner; chain Expect calls to build up its script.
+func NewFakeRunner(t *testing.T) *FakeRunner {
+	return &FakeRunner{t: t}
+}
+
+// Expect appends a call to the script: the next Run must be called with args, and will return
+// stdout/stderr/err instead of actually running git.
+func (f *FakeRunner) Expect(args []string, stdout, stderr string, err error) *FakeRunner {
+	f.expected = append(f.expected, scriptedCall{args: args, stdout: stdout, stderr: stderr, err: err})
+	return f
+}
+
+// Run implements CmdRunner by consuming the next scripted call, failing the test immediately if
+// there isn't one or if args doesn't match what was expected.
+func (f *FakeRunner) Run(_ context.Context, args []string, _ *RunOpts) (stdout, stderr *bytes.Buffer, err error) {
+	f.t.Helper()
+
+	if f.calls >= len(f.expected) {
+		f.t.Fatalf("unexpected git invocation %v: script only has %d call(s)", args, len(f.expected))
+	}
+
+	call := f.expected[f.calls]
+	f.calls++
+
+	if !reflect.DeepEqual(args, call.args) {
+		f.t.Errorf("call %d: git argv = %v, want %v", f.calls, args, call.args)
+	}
+
+	return bytes.NewBufferString(call.stdout), bytes.NewBufferString(call.stderr), call.err
+}
+
+// AssertDone fails the test if the script has unconsumed expected calls left, catching a
+// Commander method that stopped short of the git invocations it was supposed to make.
+func (f *FakeRunner) AssertDone() {
+	f.t.Helper()
+	if f.calls != len(f.expected) {
+		f.t.Errorf("script had %d call(s), only %d were made", len(f.expected), f.calls)
+	}
+}
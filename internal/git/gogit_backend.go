@@ -0,0 +1,424 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// GoGitBackend implements Backend on top of go-git, operating purely in-process without
+// shelling out to the git binary. It's useful for containerized runners that don't ship a git
+// binary, and for tests that want to avoid process spawning.
+type GoGitBackend struct {
+	repositoryRoot string
+	dryRun         bool
+	repo           *git.Repository
+	token          string
+
+	// fs is the billy.Filesystem writeSparseCheckoutFile/DisableSparseCheckout write through,
+	// following srpmproc's pattern of routing repository file access through an abstract
+	// filesystem: osfs.New(repositoryRoot) for a live run, memfs.New() for dry-run, so dry-run
+	// writes land in memory instead of on disk without every call site needing its own "if
+	// dryRun" print-and-skip branch.
+	fs billy.Filesystem
+}
+
+// NewGoGitBackend opens repositoryRoot as a go-git repository. token authenticates
+// PushBranch/PushAllBranches/FetchAll/PullMainFromRemote against the remote; pass "" for
+// unauthenticated access.
+func NewGoGitBackend(repositoryRoot string, dryRun bool, token string) (*GoGitBackend, error) {
+	repo, err := git.PlainOpen(repositoryRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository at %s: %w", repositoryRoot, err)
+	}
+
+	fs := osfs.New(repositoryRoot)
+	if dryRun {
+		fs = memfs.New()
+	}
+
+	return &GoGitBackend{
+		repositoryRoot: repositoryRoot,
+		dryRun:         dryRun,
+		repo:           repo,
+		token:          token,
+		fs:             fs,
+	}, nil
+}
+
+// auth returns the BasicAuth credentials to use for PushContext/FetchContext, or nil when no
+// token was configured (e.g. a public remote, or a local/dry-run workflow)
+func (b *GoGitBackend) auth() *http.BasicAuth {
+	if b.token == "" {
+		return nil
+	}
+	return &http.BasicAuth{Username: "x-access-token", Password: b.token}
+}
+
+// Ensure GoGitBackend satisfies Backend
+var _ Backend = (*GoGitBackend)(nil)
+
+// GetCurrentBranch returns the short name of the branch HEAD points at
+func (b *GoGitBackend) GetCurrentBranch(ctx context.Context) (string, error) {
+	head, err := b.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return head.Name().Short(), nil
+}
+
+// SwitchToBranch checks out an existing local branch
+func (b *GoGitBackend) SwitchToBranch(ctx context.Context, branchName string) error {
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Switch to branch '%s' (go-git)\n", branchName)
+		return nil
+	}
+
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	err = worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branchName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to switch to branch '%s': %w", branchName, err)
+	}
+	return nil
+}
+
+// CreateAndSwitchToBranch creates a new branch from the current HEAD and switches to it
+func (b *GoGitBackend) CreateAndSwitchToBranch(ctx context.Context, branchName string) error {
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Create and switch to branch '%s' (go-git)\n", branchName)
+		return nil
+	}
+
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	err = worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branchName),
+		Create: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create and switch to branch '%s': %w", branchName, err)
+	}
+	return nil
+}
+
+// AddFile stages a file for commit
+func (b *GoGitBackend) AddFile(ctx context.Context, filePath string) error {
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Stage file '%s' (go-git)\n", filePath)
+		return nil
+	}
+
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	relativePath, err := filepath.Rel(b.repositoryRoot, filePath)
+	if err != nil {
+		relativePath = filePath
+	}
+
+	if _, err := worktree.Add(filepath.ToSlash(relativePath)); err != nil {
+		return fmt.Errorf("failed to stage file '%s': %w", filePath, err)
+	}
+	return nil
+}
+
+// Commit creates a commit with the specified message
+func (b *GoGitBackend) Commit(ctx context.Context, message string) error {
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Commit changes: %s (go-git)\n", message)
+		return nil
+	}
+
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	if _, err := worktree.Commit(message, &git.CommitOptions{}); err != nil {
+		return fmt.Errorf("failed to commit changes: %w", err)
+	}
+	return nil
+}
+
+// FetchAll fetches all remote branches and tags
+func (b *GoGitBackend) FetchAll(ctx context.Context) error {
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Fetch all remote branches and tags (go-git)\n")
+		return nil
+	}
+
+	err := b.repo.Fetch(&git.FetchOptions{RemoteName: "origin", Auth: b.auth()})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to fetch from origin: %w", err)
+	}
+	return nil
+}
+
+// PushAllBranches pushes all local branches to remote
+func (b *GoGitBackend) PushAllBranches(ctx context.Context) error {
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Push all local branches to remote (go-git)\n")
+		return nil
+	}
+
+	err := b.repo.Push(&git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{"refs/heads/*:refs/heads/*"},
+		Auth:       b.auth(),
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to push all branches: %w", err)
+	}
+	return nil
+}
+
+// PushBranch pushes a specific branch to remote
+func (b *GoGitBackend) PushBranch(ctx context.Context, branchName string) error {
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Push branch '%s' to remote (go-git)\n", branchName)
+		return nil
+	}
+
+	refSpec := config.RefSpec(fmt.Sprintf("refs/heads/%s:refs/heads/%s", branchName, branchName))
+	err := b.repo.Push(&git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{refSpec},
+		Auth:       b.auth(),
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to push branch '%s': %w", branchName, err)
+	}
+	return nil
+}
+
+// MergeBranchToMain is not supported by the go-git backend; go-git has no merge API, so this
+// mirrors the exec backend's two-step shape but reports the unsupported operation
+func (b *GoGitBackend) MergeBranchToMain(ctx context.Context, branchName string) error {
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Merge branch '%s' into main (go-git)\n", branchName)
+		return nil
+	}
+	return fmt.Errorf("merging branch '%s' is not supported by the go-git backend", branchName)
+}
+
+// UpdateBranchFromMain is not supported by the go-git backend; see MergeBranchToMain
+func (b *GoGitBackend) UpdateBranchFromMain(ctx context.Context, branchName string) error {
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Update branch '%s' with latest changes from main (go-git)\n", branchName)
+		return nil
+	}
+	return fmt.Errorf("updating branch '%s' from main is not supported by the go-git backend", branchName)
+}
+
+// PullMainFromRemote pulls the latest changes from remote main
+func (b *GoGitBackend) PullMainFromRemote(ctx context.Context) error {
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Pull latest changes from remote main (go-git)\n")
+		return nil
+	}
+
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	err = worktree.Pull(&git.PullOptions{RemoteName: "origin", Auth: b.auth()})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to pull from origin main: %w", err)
+	}
+	return nil
+}
+
+// GetLocalBranches returns a map of local branch names
+func (b *GoGitBackend) GetLocalBranches(ctx context.Context) (map[string]bool, error) {
+	branches := make(map[string]bool)
+
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Would check local branches (go-git)\n")
+		return branches, nil
+	}
+
+	refs, err := b.repo.Branches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local branches: %w", err)
+	}
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		branches[ref.Name().Short()] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate local branches: %w", err)
+	}
+
+	return branches, nil
+}
+
+// GetRemoteBranches gets the set of remote branch names, excluding the default branch
+func (b *GoGitBackend) GetRemoteBranches(ctx context.Context, defaultBranch string) (map[string]bool, error) {
+	remoteBranches := make(map[string]bool)
+
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Would check remote branches (go-git)\n")
+		return remoteBranches, nil
+	}
+
+	refs, err := b.repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list references: %w", err)
+	}
+
+	const remotePrefix = "refs/remotes/origin/"
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().String()
+		if !strings.HasPrefix(name, remotePrefix) {
+			return nil
+		}
+		branchName := strings.TrimPrefix(name, remotePrefix)
+		if branchName != "HEAD" && branchName != defaultBranch && branchName != "" {
+			remoteBranches[branchName] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate remote references: %w", err)
+	}
+
+	return remoteBranches, nil
+}
+
+// sparseCheckoutFilePath returns the path to the sparse-checkout configuration, relative to
+// b.fs's root
+func (b *GoGitBackend) sparseCheckoutFilePath() string {
+	return filepath.Join(".git", "info", "sparse-checkout")
+}
+
+// InitSparseCheckout initializes sparse-checkout
+func (b *GoGitBackend) InitSparseCheckout(ctx context.Context) error {
+	return b.writeSparseCheckoutFile([]string{"/*"})
+}
+
+// InitSparseCheckoutCone initializes sparse-checkout in cone mode
+func (b *GoGitBackend) InitSparseCheckoutCone(ctx context.Context) error {
+	return b.writeSparseCheckoutFile([]string{"/*", "!/*/"})
+}
+
+// SetSparseCheckoutPaths sets the sparse-checkout paths by writing the config file directly
+// through the repository's filesystem and re-applying the worktree
+func (b *GoGitBackend) SetSparseCheckoutPaths(ctx context.Context, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no paths provided for sparse-checkout")
+	}
+
+	if err := b.writeSparseCheckoutFile(paths); err != nil {
+		return err
+	}
+
+	return b.ApplyCheckout(ctx)
+}
+
+// DisableSparseCheckout disables sparse-checkout by removing the configuration file from b.fs.
+// In dry-run mode b.fs is an in-memory filesystem that was never written to, so this is already
+// a no-op without needing its own dry-run branch.
+func (b *GoGitBackend) DisableSparseCheckout(ctx context.Context) error {
+	if err := b.fs.Remove(b.sparseCheckoutFilePath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to disable sparse-checkout: %w", err)
+	}
+	return nil
+}
+
+// writeSparseCheckoutFile writes the sparse-checkout pattern list through b.fs, the repository's
+// billy filesystem (osfs for a live run, memfs in dry-run, see NewGoGitBackend)
+func (b *GoGitBackend) writeSparseCheckoutFile(patterns []string) error {
+	content := strings.Join(patterns, "\n") + "\n"
+
+	if err := b.fs.MkdirAll(filepath.Join(".git", "info"), 0755); err != nil {
+		return fmt.Errorf("failed to create sparse-checkout directory: %w", err)
+	}
+
+	file, err := b.fs.Create(b.sparseCheckoutFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to create sparse-checkout file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write([]byte(content)); err != nil {
+		return fmt.Errorf("failed to write sparse-checkout file: %w", err)
+	}
+	return nil
+}
+
+// ApplyCheckout applies sparse-checkout changes by re-checking out HEAD against the worktree,
+// the go-git equivalent of `git read-tree -m -u HEAD`
+func (b *GoGitBackend) ApplyCheckout(ctx context.Context) error {
+	if b.dryRun {
+		fmt.Printf("[DRY RUN] Apply checkout changes (go-git)\n")
+		return nil
+	}
+
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	head, err := b.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	err = worktree.Checkout(&git.CheckoutOptions{
+		Branch: head.Name(),
+		Force:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply checkout changes: %w", err)
+	}
+	return nil
+}
+
+// IsRepository checks whether repositoryRoot is a git repository
+func (b *GoGitBackend) IsRepository(ctx context.Context) (bool, error) {
+	return b.repo != nil, nil
+}
+
+// GetCommitHash returns the current commit hash
+func (b *GoGitBackend) GetCommitHash(ctx context.Context) (string, error) {
+	head, err := b.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return head.Hash().String(), nil
+}
+
+// GetShortCommitHash returns the short current commit hash
+func (b *GoGitBackend) GetShortCommitHash(ctx context.Context) (string, error) {
+	hash, err := b.GetCommitHash(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(hash) < 7 {
+		return hash, nil
+	}
+	return hash[:7], nil
+}
@@ -0,0 +1,154 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// gogitBranchService is the go-git-based BranchService implementation
+type gogitBranchService struct{ gogitBase }
+
+var _ BranchService = gogitBranchService{}
+
+// SwitchToBranch checks out an existing local branch
+func (s gogitBranchService) SwitchToBranch(ctx context.Context, branchName string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Switch to branch '%s' (go-git)\n", branchName)
+		return nil
+	}
+
+	worktree, err := s.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	err = worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branchName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to switch to branch '%s': %w", branchName, err)
+	}
+	return nil
+}
+
+// CreateAndSwitchToBranch creates a new branch from the current HEAD and switches to it
+func (s gogitBranchService) CreateAndSwitchToBranch(ctx context.Context, branchName string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Create and switch to branch '%s' (go-git)\n", branchName)
+		return nil
+	}
+
+	worktree, err := s.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	err = worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branchName),
+		Create: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create and switch to branch '%s': %w", branchName, err)
+	}
+	return nil
+}
+
+// MergeBranchToMain is not supported by the go-git backend; go-git has no merge API, so this
+// returns an *UnsupportedOperationError (wrapping ErrUnsupported) instead of half-implementing it
+func (s gogitBranchService) MergeBranchToMain(ctx context.Context, branchName string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Merge branch '%s' into main (go-git)\n", branchName)
+		return nil
+	}
+	return &UnsupportedOperationError{Operation: fmt.Sprintf("merge branch '%s' into main", branchName)}
+}
+
+// UpdateBranchFromMain is not supported by the go-git backend; see MergeBranchToMain
+func (s gogitBranchService) UpdateBranchFromMain(ctx context.Context, branchName string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Update branch '%s' with latest changes from main (go-git)\n", branchName)
+		return nil
+	}
+	return &UnsupportedOperationError{Operation: fmt.Sprintf("update branch '%s' from main", branchName)}
+}
+
+// GetLocalBranches returns a map of local branch names
+func (s gogitBranchService) GetLocalBranches(ctx context.Context) (map[string]bool, error) {
+	branches := make(map[string]bool)
+
+	if s.dryRun {
+		fmt.Println("[DRY RUN] Would check local branches (go-git)")
+		return branches, nil
+	}
+
+	refs, err := s.repo.Branches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local branches: %w", err)
+	}
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		branches[ref.Name().Short()] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate local branches: %w", err)
+	}
+
+	fmt.Printf("Found %d local branches\n", len(branches))
+	return branches, nil
+}
+
+// GetCurrentBranch returns the short name of the branch HEAD points at
+func (s gogitBranchService) GetCurrentBranch(ctx context.Context) (string, error) {
+	head, err := s.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return head.Name().Short(), nil
+}
+
+// DeleteBranch force-deletes the local branch branchName
+func (s gogitBranchService) DeleteBranch(ctx context.Context, branchName string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Delete local branch '%s' (go-git)\n", branchName)
+		return nil
+	}
+
+	if err := s.repo.Storer.RemoveReference(plumbing.NewBranchReferenceName(branchName)); err != nil {
+		return fmt.Errorf("failed to delete local branch '%s': %w", branchName, err)
+	}
+	return nil
+}
+
+// CreateWorktreeForNewBranch is not supported by the go-git backend; go-git has no linked-worktree
+// API, so this returns an *UnsupportedOperationError instead of half-implementing it. A caller
+// relying on worktree-based parallelism (see creator.Creator) falls back to sequential processing
+// on this backend.
+func (s gogitBranchService) CreateWorktreeForNewBranch(ctx context.Context, dir, branchName, startPoint string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Add worktree at %s for new branch '%s' (go-git)\n", dir, branchName)
+		return nil
+	}
+	return &UnsupportedOperationError{Operation: fmt.Sprintf("add worktree at %s for new branch '%s'", dir, branchName)}
+}
+
+// AddWorktreeForBranch is not supported by the go-git backend; see CreateWorktreeForNewBranch
+func (s gogitBranchService) AddWorktreeForBranch(ctx context.Context, dir, branchName string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Add worktree at %s for branch '%s' (go-git)\n", dir, branchName)
+		return nil
+	}
+	return &UnsupportedOperationError{Operation: fmt.Sprintf("add worktree at %s for branch '%s'", dir, branchName)}
+}
+
+// RemoveWorktree is not supported by the go-git backend; see CreateWorktreeForNewBranch
+func (s gogitBranchService) RemoveWorktree(ctx context.Context, dir string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Remove worktree at %s (go-git)\n", dir)
+		return nil
+	}
+	return &UnsupportedOperationError{Operation: fmt.Sprintf("remove worktree at %s", dir)}
+}
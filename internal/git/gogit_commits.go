@@ -0,0 +1,52 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// gogitCommitService is the go-git-based CommitService implementation
+type gogitCommitService struct{ gogitBase }
+
+var _ CommitService = gogitCommitService{}
+
+// Commit creates a commit with the specified message
+func (s gogitCommitService) Commit(ctx context.Context, message string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Commit changes: %s (go-git)\n", message)
+		return nil
+	}
+
+	worktree, err := s.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	if _, err := worktree.Commit(message, &git.CommitOptions{}); err != nil {
+		return fmt.Errorf("failed to commit changes: %w", err)
+	}
+	return nil
+}
+
+// GetCommitHash returns the current commit hash
+func (s gogitCommitService) GetCommitHash(ctx context.Context) (string, error) {
+	head, err := s.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return head.Hash().String(), nil
+}
+
+// GetShortCommitHash returns the short current commit hash
+func (s gogitCommitService) GetShortCommitHash(ctx context.Context) (string, error) {
+	hash, err := s.GetCommitHash(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(hash) < 7 {
+		return hash, nil
+	}
+	return hash[:7], nil
+}
@@ -0,0 +1,151 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// conformanceClient is the subset of Client exercised by the conformance scenarios, satisfied by
+// both *ExecClient and *GoGitClient
+type conformanceClient interface {
+	Branches() BranchService
+	Commits() CommitService
+	WorkTree() WorkTreeService
+}
+
+// initConformanceRepo creates a bare-bones git repository (real git binary, not go-git) with a
+// single commit on main, so both backends start from identical on-disk state
+func initConformanceRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	run("init", "-b", "main")
+	run("config", "user.email", "conformance@example.com")
+	run("config", "user.name", "Conformance Test")
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("seed\n"), 0644); err != nil {
+		t.Fatalf("failed to seed README.md: %v", err)
+	}
+	run("add", "README.md")
+	run("commit", "-m", "seed commit")
+
+	return dir
+}
+
+// runConformanceScenario creates a branch, stages a new file, and commits it, returning the
+// resulting current branch, local branches, and committed file's content so the caller can diff the two
+// backends' results
+func runConformanceScenario(t *testing.T, dir string, client conformanceClient) (currentBranch string, localBranches map[string]bool, fileContent string) {
+	t.Helper()
+	ctx := context.Background()
+
+	if err := client.Branches().CreateAndSwitchToBranch(ctx, "feature"); err != nil {
+		t.Fatalf("CreateAndSwitchToBranch failed: %v", err)
+	}
+
+	filePath := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(filePath, []byte("conformance\n"), 0644); err != nil {
+		t.Fatalf("failed to write notes.txt: %v", err)
+	}
+	if err := client.WorkTree().AddFile(ctx, filePath); err != nil {
+		t.Fatalf("AddFile failed: %v", err)
+	}
+	if err := client.Commits().Commit(ctx, "add notes"); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	currentBranch, err := client.Branches().GetCurrentBranch(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentBranch failed: %v", err)
+	}
+
+	localBranches, err = client.Branches().GetLocalBranches(ctx)
+	if err != nil {
+		t.Fatalf("GetLocalBranches failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read back notes.txt: %v", err)
+	}
+
+	return currentBranch, localBranches, string(content)
+}
+
+// TestGoGitConformance runs the same branch/add/commit scenario against the exec-based and
+// go-git-based backends on two otherwise-identical repositories, and asserts they leave the
+// on-disk state in agreement. Sparse-checkout isn't covered here since it's intentionally
+// unsupported on the native backend (see gogit_sparse.go).
+func TestGoGitConformance(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available, skipping conformance test")
+	}
+
+	shellDir := initConformanceRepo(t)
+	nativeDir := initConformanceRepo(t)
+
+	shellClient := NewClient(false).WithRepo(shellDir)
+
+	nativeClient, err := NewGoGitClient(nativeDir, false, "")
+	if err != nil {
+		t.Fatalf("NewGoGitClient failed: %v", err)
+	}
+
+	shellBranch, shellLocalBranches, shellContent := runConformanceScenario(t, shellDir, shellClient)
+	nativeBranch, nativeLocalBranches, nativeContent := runConformanceScenario(t, nativeDir, nativeClient)
+
+	if shellBranch != nativeBranch {
+		t.Errorf("current branch mismatch: shell=%q native=%q", shellBranch, nativeBranch)
+	}
+	if shellContent != nativeContent {
+		t.Errorf("file content mismatch: shell=%q native=%q", shellContent, nativeContent)
+	}
+	for _, branch := range []string{"main", "feature"} {
+		if shellLocalBranches[branch] != nativeLocalBranches[branch] {
+			t.Errorf("local branch %q mismatch: shell=%v native=%v", branch, shellLocalBranches[branch], nativeLocalBranches[branch])
+		}
+	}
+}
+
+// TestGoGitSparseUnsupported asserts every SparseService method on the native backend fails with
+// ErrUnsupported instead of silently no-oping or half-implementing sparse-checkout
+func TestGoGitSparseUnsupported(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available, skipping conformance test")
+	}
+
+	dir := initConformanceRepo(t)
+	client, err := NewGoGitClient(dir, false, "")
+	if err != nil {
+		t.Fatalf("NewGoGitClient failed: %v", err)
+	}
+
+	ctx := context.Background()
+	sparse := client.Sparse()
+
+	scenarios := []func() error{
+		func() error { return sparse.InitSparseCheckout(ctx) },
+		func() error { return sparse.InitSparseCheckoutCone(ctx) },
+		func() error { return sparse.SetSparseCheckoutPaths(ctx, []string{"subdir"}) },
+		func() error { return sparse.DisableSparseCheckout(ctx) },
+		func() error { return sparse.ApplyCheckout(ctx) },
+	}
+
+	for i, scenario := range scenarios {
+		err := scenario()
+		if !IsUnsupported(err) {
+			t.Errorf("scenario %d: expected ErrUnsupported, got %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// gogitLFSService is the go-git-based LFSService implementation. Git LFS requires both the git
+// and git-lfs binaries to do anything useful (smudge/clean filters, the pull protocol), which
+// defeats the point of the native backend, so every method here returns an
+// *UnsupportedOperationError instead of shelling out anyway.
+type gogitLFSService struct{ gogitBase }
+
+var _ LFSService = gogitLFSService{}
+
+func (s gogitLFSService) Install(ctx context.Context) error {
+	return s.unsupported("install Git LFS")
+}
+
+func (s gogitLFSService) Track(ctx context.Context, patterns []string) error {
+	return s.unsupported("track Git LFS patterns")
+}
+
+func (s gogitLFSService) Pull(ctx context.Context, includes, excludes []string) error {
+	return s.unsupported("pull Git LFS objects")
+}
+
+func (s gogitLFSService) PointerFor(ctx context.Context, path string) (LFSPointer, error) {
+	return LFSPointer{}, s.unsupported("parse a Git LFS pointer file")
+}
+
+func (s gogitLFSService) unsupported(operation string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Would %s (go-git, unsupported on this backend)\n", operation)
+		return nil
+	}
+	return &UnsupportedOperationError{Operation: operation}
+}
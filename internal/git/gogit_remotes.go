@@ -0,0 +1,194 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"assignment-pull-request/internal/errs"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// gogitRemoteService is the go-git-based RemoteService implementation. It depends on
+// BranchService for the branch switch PullMainFromRemote needs before it can pull, same as
+// remoteService.
+type gogitRemoteService struct {
+	gogitBase
+	branches BranchService
+}
+
+var _ RemoteService = gogitRemoteService{}
+
+// FetchAll fetches all remote branches and tags
+func (s gogitRemoteService) FetchAll(ctx context.Context) error {
+	if s.dryRun {
+		fmt.Println("[DRY RUN] Fetch all remote branches and tags (go-git)")
+		return nil
+	}
+
+	err := s.repo.FetchContext(ctx, &git.FetchOptions{RemoteName: "origin", Auth: s.auth()})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to fetch from origin: %w", err)
+	}
+	return nil
+}
+
+// PushAllBranches pushes all local branches to remote
+func (s gogitRemoteService) PushAllBranches(ctx context.Context) error {
+	if s.dryRun {
+		fmt.Println("[DRY RUN] Push all local branches to remote (go-git)")
+		return nil
+	}
+
+	err := s.repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{"refs/heads/*:refs/heads/*"},
+		Auth:       s.auth(),
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return classifyGoGitPushError("push all branches", fmt.Errorf("failed to push all branches: %w", err))
+	}
+	return nil
+}
+
+// PushBranch pushes a specific branch to remote
+func (s gogitRemoteService) PushBranch(ctx context.Context, branchName string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Push branch '%s' to remote (go-git)\n", branchName)
+		return nil
+	}
+
+	refSpec := config.RefSpec(fmt.Sprintf("refs/heads/%s:refs/heads/%s", branchName, branchName))
+	err := s.repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{refSpec},
+		Auth:       s.auth(),
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return classifyGoGitPushError(branchName, fmt.Errorf("failed to push branch '%s': %w", branchName, err))
+	}
+	return nil
+}
+
+// DeleteRemoteBranch deletes branchName from origin by pushing an empty refspec source
+func (s gogitRemoteService) DeleteRemoteBranch(ctx context.Context, branchName string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Delete remote branch '%s' (go-git)\n", branchName)
+		return nil
+	}
+
+	refSpec := config.RefSpec(fmt.Sprintf(":refs/heads/%s", branchName))
+	err := s.repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{refSpec},
+		Auth:       s.auth(),
+	})
+	// Deleting a branch that's already gone from the remote isn't a failure worth reporting --
+	// NoErrAlreadyUpToDate covers a no-op push, and "remote ref does not exist" is go-git's text
+	// for "nothing to delete".
+	if err != nil && err != git.NoErrAlreadyUpToDate && !strings.Contains(err.Error(), "remote ref does not exist") {
+		return fmt.Errorf("failed to delete remote branch '%s': %w", branchName, err)
+	}
+	return nil
+}
+
+// classifyGoGitPushError recognizes a rejected push's failure mode from the go-git error's
+// message text -- go-git surfaces the remote's refusal reason (e.g. GitHub's "protected branch"
+// hook decline, or "non-fast-forward") as plain error text rather than a typed error, so this
+// mirrors classifyPushError's stderr-based classification for the exec backend instead of a
+// type switch. err is returned unchanged if it doesn't match a known rejection.
+func classifyGoGitPushError(task string, err error) error {
+	if err == nil {
+		return nil
+	}
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "protected branch"):
+		return errs.ErrProtectedBranch(task, err)
+	case strings.Contains(message, "non-fast-forward") || strings.Contains(message, "fetch first"):
+		return errs.ErrNonFastForward(task, err)
+	case strings.Contains(message, "already exists"):
+		return errs.ErrBranchExistsRemotely(task, err)
+	default:
+		return err
+	}
+}
+
+// PushBranches pushes each of the given branches in turn, aggregating failures into a
+// *MultiError the same way remoteService.PushBranches does. Unlike the exec backend, which
+// shells out to an independent git process per branch and can safely fan those out across a
+// worker pool, go-git's PushContext is not safe to call concurrently against the same
+// *git.Repository, so this pushes sequentially.
+func (s gogitRemoteService) PushBranches(ctx context.Context, branchNames []string) error {
+	var multiErr MultiError
+	for _, branchName := range branchNames {
+		if err := s.PushBranch(ctx, branchName); err != nil {
+			multiErr.Errors = append(multiErr.Errors, fmt.Errorf("pushing branch '%s': %w", branchName, err))
+		}
+	}
+
+	if len(multiErr.Errors) > 0 {
+		return &multiErr
+	}
+	return nil
+}
+
+// PullMainFromRemote pulls the latest changes from remote main
+func (s gogitRemoteService) PullMainFromRemote(ctx context.Context) error {
+	if err := s.branches.SwitchToBranch(ctx, "main"); err != nil {
+		return err
+	}
+
+	if s.dryRun {
+		fmt.Println("[DRY RUN] Pull latest changes from remote main (go-git)")
+		return nil
+	}
+
+	worktree, err := s.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	err = worktree.PullContext(ctx, &git.PullOptions{RemoteName: "origin", Auth: s.auth()})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to pull from origin main: %w", err)
+	}
+	return nil
+}
+
+// GetRemoteBranches gets the set of remote branch names, excluding the default branch
+func (s gogitRemoteService) GetRemoteBranches(ctx context.Context, defaultBranch string) (map[string]bool, error) {
+	remoteBranches := make(map[string]bool)
+
+	if s.dryRun {
+		fmt.Println("[DRY RUN] Would check remote branches (go-git)")
+		return remoteBranches, nil
+	}
+
+	refs, err := s.repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list references: %w", err)
+	}
+
+	const remotePrefix = "refs/remotes/origin/"
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().String()
+		if !strings.HasPrefix(name, remotePrefix) {
+			return nil
+		}
+		branchName := strings.TrimPrefix(name, remotePrefix)
+		if branchName != "HEAD" && branchName != defaultBranch && branchName != "" {
+			remoteBranches[branchName] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate remote references: %w", err)
+	}
+
+	fmt.Printf("Found %d remote branches\n", len(remoteBranches))
+	return remoteBranches, nil
+}
@@ -0,0 +1,48 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// gogitSparseService is the go-git-based SparseService implementation. go-git has no
+// sparse-checkout support at all, so every method here returns an *UnsupportedOperationError
+// rather than half-implementing the feature by poking at .git/info/sparse-checkout directly.
+type gogitSparseService struct{ gogitBase }
+
+var _ SparseService = gogitSparseService{}
+
+// InitSparseCheckout is not supported by the go-git backend
+func (s gogitSparseService) InitSparseCheckout(ctx context.Context) error {
+	return s.unsupported("initialize sparse-checkout")
+}
+
+// InitSparseCheckoutCone is not supported by the go-git backend
+func (s gogitSparseService) InitSparseCheckoutCone(ctx context.Context) error {
+	return s.unsupported("initialize sparse-checkout with cone mode")
+}
+
+// SetSparseCheckoutPaths is not supported by the go-git backend
+func (s gogitSparseService) SetSparseCheckoutPaths(ctx context.Context, paths []string) error {
+	return s.unsupported("set sparse-checkout paths")
+}
+
+// DisableSparseCheckout is not supported by the go-git backend
+func (s gogitSparseService) DisableSparseCheckout(ctx context.Context) error {
+	return s.unsupported("disable sparse-checkout")
+}
+
+// ApplyCheckout is not supported by the go-git backend
+func (s gogitSparseService) ApplyCheckout(ctx context.Context) error {
+	return s.unsupported("apply sparse-checkout")
+}
+
+// unsupported reports operation as not implemented by this backend, honoring dryRun like every
+// other gogit service method
+func (s gogitSparseService) unsupported(operation string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Would %s (go-git, unsupported on this backend)\n", operation)
+		return nil
+	}
+	return &UnsupportedOperationError{Operation: operation}
+}
@@ -0,0 +1,40 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+)
+
+// gogitWorkTreeService is the go-git-based WorkTreeService implementation
+type gogitWorkTreeService struct{ gogitBase }
+
+var _ WorkTreeService = gogitWorkTreeService{}
+
+// AddFile stages a file for commit
+func (s gogitWorkTreeService) AddFile(ctx context.Context, filePath string) error {
+	if s.dryRun {
+		fmt.Printf("[DRY RUN] Stage file '%s' (go-git)\n", filePath)
+		return nil
+	}
+
+	worktree, err := s.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	relativePath, err := filepath.Rel(s.repoDir, filePath)
+	if err != nil {
+		relativePath = filePath
+	}
+
+	if _, err := worktree.Add(filepath.ToSlash(relativePath)); err != nil {
+		return fmt.Errorf("failed to stage file '%s': %w", filePath, err)
+	}
+	return nil
+}
+
+// IsRepository reports whether the go-git repository was opened successfully
+func (s gogitWorkTreeService) IsRepository(ctx context.Context) (bool, error) {
+	return s.repo != nil, nil
+}
@@ -0,0 +1,173 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultLFSThreshold is the file-size cutoff, in bytes, above which Operations.AddFile routes a
+// file through Git LFS instead of committing it as a normal (possibly huge) blob
+const DefaultLFSThreshold int64 = 50 * 1024 * 1024 // 50 MB
+
+// LFSPointer is the parsed content of a Git LFS pointer file, in the "version/oid sha256/size"
+// format documented at https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md
+type LFSPointer struct {
+	Version string
+	OID     string
+	Size    int64
+}
+
+// LFSService installs Git LFS, tracks patterns in .gitattributes, pulls LFS objects, and parses
+// pointer files
+type LFSService interface {
+	Install(ctx context.Context) error
+	Track(ctx context.Context, patterns []string) error
+	Pull(ctx context.Context, includes, excludes []string) error
+	PointerFor(ctx context.Context, path string) (LFSPointer, error)
+}
+
+// lfsService is the exec-based LFSService implementation. Git LFS ships as a git subcommand, so
+// every method runs through the same Commander/SafeCmd as the rest of the per-domain services
+// rather than invoking the git-lfs binary directly.
+type lfsService struct{ base }
+
+var _ LFSService = lfsService{}
+
+// Install enables Git LFS for this repository only, without touching the user's global config
+func (s lfsService) Install(ctx context.Context) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("lfs").WithSubSubCmd("install").WithFlag("--local", ""), s.runOpts(),
+		"Install Git LFS",
+	)
+}
+
+// Track writes patterns to .gitattributes via `git lfs track` and stages the result
+func (s lfsService) Track(ctx context.Context, patterns []string) error {
+	if len(patterns) == 0 {
+		return fmt.Errorf("no patterns provided for lfs track")
+	}
+
+	if err := s.commander.RunCommand(ctx,
+		NewSafeCmd("lfs").WithSubSubCmd("track").WithArgs(patterns...), s.runOpts(),
+		"Track Git LFS patterns",
+	); err != nil {
+		return err
+	}
+
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("add").WithArgs(".gitattributes"), s.runOpts(),
+		"Stage .gitattributes",
+	)
+}
+
+// Pull downloads the LFS objects referenced by the current checkout, optionally scoped to
+// comma-separated includes/excludes glob patterns passed straight through to `git lfs pull`
+func (s lfsService) Pull(ctx context.Context, includes, excludes []string) error {
+	cmd := NewSafeCmd("lfs").WithSubSubCmd("pull")
+	if len(includes) > 0 {
+		cmd = cmd.WithFlag("--include", strings.Join(includes, ","))
+	}
+	if len(excludes) > 0 {
+		cmd = cmd.WithFlag("--exclude", strings.Join(excludes, ","))
+	}
+
+	return s.commander.RunCommand(ctx, cmd, s.runOpts(), "Pull Git LFS objects")
+}
+
+// PointerFor reads and parses the LFS pointer file at path
+func (s lfsService) PointerFor(ctx context.Context, path string) (LFSPointer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return LFSPointer{}, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var pointer LFSPointer
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "version "):
+			pointer.Version = strings.TrimPrefix(line, "version ")
+		case strings.HasPrefix(line, "oid sha256:"):
+			pointer.OID = strings.TrimPrefix(line, "oid sha256:")
+		case strings.HasPrefix(line, "size "):
+			size, err := strconv.ParseInt(strings.TrimPrefix(line, "size "), 10, 64)
+			if err != nil {
+				return LFSPointer{}, fmt.Errorf("failed to parse size in %s: %w", path, err)
+			}
+			pointer.Size = size
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return LFSPointer{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if pointer.OID == "" {
+		return LFSPointer{}, fmt.Errorf("%s is not a valid LFS pointer file", path)
+	}
+	return pointer, nil
+}
+
+// shouldRouteThroughLFS reports whether filePath should go through Git LFS: either it already
+// matches a tracked filter=lfs pattern in repoDir's .gitattributes, or it exceeds threshold
+// bytes. The returned reason describes which, for dry-run/error messages.
+func shouldRouteThroughLFS(repoDir, filePath string, threshold int64) (route bool, reason string) {
+	if isLFSTracked(repoDir, filePath) {
+		return true, "matches a tracked .gitattributes pattern"
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil || info.IsDir() {
+		return false, ""
+	}
+	if info.Size() > threshold {
+		return true, fmt.Sprintf("%d bytes exceeds the %d byte threshold", info.Size(), threshold)
+	}
+	return false, ""
+}
+
+// isLFSTracked reports whether filePath matches a "filter=lfs" pattern already recorded in
+// repoDir's .gitattributes, the same file lfsService.Track writes to
+func isLFSTracked(repoDir, filePath string) bool {
+	data, err := os.ReadFile(filepath.Join(repoDir, ".gitattributes"))
+	if err != nil {
+		return false
+	}
+
+	base := filepath.Base(filePath)
+	relPath := filePath
+	if repoDir != "" {
+		if rel, err := filepath.Rel(repoDir, filePath); err == nil {
+			relPath = rel
+		}
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.Contains(line, "filter=lfs") {
+			continue
+		}
+		if matched, _ := filepath.Match(fields[0], base); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(fields[0], relPath); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// lfsPatternFor derives a .gitattributes pattern for filePath: its extension glob ("*.pdf") when
+// it has one, or its exact base name otherwise
+func lfsPatternFor(filePath string) string {
+	if ext := filepath.Ext(filePath); ext != "" {
+		return "*" + ext
+	}
+	return filepath.Base(filePath)
+}
@@ -1,335 +1,483 @@
 package git
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"sort"
+	"strconv"
 	"strings"
 )
 
+// CmdRunner is the interface Commander uses internally to actually invoke a SafeCmd, so tests
+// can substitute FakeRunner (see fakerunner_test.go) for execCmdRunner and assert on the exact
+// argv produced instead of needing a git binary and a real repository.
+type CmdRunner interface {
+	// Run executes args against the git binary and returns its captured stdout/stderr. It
+	// never returns a nil buffer, even on error, mirroring execCmdRunner's behavior of always
+	// capturing output up to the point of failure.
+	Run(ctx context.Context, args []string, opts *RunOpts) (stdout, stderr *bytes.Buffer, err error)
+}
+
+// execCmdRunner is the default CmdRunner, invoking the git binary directly via
+// exec.CommandContext so ctx cancellation kills the process.
+type execCmdRunner struct{}
+
+func (execCmdRunner) Run(ctx context.Context, args []string, opts *RunOpts) (stdout, stderr *bytes.Buffer, err error) {
+	execCmd := exec.CommandContext(ctx, "git", args...)
+
+	execCmd.Dir = opts.Dir
+	execCmd.Env = opts.Env
+	execCmd.Stdin = opts.Stdin
+
+	var stdoutBuf, stderrBuf bytes.Buffer
+	execCmd.Stdout = &stdoutBuf
+	execCmd.Stderr = &stderrBuf
+	if opts.Stdout != nil {
+		execCmd.Stdout = io.MultiWriter(&stdoutBuf, opts.Stdout)
+	}
+	if opts.Stderr != nil {
+		execCmd.Stderr = io.MultiWriter(&stderrBuf, opts.Stderr)
+	}
+
+	return &stdoutBuf, &stderrBuf, execCmd.Run()
+}
+
 // Commander handles git command execution
 type Commander struct {
 	dryRun bool
+	runner CmdRunner
 }
 
 // NewCommander creates a new git commander
 func NewCommander(dryRun bool) *Commander {
-	return &Commander{dryRun: dryRun}
+	return &Commander{dryRun: dryRun, runner: execCmdRunner{}}
 }
 
-// RunCommand runs a git command, either for real or simulate in dry-run mode
-func (c *Commander) RunCommand(command, description string) error {
+// RunCommand runs a SafeCmd, either for real or simulated in dry-run mode. opts may be nil to
+// run in the process's own working directory/environment with no extra timeout.
+func (c *Commander) RunCommand(ctx context.Context, cmd *SafeCmd, opts *RunOpts, description string) error {
 	if c.dryRun {
-		fmt.Printf("[DRY RUN] %s: %s\n", description, command)
+		fmt.Printf("[DRY RUN] %s: %s\n", description, cmd.String())
 		return nil
 	}
 
 	if description != "" {
-		fmt.Printf("%s: %s\n", description, command)
+		fmt.Printf("%s: %s\n", description, cmd.String())
 	}
 
-	cmd := exec.Command("sh", "-c", command)
-	output, err := cmd.CombinedOutput()
-
+	stdout, _, err := c.run(ctx, cmd, opts)
 	if err != nil {
-		return fmt.Errorf("error running command '%s': %w\nOutput: %s", command, err, string(output))
+		return err
 	}
 
-	if len(output) > 0 {
-		fmt.Printf("  Output: %s\n", strings.TrimSpace(string(output)))
+	if stdout.Len() > 0 {
+		fmt.Printf("  Output: %s\n", strings.TrimSpace(stdout.String()))
 	}
 
 	return nil
 }
 
-// RunCommandWithOutput runs a git command and returns its output
-func (c *Commander) RunCommandWithOutput(command, description string) (string, error) {
+// RunCommandWithOutput runs a SafeCmd and returns its stdout. opts may be nil, as with
+// RunCommand.
+func (c *Commander) RunCommandWithOutput(ctx context.Context, cmd *SafeCmd, opts *RunOpts, description string) (string, error) {
 	if c.dryRun {
-		fmt.Printf("[DRY RUN] %s: %s\n", description, command)
+		fmt.Printf("[DRY RUN] %s: %s\n", description, cmd.String())
 		return "", nil // Return empty string for dry-run
 	}
 
 	if description != "" {
-		fmt.Printf("%s: %s\n", description, command)
+		fmt.Printf("%s: %s\n", description, cmd.String())
 	}
 
-	cmd := exec.Command("sh", "-c", command)
-	output, err := cmd.Output()
-
+	stdout, _, err := c.run(ctx, cmd, opts)
 	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			return "", fmt.Errorf("error running command '%s': %w\nStderr: %s", command, err, string(exitError.Stderr))
-		}
-		return "", fmt.Errorf("error running command '%s': %w", command, err)
+		return "", err
 	}
 
-	return strings.TrimSpace(string(output)), nil
+	return strings.TrimSpace(stdout.String()), nil
 }
 
-// Operations provides higher-level git operations
-type Operations struct {
-	commander *Commander
+// run is the low-level execution primitive every RunCommand* variant builds on: it validates
+// and renders cmd down to a plain argv, then hands it to execArgs.
+func (c *Commander) run(ctx context.Context, cmd *SafeCmd, opts *RunOpts) (stdout, stderr *bytes.Buffer, err error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return c.execArgs(ctx, cmd.Args(), opts)
 }
 
-// NewOperations creates a new git operations handler
-func NewOperations(dryRun bool) *Operations {
-	return &Operations{
-		commander: NewCommander(dryRun),
+// execArgs is the execution primitive every Run* method ultimately funnels through once its
+// input is down to a plain argv: it honors the timeout carried by opts, delegates the actual
+// invocation to c.runner (execCmdRunner for real use, FakeRunner in tests), and wraps a failure
+// into a structured GitError.
+func (c *Commander) execArgs(ctx context.Context, args []string, opts *RunOpts) (stdout, stderr *bytes.Buffer, err error) {
+	if opts == nil {
+		opts = &RunOpts{}
 	}
-}
 
-// SwitchToBranch switches to the specified branch
-func (o *Operations) SwitchToBranch(branchName string) error {
-	return o.commander.RunCommand(
-		fmt.Sprintf("git checkout %s", branchName),
-		fmt.Sprintf("Switch to branch '%s'", branchName),
-	)
+	runCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	stdout, stderr, runErr := c.runner.Run(runCtx, args, opts)
+	if runErr != nil {
+		return stdout, stderr, newError(args, opts.Dir, stdout.String(), stderr.String(), runErr)
+	}
+
+	return stdout, stderr, nil
 }
 
-// CreateAndSwitchToBranch creates a new branch and switches to it
-func (o *Operations) CreateAndSwitchToBranch(branchName string) error {
-	return o.commander.RunCommand(
-		fmt.Sprintf("git checkout -b %s", branchName),
-		fmt.Sprintf("Create and switch to branch '%s'", branchName),
-	)
+// RunArgs runs an already-built argv directly against the git binary (args[0] is the
+// subcommand, e.g. "checkout") for call sites that assemble a flat []string one argument at a
+// time instead of a SafeCmd. Like every other Commander entry point it never touches a shell —
+// it's a convenience for ad hoc argv, not a replacement for SafeCmd's flag-name validation.
+// Operations.BranchStatus uses it for its "<branch>@{upstream}" lookup; the other Operations
+// methods build a SafeCmd.
+func (c *Commander) RunArgs(ctx context.Context, args []string, opts *RunOpts, description string) error {
+	if c.dryRun {
+		fmt.Printf("[DRY RUN] %s: %s\n", description, quoteArgsForDisplay(args))
+		return nil
+	}
+
+	if description != "" {
+		fmt.Printf("%s: %s\n", description, quoteArgsForDisplay(args))
+	}
+
+	stdout, _, err := c.execArgs(ctx, args, opts)
+	if err != nil {
+		return err
+	}
+
+	if stdout.Len() > 0 {
+		fmt.Printf("  Output: %s\n", strings.TrimSpace(stdout.String()))
+	}
+
+	return nil
 }
 
-// AddFile stages a file for commit
-func (o *Operations) AddFile(filePath string) error {
-	return o.commander.RunCommand(
-		fmt.Sprintf("git add %s", filePath),
-		"Stage file",
-	)
+// RunArgsWithOutput is RunArgs, returning captured stdout like RunCommandWithOutput.
+func (c *Commander) RunArgsWithOutput(ctx context.Context, args []string, opts *RunOpts, description string) (string, error) {
+	if c.dryRun {
+		fmt.Printf("[DRY RUN] %s: %s\n", description, quoteArgsForDisplay(args))
+		return "", nil
+	}
+
+	if description != "" {
+		fmt.Printf("%s: %s\n", description, quoteArgsForDisplay(args))
+	}
+
+	stdout, _, err := c.execArgs(ctx, args, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
 }
 
-// Commit creates a commit with the specified message
-func (o *Operations) Commit(message string) error {
-	return o.commander.RunCommand(
-		fmt.Sprintf(`git commit -m "%s"`, message),
-		"Commit changes",
-	)
+// quoteArgsForDisplay renders a plain argv shell-quoted for RunArgs' dry-run and debug display,
+// same as SafeCmd.String() does for a typed SafeCmd — never parsed or executed.
+func quoteArgsForDisplay(args []string) string {
+	parts := append([]string{"git"}, args...)
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		quoted[i] = quoteForDisplay(part)
+	}
+	return strings.Join(quoted, " ")
 }
 
-// FetchAll fetches all remote branches and tags
-func (o *Operations) FetchAll() error {
-	return o.commander.RunCommand(
-		"git fetch --all",
-		"Fetch all remote branches and tags",
-	)
+// newError builds a structured GitError for a failed git invocation, extracting the exit code
+// from the underlying exec.ExitError when available. root is the repository root the command
+// actually ran in (falling back to the process's cwd when the command ran in the default dir).
+func newError(args []string, root, stdout, stderr string, err error) *GitError {
+	exitCode := -1
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitCode = exitErr.ExitCode()
+	}
+
+	if root == "" {
+		if wd, wdErr := os.Getwd(); wdErr == nil {
+			root = wd
+		}
+	}
+
+	return &GitError{
+		Args:     args,
+		Root:     root,
+		Stdout:   stdout,
+		Stderr:   stderr,
+		Err:      err,
+		ExitCode: exitCode,
+	}
 }
 
-// PushAllBranches pushes all local branches to remote
-func (o *Operations) PushAllBranches() error {
-	return o.commander.RunCommand(
-		"git push --all origin",
-		"Atomically push all local branches to remote",
-	)
+// Operations is a Backend implementation kept for callers (checkout.Processor, GoGitBackend
+// parity) that depend on the single-interface Backend shape rather than the per-domain
+// BranchService/RemoteService/SparseService/CommitService/WorkTreeService split in client.go.
+// Every method delegates to the matching per-domain service on an internal ExecClient sharing
+// the same Commander and RepoDir, so there's exactly one implementation of each git invocation —
+// except AddFile, which adds the LFS auto-routing decision described below before delegating.
+type Operations struct {
+	commander *Commander
+	// RepoDir is the working directory every command runs in. Empty means the process's cwd.
+	RepoDir string
+	client  *ExecClient
+
+	// RequireLFS, when true, makes AddFile fail fast if a file needs Git LFS (by size or an
+	// existing .gitattributes filter=lfs pattern) but the git-lfs binary isn't on PATH, instead
+	// of silently committing the file as a normal, possibly huge, blob.
+	RequireLFS bool
+
+	// LFSThreshold overrides DefaultLFSThreshold, the file-size cutoff above which AddFile routes
+	// a file through Git LFS. Zero means DefaultLFSThreshold.
+	LFSThreshold int64
 }
 
-// PushBranch pushes a specific branch to remote
-func (o *Operations) PushBranch(branchName string) error {
-	return o.commander.RunCommand(
-		fmt.Sprintf("git push origin %s", branchName),
-		fmt.Sprintf("Push branch '%s' to remote", branchName),
-	)
+// NewOperations creates a new git operations handler that runs in the process's own working
+// directory
+func NewOperations(dryRun bool) *Operations {
+	return newOperations(NewCommander(dryRun), "")
 }
 
-// MergeBranchToMain merges a specific branch into main
-func (o *Operations) MergeBranchToMain(branchName string) error {
-	// First switch to main
-	if err := o.SwitchToBranch("main"); err != nil {
-		return err
+func newOperations(commander *Commander, repoDir string) *Operations {
+	return &Operations{
+		commander: commander,
+		RepoDir:   repoDir,
+		client:    newExecClient(commander, repoDir),
 	}
+}
 
-	// Merge the branch
-	return o.commander.RunCommand(
-		fmt.Sprintf("git merge %s --no-ff", branchName),
-		fmt.Sprintf("Merge branch '%s' into main", branchName),
-	)
+// WithRepo returns a shallow copy of Operations scoped to run every command in dir, leaving the
+// receiver untouched. This lets callers (e.g. TempWorkspace-based tests) drive several
+// repositories concurrently without os.Chdir.
+func (o *Operations) WithRepo(dir string) *Operations {
+	return newOperations(o.commander, dir)
 }
 
-// UpdateBranchFromMain updates a branch with the latest changes from main
-func (o *Operations) UpdateBranchFromMain(branchName string) error {
-	// Switch to the branch
-	if err := o.SwitchToBranch(branchName); err != nil {
-		return err
-	}
+// SwitchToBranch switches to the specified branch
+func (o *Operations) SwitchToBranch(ctx context.Context, branchName string) error {
+	return o.client.Branches().SwitchToBranch(ctx, branchName)
+}
 
-	// Merge main into this branch
-	return o.commander.RunCommand(
-		"git merge main --no-ff",
-		fmt.Sprintf("Update branch '%s' with latest changes from main", branchName),
-	)
+// CreateAndSwitchToBranch creates a new branch and switches to it
+func (o *Operations) CreateAndSwitchToBranch(ctx context.Context, branchName string) error {
+	return o.client.Branches().CreateAndSwitchToBranch(ctx, branchName)
 }
 
-// PullMainFromRemote pulls the latest changes from remote main
-func (o *Operations) PullMainFromRemote() error {
-	// Switch to main first
-	if err := o.SwitchToBranch("main"); err != nil {
+// AddFile stages a file for commit, first auto-routing it through Git LFS (see LFS, below) if it
+// needs that
+func (o *Operations) AddFile(ctx context.Context, filePath string) error {
+	if err := o.routeThroughLFSIfNeeded(ctx, filePath); err != nil {
 		return err
 	}
-
-	// Pull latest changes
-	return o.commander.RunCommand(
-		"git pull origin main",
-		"Pull latest changes from remote main",
-	)
+	return o.client.WorkTree().AddFile(ctx, filePath)
 }
 
-// GetLocalBranches returns a map of local branch names
-func (o *Operations) GetLocalBranches() (map[string]bool, error) {
-	branches := make(map[string]bool)
+// routeThroughLFSIfNeeded tracks filePath via Git LFS before it's added, when it either exceeds
+// LFSThreshold or already matches a tracked .gitattributes filter=lfs pattern. When RequireLFS is
+// set, it fails fast if the git-lfs binary can't be found instead of silently falling back to
+// committing the raw blob.
+func (o *Operations) routeThroughLFSIfNeeded(ctx context.Context, filePath string) error {
+	threshold := o.LFSThreshold
+	if threshold <= 0 {
+		threshold = DefaultLFSThreshold
+	}
+
+	route, reason := shouldRouteThroughLFS(o.RepoDir, filePath, threshold)
+	if !route {
+		return nil
+	}
+
+	if o.RequireLFS {
+		if _, err := exec.LookPath("git-lfs"); err != nil {
+			return fmt.Errorf("'%s' requires Git LFS (%s) but the git-lfs binary was not found: %w", filePath, reason, err)
+		}
+	}
 
 	if o.commander.dryRun {
-		fmt.Println("[DRY RUN] Would check local branches with command:")
-		fmt.Println("  git branch")
-		// Return empty set for dry-run to simulate clean repository
-		return branches, nil
+		fmt.Printf("[DRY RUN] Would route '%s' through Git LFS (%s)\n", filePath, reason)
+		return nil
 	}
 
-	// Get local branches
-	output, err := o.commander.RunCommandWithOutput(
-		"git branch",
-		"Get local branches",
-	)
-	if err != nil {
-		return nil, err
+	if isLFSTracked(o.RepoDir, filePath) {
+		return nil
 	}
 
-	for _, line := range strings.Split(output, "\n") {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			// Format: "* main" or "  branch-name"
-			branchName := strings.TrimSpace(strings.TrimPrefix(line, "*"))
-			if branchName != "" {
-				branches[branchName] = true
-			}
-		}
+	if err := o.client.Lfs().Track(ctx, []string{lfsPatternFor(filePath)}); err != nil {
+		return fmt.Errorf("failed to track '%s' via Git LFS: %w", filePath, err)
 	}
+	return nil
+}
 
-	fmt.Printf("Found %d local branches\n", len(branches))
-	return branches, nil
+// LFS exposes Git LFS operations (install, track, pull, pointer parsing) on this Operations'
+// repository
+func (o *Operations) LFS() LFSService {
+	return o.client.Lfs()
 }
 
-// GetRemoteBranches gets list of remote branch names without creating local tracking branches
-func (o *Operations) GetRemoteBranches(defaultBranch string) (map[string]bool, error) {
-	remoteBranches := make(map[string]bool)
+// Commit creates a commit with the specified message
+func (o *Operations) Commit(ctx context.Context, message string) error {
+	return o.client.Commits().Commit(ctx, message)
+}
 
-	if o.commander.dryRun {
-		fmt.Println("[DRY RUN] Would check remote branches with command:")
-		fmt.Println("  git branch -r")
-		// Return empty set for dry-run
-		return remoteBranches, nil
-	}
+// FetchAll fetches all remote branches and tags
+func (o *Operations) FetchAll(ctx context.Context) error {
+	return o.client.Remotes().FetchAll(ctx)
+}
 
-	// Get list of remote branches
-	output, err := o.commander.RunCommandWithOutput(
-		"git branch -r",
-		"List remote branches",
-	)
-	if err != nil {
-		return nil, err
-	}
+// PushAllBranches pushes all local branches to remote
+func (o *Operations) PushAllBranches(ctx context.Context) error {
+	return o.client.Remotes().PushAllBranches(ctx)
+}
 
-	for _, line := range strings.Split(output, "\n") {
-		line = strings.TrimSpace(line)
+// PushBranch pushes a specific branch to remote
+func (o *Operations) PushBranch(ctx context.Context, branchName string) error {
+	return o.client.Remotes().PushBranch(ctx, branchName)
+}
 
-		// Skip empty lines, HEAD references, and symbolic references
-		if line == "" || strings.HasSuffix(line, "/HEAD") || strings.Contains(line, "HEAD ->") || strings.Contains(line, "->") {
-			continue
-		}
+// MergeBranchToMain merges a specific branch into main
+func (o *Operations) MergeBranchToMain(ctx context.Context, branchName string) error {
+	return o.client.Branches().MergeBranchToMain(ctx, branchName)
+}
 
-		// Format: "  origin/branch-name"
-		if branchName, ok := strings.CutPrefix(line, "origin/"); ok {
-			// Skip default branch and empty names
-			if branchName != defaultBranch && branchName != "" {
-				remoteBranches[branchName] = true
-			}
-		}
-	}
+// UpdateBranchFromMain updates a branch with the latest changes from main
+func (o *Operations) UpdateBranchFromMain(ctx context.Context, branchName string) error {
+	return o.client.Branches().UpdateBranchFromMain(ctx, branchName)
+}
 
-	fmt.Printf("Found %d remote branches\n", len(remoteBranches))
-	return remoteBranches, nil
+// PullMainFromRemote pulls the latest changes from remote main
+func (o *Operations) PullMainFromRemote(ctx context.Context) error {
+	return o.client.Remotes().PullMainFromRemote(ctx)
+}
+
+// GetLocalBranches returns a map of local branch names
+func (o *Operations) GetLocalBranches(ctx context.Context) (map[string]bool, error) {
+	return o.client.Branches().GetLocalBranches(ctx)
+}
+
+// GetRemoteBranches gets list of remote branch names without creating local tracking branches
+func (o *Operations) GetRemoteBranches(ctx context.Context, defaultBranch string) (map[string]bool, error) {
+	return o.client.Remotes().GetRemoteBranches(ctx, defaultBranch)
 }
 
 // GetCurrentBranch returns the name of the currently checked out branch
-func (o *Operations) GetCurrentBranch() (string, error) {
-	return o.commander.RunCommandWithOutput(
-		"git rev-parse --abbrev-ref HEAD",
-		"Get current branch",
-	)
+func (o *Operations) GetCurrentBranch(ctx context.Context) (string, error) {
+	return o.client.Branches().GetCurrentBranch(ctx)
 }
 
 // InitSparseCheckout initializes sparse-checkout using modern init command
-func (o *Operations) InitSparseCheckout() error {
-	return o.commander.RunCommand(
-		"git sparse-checkout init",
-		"Initialize sparse-checkout",
-	)
+func (o *Operations) InitSparseCheckout(ctx context.Context) error {
+	return o.client.Sparse().InitSparseCheckout(ctx)
 }
 
-// EnableSparseCheckoutCone enables Git sparse-checkout with cone mode using modern init command
-func (o *Operations) InitSparseCheckoutCone() error {
-	return o.commander.RunCommand(
-		"git sparse-checkout init --cone",
-		"Initialize sparse-checkout with cone mode",
-	)
+// InitSparseCheckoutCone enables Git sparse-checkout with cone mode using modern init command
+func (o *Operations) InitSparseCheckoutCone(ctx context.Context) error {
+	return o.client.Sparse().InitSparseCheckoutCone(ctx)
 }
 
 // SetSparseCheckoutPaths sets the sparse-checkout paths using git sparse-checkout command
-func (o *Operations) SetSparseCheckoutPaths(paths []string) error {
-	if len(paths) == 0 {
-		return fmt.Errorf("no paths provided for sparse-checkout")
-	}
-
-	// Use git sparse-checkout set command with paths
-	pathsStr := strings.Join(paths, " ")
-	return o.commander.RunCommand(
-		fmt.Sprintf("git sparse-checkout set %s", pathsStr),
-		"Set sparse-checkout paths",
-	)
+func (o *Operations) SetSparseCheckoutPaths(ctx context.Context, paths []string) error {
+	return o.client.Sparse().SetSparseCheckoutPaths(ctx, paths)
 }
 
-// DisableSparseCheckout disables sparse-checkout using modern git command
-func (o *Operations) DisableSparseCheckout() error {
-	return o.commander.RunCommand(
-		"git sparse-checkout disable",
-		"Disable sparse-checkout",
-	)
+// DisableSparseCheckout disables sparse-checkout using modern git command. Returns an error
+// wrapping ErrSparseCheckoutNotEnabled (checkable via errors.Is) when sparse-checkout was never
+// enabled in the first place, as opposed to a real command failure.
+func (o *Operations) DisableSparseCheckout(ctx context.Context) error {
+	return o.client.Sparse().DisableSparseCheckout(ctx)
 }
 
 // ApplyCheckout applies sparse-checkout changes by reading the tree
-func (o *Operations) ApplyCheckout() error {
-	return o.commander.RunCommand(
-		"git read-tree -m -u HEAD",
-		"Apply checkout changes",
-	)
-}
-
-// IsRepository checks if the current directory is a Git repository
-func (o *Operations) IsRepository() (bool, error) {
-	_, err := o.commander.RunCommandWithOutput(
-		"git rev-parse --git-dir",
-		"",
-	)
-	if err != nil {
-		// If the command fails, it's likely not a git repository
-		return false, nil
-	}
-	return true, nil
+func (o *Operations) ApplyCheckout(ctx context.Context) error {
+	return o.client.Sparse().ApplyCheckout(ctx)
+}
+
+// IsRepository checks if the current directory is a Git repository. An error is only returned
+// for failures other than "not a repository" (e.g. git missing, permission denied); callers that
+// only care about the repository check can keep ignoring the error, but now get a chance to
+// surface real failures instead of having them silently read as "not a repository".
+func (o *Operations) IsRepository(ctx context.Context) (bool, error) {
+	return o.client.WorkTree().IsRepository(ctx)
+}
+
+// PushBranches pushes each of the given branches to origin concurrently across a bounded worker
+// pool, collecting per-branch failures into a *MultiError instead of aborting on the first one —
+// unlike PushAllBranches, which pushes everything atomically in one git invocation and fails the
+// whole batch together. Returns nil if every push succeeded.
+func (o *Operations) PushBranches(ctx context.Context, branchNames []string) error {
+	return o.client.Remotes().PushBranches(ctx, branchNames)
 }
 
 // GetCommitHash returns the current commit hash
-func (o *Operations) GetCommitHash() (string, error) {
-	return o.commander.RunCommandWithOutput(
-		"git rev-parse HEAD",
-		"Get commit hash",
-	)
+func (o *Operations) GetCommitHash(ctx context.Context) (string, error) {
+	return o.client.Commits().GetCommitHash(ctx)
 }
 
 // GetShortCommitHash returns the short current commit hash
-func (o *Operations) GetShortCommitHash() (string, error) {
-	return o.commander.RunCommandWithOutput(
-		"git rev-parse --short HEAD",
-		"Get short commit hash",
-	)
+func (o *Operations) GetShortCommitHash(ctx context.Context) (string, error) {
+	return o.client.Commits().GetShortCommitHash(ctx)
+}
+
+// GetCommitDifferences reports how far local and upstream have diverged; see
+// CommitService.GetCommitDifferences for details.
+func (o *Operations) GetCommitDifferences(ctx context.Context, local, upstream string) (ahead, behind string, err error) {
+	return o.client.Commits().GetCommitDifferences(ctx, local, upstream)
+}
+
+// BranchDivergence reports how far a branch has diverged from its tracked upstream.
+type BranchDivergence struct {
+	Ahead  int
+	Behind int
+}
+
+// BranchStatus reports per-branch ahead/behind divergence from each local branch's tracked
+// upstream, for every local branch that has one. A branch with no upstream (e.g. one that was
+// never pushed, or a plain local-only branch) is omitted rather than reported with a zero or
+// placeholder divergence. This lets the assignment runner skip pushing branches that are
+// already up to date with their remote counterpart.
+func (o *Operations) BranchStatus(ctx context.Context) (map[string]BranchDivergence, error) {
+	branches, err := o.GetLocalBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	branchNames := make([]string, 0, len(branches))
+	for branch := range branches {
+		branchNames = append(branchNames, branch)
+	}
+	sort.Strings(branchNames)
+
+	status := make(map[string]BranchDivergence)
+	for _, branch := range branchNames {
+		upstream, err := o.commander.RunArgsWithOutput(ctx,
+			[]string{"rev-parse", "--abbrev-ref", branch + "@{upstream}"}, &RunOpts{Dir: o.RepoDir},
+			fmt.Sprintf("Get upstream for branch '%s'", branch),
+		)
+		if err != nil {
+			// No tracked upstream for this branch; nothing to report.
+			continue
+		}
+
+		ahead, behind, err := o.GetCommitDifferences(ctx, branch, upstream)
+		if err != nil {
+			return nil, err
+		}
+
+		divergence := BranchDivergence{}
+		if n, convErr := strconv.Atoi(ahead); convErr == nil {
+			divergence.Ahead = n
+		}
+		if n, convErr := strconv.Atoi(behind); convErr == nil {
+			divergence.Behind = n
+		}
+		status[branch] = divergence
+	}
+
+	return status, nil
 }
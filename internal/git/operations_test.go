@@ -1,8 +1,12 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -37,40 +41,43 @@ func TestNewCommander(t *testing.T) {
 	}
 }
 
-// TestRunCommand tests command execution
+// TestRunCommand tests command execution, asserting on the exact git argv the FakeRunner
+// receives rather than relying on dry-run to swallow everything
 func TestRunCommand(t *testing.T) {
 	tests := []struct {
 		name        string
 		dryRun      bool
-		command     string
+		command     *SafeCmd
 		description string
+		scriptErr   error
 		expectError bool
 	}{
 		{
-			name:        "dry run mode - always succeeds",
+			name:        "dry run mode never reaches the runner",
 			dryRun:      true,
-			command:     "invalid-command-that-does-not-exist",
+			command:     NewSafeCmd("checkout").WithFlag("-b", "test-branch"),
 			description: "Testing dry run",
 			expectError: false,
 		},
 		{
-			name:        "valid command",
+			name:        "valid command records the exact argv",
 			dryRun:      false,
-			command:     "echo 'test'",
-			description: "Echo test",
+			command:     NewSafeCmd("checkout").WithFlag("-b", "test-branch"),
+			description: "Version test",
 			expectError: false,
 		},
 		{
-			name:        "invalid command",
+			name:        "failing command surfaces the error",
 			dryRun:      false,
-			command:     "invalid-command-that-does-not-exist",
+			command:     NewSafeCmd("fetch").WithFlag("--all", ""),
 			description: "Invalid command test",
+			scriptErr:   errors.New("network unreachable"),
 			expectError: true,
 		},
 		{
 			name:        "empty description",
 			dryRun:      false,
-			command:     "echo 'test'",
+			command:     NewSafeCmd("checkout").WithFlag("-b", "test-branch"),
 			description: "",
 			expectError: false,
 		},
@@ -78,8 +85,13 @@ func TestRunCommand(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			commander := NewCommander(tt.dryRun)
-			err := commander.RunCommand(tt.command, tt.description)
+			runner := NewFakeRunner(t)
+			if !tt.dryRun {
+				runner.Expect(tt.command.Args(), "", "", tt.scriptErr)
+			}
+			commander := &Commander{dryRun: tt.dryRun, runner: runner}
+
+			err := commander.RunCommand(context.Background(), tt.command, nil, tt.description)
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -87,6 +99,7 @@ func TestRunCommand(t *testing.T) {
 			if !tt.expectError && err != nil {
 				t.Errorf("Unexpected error: %v", err)
 			}
+			runner.AssertDone()
 		})
 	}
 }
@@ -96,7 +109,7 @@ func TestRunCommandWithOutput(t *testing.T) {
 	tests := []struct {
 		name           string
 		dryRun         bool
-		command        string
+		command        *SafeCmd
 		description    string
 		expectedOutput string
 		expectError    bool
@@ -104,23 +117,23 @@ func TestRunCommandWithOutput(t *testing.T) {
 		{
 			name:           "dry run mode - returns empty",
 			dryRun:         true,
-			command:        "echo 'test'",
+			command:        NewSafeCmd("version"),
 			description:    "Testing dry run",
 			expectedOutput: "",
 			expectError:    false,
 		},
 		{
-			name:           "echo command",
+			name:           "version command",
 			dryRun:         false,
-			command:        "echo 'hello world'",
-			description:    "Echo test",
-			expectedOutput: "hello world",
+			command:        NewSafeCmd("version"),
+			description:    "Version test",
+			expectedOutput: "git version",
 			expectError:    false,
 		},
 		{
 			name:           "invalid command",
 			dryRun:         false,
-			command:        "invalid-command-that-does-not-exist",
+			command:        NewSafeCmd("invalid-command-that-does-not-exist"),
 			description:    "Invalid command test",
 			expectedOutput: "",
 			expectError:    true,
@@ -130,7 +143,7 @@ func TestRunCommandWithOutput(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			commander := NewCommander(tt.dryRun)
-			output, err := commander.RunCommandWithOutput(tt.command, tt.description)
+			output, err := commander.RunCommandWithOutput(context.Background(), tt.command, nil, tt.description)
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -154,6 +167,34 @@ func TestRunCommandWithOutput(t *testing.T) {
 	}
 }
 
+// TestRunArgs tests that RunArgs passes an already-built argv straight through to the runner
+func TestRunArgs(t *testing.T) {
+	args := []string{"rev-list", "@{u}..HEAD", "--count"}
+	runner := NewFakeRunner(t).Expect(args, "", "", nil)
+	commander := &Commander{runner: runner}
+
+	if err := commander.RunArgs(context.Background(), args, nil, "Count unpushed commits"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	runner.AssertDone()
+}
+
+// TestRunArgsWithOutput tests that RunArgsWithOutput returns the runner's trimmed stdout
+func TestRunArgsWithOutput(t *testing.T) {
+	args := []string{"for-each-ref", "--format=%(refname:short)", "refs/heads"}
+	runner := NewFakeRunner(t).Expect(args, "main\nfeature-1\n", "", nil)
+	commander := &Commander{runner: runner}
+
+	output, err := commander.RunArgsWithOutput(context.Background(), args, nil, "List refs")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if want := "main\nfeature-1"; output != want {
+		t.Errorf("RunArgsWithOutput() = %q, want %q", output, want)
+	}
+	runner.AssertDone()
+}
+
 // TestNewOperations tests git operations creation
 func TestNewOperations(t *testing.T) {
 	tests := []struct {
@@ -184,41 +225,35 @@ func TestNewOperations(t *testing.T) {
 	}
 }
 
-// TestCreateAndSwitchToBranch tests branch creation and switching
+// TestCreateAndSwitchToBranch tests that CreateAndSwitchToBranch issues exactly
+// "git checkout -b <name>"
 func TestCreateAndSwitchToBranch(t *testing.T) {
-	// This test requires a git repository to be present
-	// We'll test both dry-run and error cases
 	tests := []struct {
-		name        string
-		dryRun      bool
-		branchName  string
-		expectError bool
+		name       string
+		branchName string
+		wantArgs   []string
 	}{
 		{
-			name:        "dry run mode",
-			dryRun:      true,
-			branchName:  "test-branch",
-			expectError: false,
+			name:       "creates and switches to a new branch",
+			branchName: "test-branch",
+			wantArgs:   []string{"checkout", "-b", "test-branch"},
 		},
 		{
-			name:        "invalid branch name",
-			dryRun:      true,
-			branchName:  "",
-			expectError: false, // Dry run doesn't validate
+			name:       "empty branch name is still passed through as-is",
+			branchName: "",
+			wantArgs:   []string{"checkout", "-b"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ops := NewOperations(tt.dryRun)
-			err := ops.CreateAndSwitchToBranch(tt.branchName)
+			runner := NewFakeRunner(t).Expect(tt.wantArgs, "", "", nil)
+			ops := newOperations(&Commander{runner: runner}, "")
 
-			if tt.expectError && err == nil {
-				t.Error("Expected error but got none")
-			}
-			if !tt.expectError && err != nil {
+			if err := ops.CreateAndSwitchToBranch(context.Background(), tt.branchName); err != nil {
 				t.Errorf("Unexpected error: %v", err)
 			}
+			runner.AssertDone()
 		})
 	}
 }
@@ -248,7 +283,7 @@ func TestSwitchToBranch(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ops := NewOperations(tt.dryRun)
-			err := ops.SwitchToBranch(tt.branchName)
+			err := ops.SwitchToBranch(context.Background(), tt.branchName)
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -285,7 +320,7 @@ func TestAddFile(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ops := NewOperations(tt.dryRun)
-			err := ops.AddFile(tt.filePath)
+			err := ops.AddFile(context.Background(), tt.filePath)
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -322,7 +357,7 @@ func TestCommit(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ops := NewOperations(tt.dryRun)
-			err := ops.Commit(tt.message)
+			err := ops.Commit(context.Background(), tt.message)
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -334,121 +369,148 @@ func TestCommit(t *testing.T) {
 	}
 }
 
-// TestPushAllBranches tests branch pushing
+// TestPushAllBranches tests that PushAllBranches issues exactly "git push --all origin"
 func TestPushAllBranches(t *testing.T) {
-	tests := []struct {
-		name        string
-		dryRun      bool
-		expectError bool
-	}{
-		{
-			name:        "dry run mode",
-			dryRun:      true,
-			expectError: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ops := NewOperations(tt.dryRun)
-			err := ops.PushAllBranches()
+	runner := NewFakeRunner(t).Expect([]string{"push", "--all", "origin"}, "", "", nil)
+	ops := newOperations(&Commander{runner: runner}, "")
 
-			if tt.expectError && err == nil {
-				t.Error("Expected error but got none")
-			}
-			if !tt.expectError && err != nil {
-				t.Errorf("Unexpected error: %v", err)
-			}
-		})
+	if err := ops.PushAllBranches(context.Background()); err != nil {
+		t.Errorf("Unexpected error: %v", err)
 	}
+	runner.AssertDone()
 }
 
-// TestGetLocalBranches tests local branch detection
+// TestGetLocalBranches tests that GetLocalBranches issues exactly "git branch" and parses its
+// output into the expected branch set
 func TestGetLocalBranches(t *testing.T) {
-	tests := []struct {
-		name        string
-		dryRun      bool
-		expectError bool
-	}{
-		{
-			name:        "dry run mode",
-			dryRun:      true,
-			expectError: false,
-		},
+	runner := NewFakeRunner(t).Expect([]string{"branch"}, "* main\n  feature-1\n", "", nil)
+	ops := newOperations(&Commander{runner: runner}, "")
+
+	branches, err := ops.GetLocalBranches(context.Background())
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ops := NewOperations(tt.dryRun)
-			branches, err := ops.GetLocalBranches()
+	want := map[string]bool{"main": true, "feature-1": true}
+	if !reflect.DeepEqual(branches, want) {
+		t.Errorf("GetLocalBranches() = %v, want %v", branches, want)
+	}
+	runner.AssertDone()
+}
 
-			if tt.expectError && err == nil {
-				t.Error("Expected error but got none")
-			}
-			if !tt.expectError && err != nil {
-				t.Errorf("Unexpected error: %v", err)
-			}
+// TestGetRemoteBranches tests that GetRemoteBranches issues exactly "git branch -r" and
+// excludes the default branch and HEAD references from the result
+func TestGetRemoteBranches(t *testing.T) {
+	output := "  origin/main\n  origin/feature-1\n  origin/HEAD -> origin/main\n"
+	runner := NewFakeRunner(t).Expect([]string{"branch", "-r"}, output, "", nil)
+	ops := newOperations(&Commander{runner: runner}, "")
 
-			// In dry-run mode, should return empty map
-			if tt.dryRun && len(branches) != 0 {
-				t.Errorf("Expected empty branches in dry-run mode, got %d", len(branches))
-			}
-		})
+	branches, err := ops.GetRemoteBranches(context.Background(), "main")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
 	}
+
+	want := map[string]bool{"feature-1": true}
+	if !reflect.DeepEqual(branches, want) {
+		t.Errorf("GetRemoteBranches() = %v, want %v", branches, want)
+	}
+	runner.AssertDone()
 }
 
-// TestGetRemoteBranches tests remote branch handling
-func TestGetRemoteBranches(t *testing.T) {
+// TestGetCommitDifferences tests that GetCommitDifferences reports ahead/behind counts, and
+// falls back to "?" for whichever side's rev-list fails without failing the whole call
+func TestGetCommitDifferences(t *testing.T) {
 	tests := []struct {
-		name          string
-		dryRun        bool
-		defaultBranch string
-		expectError   bool
+		name       string
+		aheadOut   string
+		aheadErr   error
+		behindOut  string
+		behindErr  error
+		wantAhead  string
+		wantBehind string
 	}{
 		{
-			name:          "dry run mode",
-			dryRun:        true,
-			defaultBranch: "main",
-			expectError:   false,
+			name:       "both sides succeed",
+			aheadOut:   "2\n",
+			behindOut:  "1\n",
+			wantAhead:  "2",
+			wantBehind: "1",
+		},
+		{
+			name:       "upstream missing locally falls back to ? for behind",
+			aheadOut:   "3\n",
+			behindErr:  errors.New("unknown revision"),
+			wantAhead:  "3",
+			wantBehind: "?",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ops := NewOperations(tt.dryRun)
-			err := ops.GetRemoteBranches(tt.defaultBranch)
+			runner := NewFakeRunner(t).
+				Expect([]string{"rev-list", "origin/main..feature-1", "--count"}, tt.aheadOut, "", tt.aheadErr).
+				Expect([]string{"rev-list", "feature-1..origin/main", "--count"}, tt.behindOut, "", tt.behindErr)
+			ops := newOperations(&Commander{runner: runner}, "")
 
-			if tt.expectError && err == nil {
-				t.Error("Expected error but got none")
-			}
-			if !tt.expectError && err != nil {
+			ahead, behind, err := ops.GetCommitDifferences(context.Background(), "feature-1", "origin/main")
+			if err != nil {
 				t.Errorf("Unexpected error: %v", err)
 			}
+			if ahead != tt.wantAhead {
+				t.Errorf("ahead = %q, want %q", ahead, tt.wantAhead)
+			}
+			if behind != tt.wantBehind {
+				t.Errorf("behind = %q, want %q", behind, tt.wantBehind)
+			}
+			runner.AssertDone()
 		})
 	}
 }
 
+// TestBranchStatus tests that BranchStatus reports ahead/behind divergence for branches with a
+// tracked upstream, and omits branches that have none
+func TestBranchStatus(t *testing.T) {
+	runner := NewFakeRunner(t).
+		Expect([]string{"branch"}, "* main\n  feature-1\n", "", nil).
+		Expect([]string{"rev-parse", "--abbrev-ref", "feature-1@{upstream}"}, "", "", errors.New("no upstream")).
+		Expect([]string{"rev-parse", "--abbrev-ref", "main@{upstream}"}, "origin/main", "", nil).
+		Expect([]string{"rev-list", "origin/main..main", "--count"}, "0", "", nil).
+		Expect([]string{"rev-list", "main..origin/main", "--count"}, "0", "", nil)
+	ops := newOperations(&Commander{runner: runner}, "")
+
+	status, err := ops.BranchStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := map[string]BranchDivergence{"main": {Ahead: 0, Behind: 0}}
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("BranchStatus() = %v, want %v", status, want)
+	}
+	runner.AssertDone()
+}
+
 // BenchmarkRunCommand benchmarks command execution
 func BenchmarkRunCommand(b *testing.B) {
 	commander := NewCommander(true) // Use dry-run for consistent timing
-	command := "echo 'benchmark test'"
+	command := NewSafeCmd("version")
 	description := "Benchmark test"
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = commander.RunCommand(command, description)
+		_ = commander.RunCommand(context.Background(), command, nil, description)
 	}
 }
 
 // BenchmarkRunCommandWithOutput benchmarks command execution with output
 func BenchmarkRunCommandWithOutput(b *testing.B) {
 	commander := NewCommander(true) // Use dry-run for consistent timing
-	command := "echo 'benchmark test'"
+	command := NewSafeCmd("version")
 	description := "Benchmark test"
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = commander.RunCommandWithOutput(command, description)
+		_, _ = commander.RunCommandWithOutput(context.Background(), command, nil, description)
 	}
 }
 
@@ -468,7 +530,7 @@ func TestGitIntegration(t *testing.T) {
 	ops := NewOperations(true)
 
 	t.Run("get local branches in dry-run", func(t *testing.T) {
-		branches, err := ops.GetLocalBranches()
+		branches, err := ops.GetLocalBranches(context.Background())
 		if err != nil {
 			t.Errorf("Unexpected error: %v", err)
 		}
@@ -479,16 +541,111 @@ func TestGitIntegration(t *testing.T) {
 	})
 
 	t.Run("fetch all in dry-run", func(t *testing.T) {
-		err := ops.FetchAll()
+		err := ops.FetchAll(context.Background())
 		if err != nil {
 			t.Errorf("Unexpected error in dry-run mode: %v", err)
 		}
 	})
 
 	t.Run("get remote branches in dry-run", func(t *testing.T) {
-		err := ops.GetRemoteBranches("main")
+		_, err := ops.GetRemoteBranches(context.Background(), "main")
 		if err != nil {
 			t.Errorf("Unexpected error in dry-run mode: %v", err)
 		}
 	})
 }
+
+// TestAddFileLFSRouting tests that AddFile routes large files through Git LFS based on
+// LFSThreshold, in dry-run mode so no git-lfs binary is required
+func TestAddFileLFSRouting(t *testing.T) {
+	dir := t.TempDir()
+	bigFile := filepath.Join(dir, "dataset.bin")
+	if err := os.WriteFile(bigFile, make([]byte, 1024), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	ops := NewOperations(true).WithRepo(dir)
+	ops.LFSThreshold = 100 // smaller than the file, to force routing without a 50MB fixture
+
+	if err := ops.AddFile(context.Background(), bigFile); err != nil {
+		t.Errorf("Unexpected error routing large file through LFS in dry-run: %v", err)
+	}
+}
+
+// TestAddFileRequireLFSIgnoresSmallFiles tests that RequireLFS only checks for the git-lfs
+// binary when a file actually needs to be routed through LFS
+func TestAddFileRequireLFSIgnoresSmallFiles(t *testing.T) {
+	dir := t.TempDir()
+	smallFile := filepath.Join(dir, "README.md")
+	if err := os.WriteFile(smallFile, []byte("hello\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	ops := NewOperations(true).WithRepo(dir)
+	ops.RequireLFS = true
+
+	if err := ops.AddFile(context.Background(), smallFile); err != nil {
+		t.Errorf("Unexpected error for a file under the LFS threshold: %v", err)
+	}
+}
+
+// TestShouldRouteThroughLFS tests the size- and .gitattributes-based LFS routing decision
+func TestShouldRouteThroughLFS(t *testing.T) {
+	dir := t.TempDir()
+
+	small := filepath.Join(dir, "small.txt")
+	if err := os.WriteFile(small, []byte("tiny"), 0644); err != nil {
+		t.Fatalf("failed to write small.txt: %v", err)
+	}
+
+	large := filepath.Join(dir, "large.bin")
+	if err := os.WriteFile(large, make([]byte, 200), 0644); err != nil {
+		t.Fatalf("failed to write large.bin: %v", err)
+	}
+
+	tracked := filepath.Join(dir, "model.pt")
+	if err := os.WriteFile(tracked, []byte("x"), 0644); err != nil {
+		t.Fatalf("failed to write model.pt: %v", err)
+	}
+	gitattributes := "*.pt filter=lfs diff=lfs merge=lfs -text\n"
+	if err := os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte(gitattributes), 0644); err != nil {
+		t.Fatalf("failed to write .gitattributes: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		filePath  string
+		threshold int64
+		want      bool
+	}{
+		{"under threshold, untracked", small, 100, false},
+		{"over threshold", large, 100, true},
+		{"matches tracked pattern regardless of size", tracked, 100, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, _ := shouldRouteThroughLFS(dir, tt.filePath, tt.threshold)
+			if route != tt.want {
+				t.Errorf("shouldRouteThroughLFS(%q) = %v, want %v", tt.filePath, route, tt.want)
+			}
+		})
+	}
+}
+
+// TestLfsPatternFor tests deriving a .gitattributes pattern from a file path
+func TestLfsPatternFor(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     string
+	}{
+		{"assets/dataset.csv", "*.csv"},
+		{"Dockerfile", "Dockerfile"},
+	}
+
+	for _, tt := range tests {
+		if got := lfsPatternFor(tt.filePath); got != tt.want {
+			t.Errorf("lfsPatternFor(%q) = %q, want %q", tt.filePath, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"assignment-pull-request/internal/errs"
+)
+
+// RemoteService manages fetching from and pushing to the remote
+type RemoteService interface {
+	FetchAll(ctx context.Context) error
+	PushAllBranches(ctx context.Context) error
+	PushBranch(ctx context.Context, branchName string) error
+	PushBranches(ctx context.Context, branchNames []string) error
+	PullMainFromRemote(ctx context.Context) error
+	GetRemoteBranches(ctx context.Context, defaultBranch string) (map[string]bool, error)
+
+	// DeleteRemoteBranch deletes branchName from origin (see Creator.rollback). It is not an
+	// error for the branch to not exist on the remote.
+	DeleteRemoteBranch(ctx context.Context, branchName string) error
+}
+
+// remoteService is the exec-based RemoteService implementation. It depends on BranchService for
+// the branch switches PullMainFromRemote needs before it can pull.
+type remoteService struct {
+	base
+	branches BranchService
+}
+
+var _ RemoteService = remoteService{}
+
+// FetchAll fetches all remote branches and tags
+func (s remoteService) FetchAll(ctx context.Context) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("fetch").WithFlag("--all", ""), s.runOpts(),
+		"Fetch all remote branches and tags",
+	)
+}
+
+// PushAllBranches pushes all local branches to remote
+func (s remoteService) PushAllBranches(ctx context.Context) error {
+	err := s.commander.RunCommand(ctx,
+		NewSafeCmd("push").WithFlag("--all", "").WithRevArgs("origin"), s.runOpts(),
+		"Atomically push all local branches to remote",
+	)
+	return classifyPushError("push all branches", err)
+}
+
+// PushBranch pushes a specific branch to remote
+func (s remoteService) PushBranch(ctx context.Context, branchName string) error {
+	err := s.commander.RunCommand(ctx,
+		NewSafeCmd("push").WithRevArgs("origin", branchName), s.runOpts(),
+		fmt.Sprintf("Push branch '%s' to remote", branchName),
+	)
+	return classifyPushError(branchName, err)
+}
+
+// PushBranches pushes each of the given branches to origin concurrently across a bounded worker
+// pool, collecting per-branch failures into a *MultiError instead of aborting on the first one —
+// unlike PushAllBranches, which pushes everything atomically in one git invocation and fails the
+// whole batch together. Returns nil if every push succeeded.
+func (s remoteService) PushBranches(ctx context.Context, branchNames []string) error {
+	maxWorkers := runtime.GOMAXPROCS(0)
+	if len(branchNames) < maxWorkers {
+		maxWorkers = len(branchNames)
+	}
+	if maxWorkers == 0 {
+		return nil
+	}
+
+	jobs := make(chan string)
+	errorsCh := make(chan error)
+
+	var wg sync.WaitGroup
+	for i := 0; i < maxWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for branchName := range jobs {
+				if err := s.PushBranch(ctx, branchName); err != nil {
+					errorsCh <- fmt.Errorf("pushing branch '%s': %w", branchName, err)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for _, branchName := range branchNames {
+			jobs <- branchName
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(errorsCh)
+	}()
+
+	var multiErr MultiError
+	for err := range errorsCh {
+		multiErr.Errors = append(multiErr.Errors, err)
+	}
+
+	if len(multiErr.Errors) > 0 {
+		return &multiErr
+	}
+	return nil
+}
+
+// PullMainFromRemote pulls the latest changes from remote main
+func (s remoteService) PullMainFromRemote(ctx context.Context) error {
+	// Switch to main first
+	if err := s.branches.SwitchToBranch(ctx, "main"); err != nil {
+		return err
+	}
+
+	// Pull latest changes
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("pull").WithRevArgs("origin", "main"), s.runOpts(),
+		"Pull latest changes from remote main",
+	)
+}
+
+// GetRemoteBranches gets list of remote branch names without creating local tracking branches
+func (s remoteService) GetRemoteBranches(ctx context.Context, defaultBranch string) (map[string]bool, error) {
+	remoteBranches := make(map[string]bool)
+
+	if s.commander.dryRun {
+		fmt.Println("[DRY RUN] Would check remote branches with command:")
+		fmt.Println("  git branch -r")
+		// Return empty set for dry-run
+		return remoteBranches, nil
+	}
+
+	// Get list of remote branches
+	output, err := s.commander.RunCommandWithOutput(ctx,
+		NewSafeCmd("branch").WithFlag("-r", ""), s.runOpts(),
+		"List remote branches",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		// Skip empty lines, HEAD references, and symbolic references
+		if line == "" || strings.HasSuffix(line, "/HEAD") || strings.Contains(line, "HEAD ->") || strings.Contains(line, "->") {
+			continue
+		}
+
+		// Format: "  origin/branch-name"
+		if branchName, ok := strings.CutPrefix(line, "origin/"); ok {
+			// Skip default branch and empty names
+			if branchName != defaultBranch && branchName != "" {
+				remoteBranches[branchName] = true
+			}
+		}
+	}
+
+	fmt.Printf("Found %d remote branches\n", len(remoteBranches))
+	return remoteBranches, nil
+}
+
+// DeleteRemoteBranch deletes branchName from origin
+func (s remoteService) DeleteRemoteBranch(ctx context.Context, branchName string) error {
+	err := s.commander.RunCommand(ctx,
+		NewSafeCmd("push").WithFlag("--delete", "").WithRevArgs("origin", branchName), s.runOpts(),
+		fmt.Sprintf("Delete remote branch '%s'", branchName),
+	)
+	var gitErr *GitError
+	if errors.As(err, &gitErr) && strings.Contains(gitErr.Stderr, "remote ref does not exist") {
+		return nil
+	}
+	return err
+}
+
+// classifyPushError recognizes a rejected `git push`'s failure mode from its stderr (see
+// IsNonFastForward, IsProtectedBranch, IsBranchExists) and wraps it into the matching
+// errs.HintedError so callers can report a machine-readable code and remediation hint instead of
+// a raw command failure. err is returned unchanged if it's nil or doesn't match a known rejection.
+func classifyPushError(task string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case IsProtectedBranch(err):
+		return errs.ErrProtectedBranch(task, err)
+	case IsNonFastForward(err):
+		return errs.ErrNonFastForward(task, err)
+	case IsBranchExists(err):
+		return errs.ErrBranchExistsRemotely(task, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,31 @@
+package git
+
+import (
+	"io"
+	"time"
+)
+
+// RunOpts configures a single SafeCmd invocation: where it runs, what environment and stdin it
+// sees, and how long it's allowed to run. Modeled on Gitea's git.RunOpts, it lets Operations run
+// against a repository other than the process's current working directory, and lets callers
+// enforce a deadline on top of whatever the passed-in context.Context already carries.
+//
+// A nil *RunOpts is equivalent to &RunOpts{}: the command inherits the process's environment and
+// stdin, runs in the process's cwd, and has no additional timeout.
+type RunOpts struct {
+	// Dir is the working directory the command runs in. Empty means the process's cwd.
+	Dir string
+	// Env is passed to exec.Cmd.Env verbatim. Nil means the command inherits the process's
+	// environment.
+	Env []string
+	// Stdin, if set, is connected to the command's stdin.
+	Stdin io.Reader
+	// Stdout and Stderr, if set, additionally receive the command's output as it streams in,
+	// alongside the copy Commander always captures internally for error reporting and
+	// RunCommandWithOutput.
+	Stdout io.Writer
+	Stderr io.Writer
+	// Timeout, if positive, bounds the command's run time on top of ctx; it is implemented as a
+	// context.WithTimeout derived from the ctx passed to the Commander's RunCommand/RunArgs methods.
+	Timeout time.Duration
+}
@@ -0,0 +1,171 @@
+package git
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// flagNamePattern validates that a flag or global option name looks like a short ("-x") or
+// long ("--foo-bar") git flag, so a caller can't smuggle extra arguments or shell metacharacters
+// into the argv through a malformed flag name
+var flagNamePattern = regexp.MustCompile(`^-[a-zA-Z]$|^--[a-z][a-z-]*$`)
+
+// GlobalOption is a flag that appears before the subcommand, e.g. "-C <dir>" or "--no-pager"
+type GlobalOption struct {
+	Name  string
+	Value string
+}
+
+// Flag is a subcommand flag, e.g. "--cone" or "-m <message>". Value is omitted from the argv
+// when empty, so boolean flags like "--cone" are expressed as Flag{Name: "--cone"}.
+type Flag struct {
+	Name  string
+	Value string
+}
+
+// SafeCmd builds a git invocation as a typed argv — global options, subcommand, an optional
+// sub-subcommand (e.g. "sparse-checkout init"), subcommand flags, and positional arguments kept
+// as separate slices — so it can only ever be run via exec.Command against the git binary
+// directly, never through a shell. This closes the injection surface that string-built shell
+// commands have whenever a branch name, path, or commit message contains shell metacharacters.
+type SafeCmd struct {
+	GlobalOptions []GlobalOption
+	SubCmd        string
+	SubSubCmd     string
+	Flags         []Flag
+	RevArgs       []string
+	PostSepArgs   []string
+}
+
+// NewSafeCmd starts a SafeCmd for the given git subcommand, e.g. NewSafeCmd("checkout")
+func NewSafeCmd(subCmd string) *SafeCmd {
+	return &SafeCmd{SubCmd: subCmd}
+}
+
+// WithGlobalOption appends a global option, to be placed before the subcommand
+func (c *SafeCmd) WithGlobalOption(name, value string) *SafeCmd {
+	c.GlobalOptions = append(c.GlobalOptions, GlobalOption{Name: name, Value: value})
+	return c
+}
+
+// WithSubSubCmd sets a sub-subcommand, e.g. "init" for "git sparse-checkout init"
+func (c *SafeCmd) WithSubSubCmd(subSubCmd string) *SafeCmd {
+	c.SubSubCmd = subSubCmd
+	return c
+}
+
+// WithFlag appends a subcommand flag. Pass an empty value for boolean flags.
+func (c *SafeCmd) WithFlag(name, value string) *SafeCmd {
+	c.Flags = append(c.Flags, Flag{Name: name, Value: value})
+	return c
+}
+
+// WithArgs appends one or more pathspec arguments (e.g. a file to "git add"), placed after a
+// "--" separator so they can never be misinterpreted as flags. Use WithRevArgs instead for
+// revisions, branches, or remote names: a "--" before those makes git treat them as pathspecs
+// rather than refs, which is a different (and usually wrong) lookup.
+func (c *SafeCmd) WithArgs(args ...string) *SafeCmd {
+	c.PostSepArgs = append(c.PostSepArgs, args...)
+	return c
+}
+
+// WithRevArgs appends one or more positional revision/ref/remote-name arguments, with no "--"
+// separator, so git resolves them as revisions rather than pathspecs
+func (c *SafeCmd) WithRevArgs(args ...string) *SafeCmd {
+	c.RevArgs = append(c.RevArgs, args...)
+	return c
+}
+
+// Validate checks that every flag and global option name matches the expected "-x"/"--long-name"
+// shape
+func (c *SafeCmd) Validate() error {
+	for _, opt := range c.GlobalOptions {
+		if !flagNamePattern.MatchString(opt.Name) {
+			return &InvalidFlagError{Name: opt.Name}
+		}
+	}
+	for _, flag := range c.Flags {
+		if !flagNamePattern.MatchString(flag.Name) {
+			return &InvalidFlagError{Name: flag.Name}
+		}
+	}
+	return nil
+}
+
+// InvalidFlagError is returned by SafeCmd.Validate when a flag or global option name doesn't
+// match the expected "-x"/"--long-name" shape
+type InvalidFlagError struct {
+	Name string
+}
+
+func (e *InvalidFlagError) Error() string {
+	return "invalid flag name: " + e.Name
+}
+
+// Args renders the full argv (excluding the "git" binary itself) that would be passed to
+// exec.Command
+func (c *SafeCmd) Args() []string {
+	var args []string
+
+	for _, opt := range c.GlobalOptions {
+		args = append(args, opt.Name)
+		if opt.Value != "" {
+			args = append(args, opt.Value)
+		}
+	}
+
+	args = append(args, c.SubCmd)
+
+	if c.SubSubCmd != "" {
+		args = append(args, c.SubSubCmd)
+	}
+
+	for _, flag := range c.Flags {
+		args = append(args, flag.Name)
+		if flag.Value != "" {
+			args = append(args, flag.Value)
+		}
+	}
+
+	args = append(args, c.RevArgs...)
+
+	if len(c.PostSepArgs) > 0 {
+		args = append(args, "--")
+		args = append(args, c.PostSepArgs...)
+	}
+
+	return args
+}
+
+// Cmd builds the *exec.Cmd that runs this SafeCmd's argv against the "git" binary directly
+func (c *SafeCmd) Cmd() (*exec.Cmd, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return exec.Command("git", c.Args()...), nil
+}
+
+// String renders the argv shell-quoted, for dry-run and debug display only — it is never
+// parsed or executed
+func (c *SafeCmd) String() string {
+	parts := append([]string{"git"}, c.Args()...)
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		quoted[i] = quoteForDisplay(part)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellSpecialChars are the characters that make quoteForDisplay wrap an argv element in quotes
+const shellSpecialChars = " \t\n\"'$`\\;|&<>(){}*?[]~!"
+
+// quoteForDisplay single-quotes an argv element if it contains anything a shell would treat
+// specially, so the rendered command is safe to copy-paste without re-introducing the
+// injection this package exists to avoid
+func quoteForDisplay(s string) string {
+	if s != "" && !strings.ContainsAny(s, shellSpecialChars) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
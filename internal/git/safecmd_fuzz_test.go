@@ -0,0 +1,63 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// FuzzSafeCmdArgs feeds arbitrary bytes as branch/message/path arguments through SafeCmd and
+// asserts that the resulting argv always addresses a single "git" invocation — i.e. that no
+// shell metacharacter in the input can ever split the call into multiple processes or smuggle in
+// extra commands, which was possible with the previous fmt.Sprintf + "sh -c" approach.
+func FuzzSafeCmdArgs(f *testing.F) {
+	seeds := []string{
+		"feature/my-branch",
+		"'; rm -rf / #",
+		"$(whoami)",
+		"`id`",
+		"branch && echo pwned",
+		"branch; echo pwned",
+		"branch | cat /etc/passwd",
+		"branch\nrm -rf /",
+		"--upload-pack=touch /tmp/pwned",
+		"",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		cmd := NewSafeCmd("checkout").WithRevArgs(input)
+
+		execCmd, err := cmd.Cmd()
+		if err != nil {
+			// An InvalidFlagError is fine here; input is a positional arg, not a flag name.
+			return
+		}
+
+		if execCmd.Path != "" && !strings.HasSuffix(execCmd.Path, "git") {
+			lookedUp, lookErr := exec.LookPath("git")
+			if lookErr == nil && execCmd.Path != lookedUp {
+				t.Fatalf("expected exec.Cmd to target the git binary, got %q", execCmd.Path)
+			}
+		}
+
+		if len(execCmd.Args) < 2 || execCmd.Args[0] != "git" {
+			t.Fatalf("expected argv[0] to be 'git', got %v", execCmd.Args)
+		}
+
+		// The arbitrary input must survive as a single argv element, never re-interpreted into
+		// additional arguments or a second command.
+		found := false
+		for _, arg := range execCmd.Args {
+			if arg == input {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("expected input %q to appear as a single argv element, got %v", input, execCmd.Args)
+		}
+	})
+}
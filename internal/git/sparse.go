@@ -0,0 +1,67 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// SparseService configures and applies sparse-checkout
+type SparseService interface {
+	InitSparseCheckout(ctx context.Context) error
+	InitSparseCheckoutCone(ctx context.Context) error
+	SetSparseCheckoutPaths(ctx context.Context, paths []string) error
+	DisableSparseCheckout(ctx context.Context) error
+	ApplyCheckout(ctx context.Context) error
+}
+
+// sparseService is the exec-based SparseService implementation
+type sparseService struct{ base }
+
+var _ SparseService = sparseService{}
+
+// InitSparseCheckout initializes sparse-checkout using modern init command
+func (s sparseService) InitSparseCheckout(ctx context.Context) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("sparse-checkout").WithSubSubCmd("init"), s.runOpts(),
+		"Initialize sparse-checkout",
+	)
+}
+
+// InitSparseCheckoutCone enables Git sparse-checkout with cone mode using modern init command
+func (s sparseService) InitSparseCheckoutCone(ctx context.Context) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("sparse-checkout").WithSubSubCmd("init").WithFlag("--cone", ""), s.runOpts(),
+		"Initialize sparse-checkout with cone mode",
+	)
+}
+
+// SetSparseCheckoutPaths sets the sparse-checkout paths using git sparse-checkout command
+func (s sparseService) SetSparseCheckoutPaths(ctx context.Context, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no paths provided for sparse-checkout")
+	}
+
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("sparse-checkout").WithSubSubCmd("set").WithArgs(paths...), s.runOpts(),
+		"Set sparse-checkout paths",
+	)
+}
+
+// DisableSparseCheckout disables sparse-checkout using modern git command. Returns an error
+// wrapping ErrSparseCheckoutNotEnabled (checkable via errors.Is) when sparse-checkout was never
+// enabled in the first place, as opposed to a real command failure.
+func (s sparseService) DisableSparseCheckout(ctx context.Context) error {
+	err := s.commander.RunCommand(ctx,
+		NewSafeCmd("sparse-checkout").WithSubSubCmd("disable"), s.runOpts(),
+		"Disable sparse-checkout",
+	)
+	return classifySparseCheckoutDisableError(err)
+}
+
+// ApplyCheckout applies sparse-checkout changes by reading the tree
+func (s sparseService) ApplyCheckout(ctx context.Context) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("read-tree").WithFlag("-m", "").WithFlag("-u", "").WithRevArgs("HEAD"), s.runOpts(),
+		"Apply checkout changes",
+	)
+}
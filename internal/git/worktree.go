@@ -0,0 +1,40 @@
+package git
+
+import "context"
+
+// WorkTreeService stages files and inspects the working tree
+type WorkTreeService interface {
+	AddFile(ctx context.Context, filePath string) error
+	IsRepository(ctx context.Context) (bool, error)
+}
+
+// workTreeService is the exec-based WorkTreeService implementation
+type workTreeService struct{ base }
+
+var _ WorkTreeService = workTreeService{}
+
+// AddFile stages a file for commit
+func (s workTreeService) AddFile(ctx context.Context, filePath string) error {
+	return s.commander.RunCommand(ctx,
+		NewSafeCmd("add").WithArgs(filePath), s.runOpts(),
+		"Stage file",
+	)
+}
+
+// IsRepository checks if the current directory is a Git repository. An error is only returned
+// for failures other than "not a repository" (e.g. git missing, permission denied); callers that
+// only care about the repository check can keep ignoring the error, but now get a chance to
+// surface real failures instead of having them silently read as "not a repository".
+func (s workTreeService) IsRepository(ctx context.Context) (bool, error) {
+	_, err := s.commander.RunCommandWithOutput(ctx,
+		NewSafeCmd("rev-parse").WithFlag("--git-dir", ""), s.runOpts(),
+		"",
+	)
+	if err != nil {
+		if IsNotRepository(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
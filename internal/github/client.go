@@ -2,9 +2,15 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"assignment-pull-request/internal/errs"
 
 	"github.com/google/go-github/v57/github"
 	"golang.org/x/oauth2"
@@ -16,10 +22,57 @@ type Client struct {
 	ctx            context.Context
 	repositoryName string
 	dryRun         bool
+
+	// TokenSource is the oauth2.TokenSource backing client's transport, exposed so callers can
+	// inspect or swap the credential a Client was built with (a static PAT via NewClient, or an
+	// auto-refreshing GitHub App installation token via NewAppClient). Every c.client sub-resource
+	// (PullRequests, Issues, Repositories, ...) shares this same transport already; this field just
+	// makes that credential visible rather than buried inside the http.Client oauth2.NewClient built.
+	TokenSource oauth2.TokenSource
+
+	rateLimitMu sync.Mutex
+	rateLimit   RateLimit
+}
+
+// RateLimit is the most recently observed GitHub API rate-limit window, updated after every call
+// that returns a *github.Response (see recordRateLimit).
+type RateLimit struct {
+	Remaining int
+	Limit     int
+	Reset     time.Time
+}
+
+// RateLimit returns the rate-limit window observed on the most recent API call, or the zero value
+// if no call has completed yet (including throughout a dry run).
+func (c *Client) RateLimit() RateLimit {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	return c.rateLimit
+}
+
+// recordRateLimit updates c.rateLimit from resp's "Core" rate-limit headers, if resp is non-nil.
+func (c *Client) recordRateLimit(resp *github.Response) {
+	if resp == nil {
+		return
+	}
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	c.rateLimit = RateLimit{
+		Remaining: resp.Rate.Remaining,
+		Limit:     resp.Rate.Limit,
+		Reset:     resp.Rate.Reset.Time,
+	}
 }
 
 // NewClient creates a new GitHub client
 func NewClient(token, repositoryName string, dryRun bool) *Client {
+	return NewClientWithRetryPolicy(token, repositoryName, dryRun, DefaultRetryPolicy())
+}
+
+// NewClientWithRetryPolicy is NewClient with a caller-supplied RetryPolicy in place of
+// DefaultRetryPolicy, so a batch run across hundreds of student repos can tune how aggressively
+// it backs off from GitHub's secondary rate limits instead of aborting on the first trigger.
+func NewClientWithRetryPolicy(token, repositoryName string, dryRun bool, policy RetryPolicy) *Client {
 	c := &Client{
 		repositoryName: repositoryName,
 		ctx:            context.Background(),
@@ -31,7 +84,9 @@ func NewClient(token, repositoryName string, dryRun bool) *Client {
 		ts := oauth2.StaticTokenSource(
 			&oauth2.Token{AccessToken: token},
 		)
-		tc := oauth2.NewClient(c.ctx, ts)
+		c.TokenSource = ts
+		ctx := context.WithValue(c.ctx, oauth2.HTTPClient, &http.Client{Transport: newRetryTransport(http.DefaultTransport, policy)})
+		tc := oauth2.NewClient(ctx, ts)
 		c.client = github.NewClient(tc)
 	}
 
@@ -68,6 +123,7 @@ func (c *Client) GetExistingPullRequests() (map[string]string, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error getting pull requests: %w", err)
 		}
+		c.recordRateLimit(resp)
 
 		for _, pr := range prs {
 			if pr.Head != nil && pr.Head.Ref != nil && pr.State != nil {
@@ -84,90 +140,211 @@ func (c *Client) GetExistingPullRequests() (map[string]string, error) {
 	return allPRs, nil
 }
 
-// CreatePullRequest creates a pull request for the assignment branch
+// CreatePullRequest creates a pull request for the assignment branch. It's a thin wrapper around
+// CreatePullRequestWithRequest for callers that don't need reviewers, labels, assignees, a
+// milestone, or draft status.
 func (c *Client) CreatePullRequest(title, body, head, base string) (string, error) {
+	return c.CreatePullRequestWithRequest(CreatePullRequestRequest{
+		Title: title,
+		Body:  body,
+		Head:  head,
+		Base:  base,
+	})
+}
+
+// MergePullRequest merges a pull request automatically using the merge commit strategy
+func (c *Client) MergePullRequest(prNumber, title string) error {
 	if c.dryRun {
-		fmt.Printf("[DRY RUN] Would create pull request:\n")
-		fmt.Printf("  Title: %s\n", title)
-		fmt.Printf("  Head: %s\n", head)
-		fmt.Printf("  Base: %s\n", base)
-		bodyPreview := body
-		if len(body) > 100 {
-			bodyPreview = body[:100] + "..."
-		}
-		fmt.Printf("  Body: %s\n", bodyPreview)
+		fmt.Printf("[DRY RUN] Would merge pull request %s\n", prNumber)
+		return nil
+	}
 
-		// Simulate PR number (this would need to be passed in for proper simulation)
-		fmt.Printf("[DRY RUN] Simulated pull request #1\n")
-		return "#1", nil
+	// Convert PR number string to integer (remove # prefix if present)
+	prNum, err := strconv.Atoi(strings.TrimPrefix(prNumber, "#"))
+	if err != nil {
+		return fmt.Errorf("invalid PR number format '%s': %w", prNumber, err)
 	}
 
 	// Parse repository name
 	parts := strings.Split(c.repositoryName, "/")
 	if len(parts) != 2 {
-		return "", fmt.Errorf("invalid repository name format: %s", c.repositoryName)
+		return fmt.Errorf("invalid repository name format: %s", c.repositoryName)
 	}
 	owner, repo := parts[0], parts[1]
 
-	// Create the pull request via GitHub API
-	newPR := &github.NewPullRequest{
-		Title: &title,
-		Body:  &body,
-		Head:  &head,
-		Base:  &base,
+	// Merge the pull request using merge commit strategy
+	commitMessage := fmt.Sprintf("Merge pull request %s: %s", prNumber, title)
+	mergeOptions := &github.PullRequestOptions{
+		CommitTitle: commitMessage,
+		MergeMethod: "merge", // Use merge commit strategy
+	}
+
+	result, resp, err := c.client.PullRequests.Merge(c.ctx, owner, repo, prNum, "", mergeOptions)
+	if err != nil {
+		return classifyMergeError(prNumber, fmt.Errorf("error merging pull request %s: %w", prNumber, err))
+	}
+	c.recordRateLimit(resp)
+
+	if result.Merged != nil && *result.Merged {
+		fmt.Printf("✅ Merged pull request %s\n", prNumber)
+	} else {
+		return fmt.Errorf("failed to merge pull request %s: merge was not successful", prNumber)
+	}
+
+	return nil
+}
+
+// GetFileContent fetches the decoded content of a single file from owner/repo at ref
+func (c *Client) GetFileContent(owner, repo, path, ref string) (string, error) {
+	if c.client == nil {
+		return "", fmt.Errorf("GitHub API client not initialized")
 	}
 
-	pr, _, err := c.client.PullRequests.Create(c.ctx, owner, repo, newPR)
+	fileContent, _, _, err := c.client.Repositories.GetContents(c.ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
 	if err != nil {
-		return "", fmt.Errorf("error creating pull request: %w", err)
+		return "", fmt.Errorf("error fetching %s/%s/%s@%s: %w", owner, repo, path, ref, err)
+	}
+	if fileContent == nil {
+		return "", fmt.Errorf("%s/%s/%s@%s is a directory, not a file", owner, repo, path, ref)
+	}
+
+	content, err := fileContent.GetContent()
+	if err != nil {
+		return "", fmt.Errorf("error decoding content of %s/%s/%s@%s: %w", owner, repo, path, ref, err)
 	}
 
-	prNumber := fmt.Sprintf("#%d", *pr.Number)
-	fmt.Printf("✅ Created pull request %s: %s\n", prNumber, title)
-	return prNumber, nil
+	return content, nil
 }
 
-// MergePullRequest merges a pull request automatically using the merge commit strategy
-func (c *Client) MergePullRequest(prNumber, title string) error {
+// classifyMergeError recognizes GitHub's "not mergeable" (405) and conflict (409) responses to a
+// merge request and wraps err into errs.ErrMergeConflict, so callers can report a machine-readable
+// code and remediation hint instead of a raw API failure. err is returned unchanged for any other
+// status code or a non-API error.
+func classifyMergeError(prNumber string, err error) error {
+	var apiErr *github.ErrorResponse
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	if apiErr.Response.StatusCode == http.StatusMethodNotAllowed || apiErr.Response.StatusCode == http.StatusConflict {
+		return errs.ErrMergeConflict(prNumber, err)
+	}
+	return err
+}
+
+// RequestReviewers requests the given users as reviewers on prNumber
+func (c *Client) RequestReviewers(prNumber string, reviewers []string) error {
 	if c.dryRun {
-		fmt.Printf("[DRY RUN] Would merge pull request %s\n", prNumber)
+		fmt.Printf("[DRY RUN] Would request reviewers %v for pull request %s\n", reviewers, prNumber)
 		return nil
 	}
 
-	// Convert PR number string to integer (remove # prefix if present)
 	prNum, err := strconv.Atoi(strings.TrimPrefix(prNumber, "#"))
 	if err != nil {
 		return fmt.Errorf("invalid PR number format '%s': %w", prNumber, err)
 	}
 
-	// Parse repository name
 	parts := strings.Split(c.repositoryName, "/")
 	if len(parts) != 2 {
 		return fmt.Errorf("invalid repository name format: %s", c.repositoryName)
 	}
 	owner, repo := parts[0], parts[1]
 
-	// Merge the pull request using merge commit strategy
-	commitMessage := fmt.Sprintf("Merge pull request %s: %s", prNumber, title)
-	mergeOptions := &github.PullRequestOptions{
-		CommitTitle: commitMessage,
-		MergeMethod: "merge", // Use merge commit strategy
+	_, resp, err := c.client.PullRequests.RequestReviewers(c.ctx, owner, repo, prNum, github.ReviewersRequest{Reviewers: reviewers})
+	if err != nil {
+		return fmt.Errorf("error requesting reviewers for pull request %s: %w", prNumber, err)
 	}
+	c.recordRateLimit(resp)
 
-	result, _, err := c.client.PullRequests.Merge(c.ctx, owner, repo, prNum, "", mergeOptions)
+	fmt.Printf("✅ Requested reviewers %v for pull request %s\n", reviewers, prNumber)
+	return nil
+}
+
+// AddLabels applies labels to prNumber
+func (c *Client) AddLabels(prNumber string, labels []string) error {
+	if c.dryRun {
+		fmt.Printf("[DRY RUN] Would add labels %v to pull request %s\n", labels, prNumber)
+		return nil
+	}
+
+	prNum, err := strconv.Atoi(strings.TrimPrefix(prNumber, "#"))
 	if err != nil {
-		return fmt.Errorf("error merging pull request %s: %w", prNumber, err)
+		return fmt.Errorf("invalid PR number format '%s': %w", prNumber, err)
 	}
 
-	if result.Merged != nil && *result.Merged {
-		fmt.Printf("✅ Merged pull request %s\n", prNumber)
-	} else {
-		return fmt.Errorf("failed to merge pull request %s: merge was not successful", prNumber)
+	parts := strings.Split(c.repositoryName, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid repository name format: %s", c.repositoryName)
+	}
+	owner, repo := parts[0], parts[1]
+
+	_, resp, err := c.client.Issues.AddLabelsToIssue(c.ctx, owner, repo, prNum, labels)
+	if err != nil {
+		return fmt.Errorf("error adding labels to pull request %s: %w", prNumber, err)
+	}
+	c.recordRateLimit(resp)
+
+	fmt.Printf("✅ Added labels %v to pull request %s\n", labels, prNumber)
+	return nil
+}
+
+// SetMilestone assigns the milestone named milestoneTitle to prNumber, looking up its number by
+// title since the GitHub API identifies a milestone by number, not title
+func (c *Client) SetMilestone(prNumber, milestoneTitle string) error {
+	if c.dryRun {
+		fmt.Printf("[DRY RUN] Would set milestone '%s' on pull request %s\n", milestoneTitle, prNumber)
+		return nil
+	}
+
+	prNum, err := strconv.Atoi(strings.TrimPrefix(prNumber, "#"))
+	if err != nil {
+		return fmt.Errorf("invalid PR number format '%s': %w", prNumber, err)
+	}
+
+	parts := strings.Split(c.repositoryName, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid repository name format: %s", c.repositoryName)
+	}
+	owner, repo := parts[0], parts[1]
+
+	milestoneNumber, err := c.findMilestoneNumber(owner, repo, milestoneTitle)
+	if err != nil {
+		return err
 	}
 
+	update := &github.IssueRequest{Milestone: &milestoneNumber}
+	_, resp, err := c.client.Issues.Edit(c.ctx, owner, repo, prNum, update)
+	if err != nil {
+		return fmt.Errorf("error setting milestone on pull request %s: %w", prNumber, err)
+	}
+	c.recordRateLimit(resp)
+
+	fmt.Printf("✅ Set milestone '%s' on pull request %s\n", milestoneTitle, prNumber)
 	return nil
 }
 
+// findMilestoneNumber looks up the milestone number for milestoneTitle among all of owner/repo's
+// milestones, open or closed
+func (c *Client) findMilestoneNumber(owner, repo, milestoneTitle string) (int, error) {
+	opts := &github.MilestoneListOptions{State: "all", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		milestones, resp, err := c.client.Issues.ListMilestones(c.ctx, owner, repo, opts)
+		if err != nil {
+			return 0, fmt.Errorf("error listing milestones: %w", err)
+		}
+		c.recordRateLimit(resp)
+		for _, milestone := range milestones {
+			if milestone.Title != nil && *milestone.Title == milestoneTitle && milestone.Number != nil {
+				return *milestone.Number, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return 0, fmt.Errorf("milestone '%s' not found in %s/%s", milestoneTitle, owner, repo)
+}
+
 // ReopenPullRequest reopens a closed pull request
 func (c *Client) ReopenPullRequest(prNumber, title string) error {
 	if c.dryRun {
@@ -194,10 +371,11 @@ func (c *Client) ReopenPullRequest(prNumber, title string) error {
 		State: &state,
 	}
 
-	_, _, err = c.client.PullRequests.Edit(c.ctx, owner, repo, prNum, prUpdate)
+	_, resp, err := c.client.PullRequests.Edit(c.ctx, owner, repo, prNum, prUpdate)
 	if err != nil {
 		return fmt.Errorf("error reopening pull request %s: %w", prNumber, err)
 	}
+	c.recordRateLimit(resp)
 
 	fmt.Printf("✅ Reopened pull request %s\n", prNumber)
 	return nil
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// graphQLEndpoint is the GitHub GraphQL v4 API endpoint, used directly via net/http rather than a
+// generated client since the only query this package needs is the batched ref lookup below.
+const graphQLEndpoint = "https://api.github.com/graphql"
+
+// graphQLBatchSize is the maximum number of heads queried in a single GraphQL request. GitHub's
+// GraphQL API counts query complexity per aliased field, so batches are kept well under its node
+// limit rather than attempting everything in one request.
+const graphQLBatchSize = 50
+
+// PRInfo is the pull request GitHub reports as associated with a branch ref, as returned by
+// LookupPullRequestsByHeads.
+type PRInfo struct {
+	Number    int    `json:"number"`
+	State     string `json:"state"`
+	IsDraft   bool   `json:"isDraft"`
+	Mergeable string `json:"mergeable"`
+}
+
+// LookupPullRequestsByHeads looks up, for each branch name in heads, the most recently associated
+// pull request via the GitHub GraphQL v4 API, batching up to graphQLBatchSize heads per request
+// instead of GetExistingPullRequests' full REST pagination -- the difference that matters once a
+// classroom repo accumulates thousands of historical PRs. Heads with no associated pull request
+// are simply absent from the returned map. If the GraphQL request fails or returns errors for a
+// batch, that batch falls back to GetExistingPullRequests' REST pagination instead of failing the
+// whole lookup.
+func (c *Client) LookupPullRequestsByHeads(heads []string) (map[string]PRInfo, error) {
+	if c.dryRun {
+		fmt.Printf("[DRY RUN] Would look up pull requests for %d head(s) via GraphQL\n", len(heads))
+		return make(map[string]PRInfo), nil
+	}
+
+	parts := strings.Split(c.repositoryName, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repository name format: %s", c.repositoryName)
+	}
+	owner, repo := parts[0], parts[1]
+
+	result := make(map[string]PRInfo, len(heads))
+	for start := 0; start < len(heads); start += graphQLBatchSize {
+		end := start + graphQLBatchSize
+		if end > len(heads) {
+			end = len(heads)
+		}
+		batch := heads[start:end]
+
+		batchResult, err := c.lookupPullRequestsByHeadsBatch(owner, repo, batch)
+		if err != nil {
+			fmt.Printf("Warning: GraphQL lookup failed for %d head(s), falling back to REST pagination: %v\n", len(batch), err)
+			fallback, fallbackErr := c.restFallbackForHeads(batch)
+			if fallbackErr != nil {
+				return nil, fmt.Errorf("GraphQL lookup failed (%w) and REST fallback also failed: %v", err, fallbackErr)
+			}
+			batchResult = fallback
+		}
+
+		for head, info := range batchResult {
+			result[head] = info
+		}
+	}
+
+	return result, nil
+}
+
+// restFallbackForHeads restricts GetExistingPullRequests' full REST-paginated result to heads, for
+// use when a GraphQL batch can't be completed.
+func (c *Client) restFallbackForHeads(heads []string) (map[string]PRInfo, error) {
+	allPRs, err := c.GetExistingPullRequests()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]PRInfo, len(heads))
+	for _, head := range heads {
+		if state, ok := allPRs[head]; ok {
+			result[head] = PRInfo{State: state}
+		}
+	}
+	return result, nil
+}
+
+// graphQLRequest is the JSON body POSTed to graphQLEndpoint.
+type graphQLRequest struct {
+	Query string `json:"query"`
+}
+
+// graphQLResponse is the JSON body graphQLEndpoint returns: Data keyed by each query's alias, plus
+// any partial Errors GitHub reports alongside otherwise-usable Data.
+type graphQLResponse struct {
+	Data   map[string]graphQLRepository `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type graphQLRepository struct {
+	Ref *struct {
+		AssociatedPullRequests struct {
+			Nodes []PRInfo `json:"nodes"`
+		} `json:"associatedPullRequests"`
+	} `json:"ref"`
+}
+
+// graphQLHTTPClient returns an *http.Client authenticated with c.TokenSource, shared by every
+// raw GraphQL request this package sends (LookupPullRequestsByHeads, enablePullRequestAutoMerge).
+func (c *Client) graphQLHTTPClient() *http.Client {
+	return oauth2.NewClient(c.ctx, c.TokenSource)
+}
+
+// lookupPullRequestsByHeadsBatch runs a single GraphQL query aliasing one repository(ref: ...)
+// lookup per head in batch, so the whole batch resolves in one round trip.
+func (c *Client) lookupPullRequestsByHeadsBatch(owner, repo string, batch []string) (map[string]PRInfo, error) {
+	aliasToHead := make(map[string]string, len(batch))
+	var fields strings.Builder
+	fields.WriteString("query {\n")
+	for i, head := range batch {
+		alias := fmt.Sprintf("r%d", i)
+		aliasToHead[alias] = head
+		fmt.Fprintf(&fields, "  %s: repository(owner: %q, name: %q) {\n", alias, owner, repo)
+		fmt.Fprintf(&fields, "    ref(qualifiedName: %q) {\n", "refs/heads/"+head)
+		fields.WriteString("      associatedPullRequests(first: 1) { nodes { number state isDraft mergeable } }\n")
+		fields.WriteString("    }\n  }\n")
+	}
+	fields.WriteString("}")
+
+	reqBody, err := json.Marshal(graphQLRequest{Query: fields.String()})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding GraphQL request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(c.ctx, http.MethodPost, graphQLEndpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("error building GraphQL request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.graphQLHTTPClient().Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("error sending GraphQL request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GraphQL request failed with status %d", httpResp.StatusCode)
+	}
+
+	var parsed graphQLResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("error decoding GraphQL response: %w", err)
+	}
+	if len(parsed.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL response contained %d error(s): %s", len(parsed.Errors), parsed.Errors[0].Message)
+	}
+
+	result := make(map[string]PRInfo, len(batch))
+	for alias, head := range aliasToHead {
+		repository, ok := parsed.Data[alias]
+		if !ok || repository.Ref == nil || len(repository.Ref.AssociatedPullRequests.Nodes) == 0 {
+			continue
+		}
+		result[head] = repository.Ref.AssociatedPullRequests.Nodes[0]
+	}
+
+	return result, nil
+}
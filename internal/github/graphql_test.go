@@ -0,0 +1,31 @@
+package github
+
+import "testing"
+
+// TestLookupPullRequestsByHeadsDryRun covers that a dry run never calls GraphQL and reports no PRs.
+func TestLookupPullRequestsByHeadsDryRun(t *testing.T) {
+	client := NewClient("test-token", "owner/repo", true)
+
+	prs, err := client.LookupPullRequestsByHeads([]string{"assignment-1", "assignment-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 0 {
+		t.Errorf("expected empty PR map in dry-run mode, got %d PRs", len(prs))
+	}
+}
+
+// TestRestFallbackForHeadsRestrictsToRequestedHeads covers that the REST fallback only returns the
+// heads asked for, even though GetExistingPullRequests itself returns every PR in the repo.
+func TestRestFallbackForHeadsRestrictsToRequestedHeads(t *testing.T) {
+	client := NewClient("test-token", "owner/repo", true)
+
+	prs, err := client.restFallbackForHeads([]string{"assignment-1", "assignment-missing"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Dry-run GetExistingPullRequests always returns an empty map, so neither head should resolve.
+	if len(prs) != 0 {
+		t.Errorf("expected no PRs for either head in dry-run mode, got %d", len(prs))
+	}
+}
@@ -0,0 +1,289 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// MergeOptions configures MergePullRequestWithOptions beyond the single hard-coded merge-commit
+// behavior MergePullRequest provides, for classroom workflows that need a different merge
+// strategy, templated commit text, or to wait on required status checks before merging.
+type MergeOptions struct {
+	// Strategy is one of "merge", "squash", or "rebase" (github.PullRequestOptions.MergeMethod).
+	// Defaults to "merge" when empty.
+	Strategy string
+
+	// CommitTitleTemplate and CommitMessageTemplate are text/template strings rendered against a
+	// struct with PRNumber and Title fields to produce the merge commit's title/message. Empty
+	// falls back to MergePullRequest's existing "Merge pull request %s: %s" title and no message.
+	CommitTitleTemplate   string
+	CommitMessageTemplate string
+
+	// WaitForChecks, when true, polls the PR head SHA's check runs and combined status until every
+	// context in RequiredContexts reports success, or Timeout elapses.
+	WaitForChecks    bool
+	RequiredContexts []string
+	Timeout          time.Duration
+
+	// EnableAutoMerge, when true, enables GitHub's native auto-merge (via the GraphQL
+	// enablePullRequestAutoMerge mutation) instead of merging immediately, so GitHub merges the PR
+	// itself once branch protection and required checks pass.
+	EnableAutoMerge bool
+
+	// SignCommits, when true, builds the merge commit manually via the Git Data API and attaches a
+	// detached signature from Signer, instead of letting PullRequests.Merge author an unsigned
+	// commit as the PAT/App user. Squash is the only Strategy SignCommits supports, since it
+	// doesn't require recomputing a three-way merge tree: the new commit reuses the PR head's
+	// existing tree with the base branch's current commit as its sole parent. With SignCommits set,
+	// an empty Strategy is accepted and also produces that squash commit.
+	SignCommits bool
+	// Signer produces the detached signature SignCommits attaches to the new commit. Required when
+	// SignCommits is true.
+	Signer Signer
+	// Author and Committer are the identities recorded on a SignCommits commit.
+	Author    CommitAuthor
+	Committer CommitAuthor
+}
+
+// mergeCommitTemplateData is the data text/template renders CommitTitleTemplate and
+// CommitMessageTemplate against.
+type mergeCommitTemplateData struct {
+	PRNumber string
+	Title    string
+}
+
+// renderMergeTemplate renders tmplText against data, returning fallback when tmplText is empty.
+func renderMergeTemplate(tmplText, fallback string, data mergeCommitTemplateData) (string, error) {
+	if tmplText == "" {
+		return fallback, nil
+	}
+	tmpl, err := template.New("merge").Parse(tmplText)
+	if err != nil {
+		return "", fmt.Errorf("error parsing merge commit template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("error rendering merge commit template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// MergePullRequestWithOptions merges prNumber per opts: a configurable merge strategy and commit
+// text (in place of MergePullRequest's hard-coded merge-commit behavior), optionally waiting for
+// opts.RequiredContexts to succeed first, or enabling GitHub's native auto-merge instead of
+// merging directly. Dry-run mode prints the planned strategy and the check names it would wait on
+// without calling the GitHub API.
+func (c *Client) MergePullRequestWithOptions(prNumber, title string, opts MergeOptions) error {
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = "merge"
+	}
+
+	if c.dryRun {
+		fmt.Printf("[DRY RUN] Would merge pull request %s using strategy %q\n", prNumber, strategy)
+		if opts.WaitForChecks {
+			fmt.Printf("[DRY RUN] Would wait on required checks: %v\n", opts.RequiredContexts)
+		}
+		if opts.EnableAutoMerge {
+			fmt.Printf("[DRY RUN] Would enable auto-merge instead of merging directly\n")
+		}
+		if opts.SignCommits {
+			fmt.Printf("[DRY RUN] Would build and sign the merge commit via the Git Data API\n")
+		}
+		return nil
+	}
+
+	prNum, err := strconv.Atoi(strings.TrimPrefix(prNumber, "#"))
+	if err != nil {
+		return fmt.Errorf("invalid PR number format '%s': %w", prNumber, err)
+	}
+
+	parts := strings.Split(c.repositoryName, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid repository name format: %s", c.repositoryName)
+	}
+	owner, repo := parts[0], parts[1]
+
+	pr, resp, err := c.client.PullRequests.Get(c.ctx, owner, repo, prNum)
+	if err != nil {
+		return fmt.Errorf("error fetching pull request %s: %w", prNumber, err)
+	}
+	c.recordRateLimit(resp)
+	if pr.Head == nil || pr.Head.SHA == nil {
+		return fmt.Errorf("pull request %s has no head SHA", prNumber)
+	}
+	headSHA := *pr.Head.SHA
+
+	if opts.EnableAutoMerge {
+		if pr.NodeID == nil {
+			return fmt.Errorf("pull request %s has no GraphQL node ID", prNumber)
+		}
+		return c.enablePullRequestAutoMerge(*pr.NodeID, strategy)
+	}
+
+	if opts.WaitForChecks {
+		if err := c.waitForRequiredChecks(owner, repo, headSHA, opts.RequiredContexts, opts.Timeout); err != nil {
+			return err
+		}
+	}
+
+	commitTitle, err := renderMergeTemplate(opts.CommitTitleTemplate, fmt.Sprintf("Merge pull request %s: %s", prNumber, title), mergeCommitTemplateData{PRNumber: prNumber, Title: title})
+	if err != nil {
+		return err
+	}
+	commitMessage, err := renderMergeTemplate(opts.CommitMessageTemplate, "", mergeCommitTemplateData{PRNumber: prNumber, Title: title})
+	if err != nil {
+		return err
+	}
+
+	if opts.SignCommits {
+		return c.mergeWithSignedCommit(owner, repo, prNumber, pr, commitTitle, opts)
+	}
+
+	mergeOptions := &github.PullRequestOptions{
+		CommitTitle: commitTitle,
+		MergeMethod: strategy,
+	}
+
+	result, mergeResp, err := c.client.PullRequests.Merge(c.ctx, owner, repo, prNum, commitMessage, mergeOptions)
+	if err != nil {
+		return classifyMergeError(prNumber, fmt.Errorf("error merging pull request %s: %w", prNumber, err))
+	}
+	c.recordRateLimit(mergeResp)
+
+	if result.Merged != nil && *result.Merged {
+		fmt.Printf("✅ Merged pull request %s (%s)\n", prNumber, strategy)
+	} else {
+		return fmt.Errorf("failed to merge pull request %s: merge was not successful", prNumber)
+	}
+
+	return nil
+}
+
+// waitForRequiredChecks polls sha's check runs and combined status until every context in
+// requiredContexts reports success, or timeout elapses.
+func (c *Client) waitForRequiredChecks(owner, repo, sha string, requiredContexts []string, timeout time.Duration) error {
+	if len(requiredContexts) == 0 {
+		return nil
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Minute
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		pending, err := c.pendingRequiredContexts(owner, repo, sha, requiredContexts)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting on required checks %v for %s", timeout, pending, sha)
+		}
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// pendingRequiredContexts returns the subset of requiredContexts that haven't yet reported success
+// via either the check-runs API (GitHub Actions, Apps) or the legacy commit-status API.
+func (c *Client) pendingRequiredContexts(owner, repo, sha string, requiredContexts []string) ([]string, error) {
+	succeeded := make(map[string]bool, len(requiredContexts))
+
+	checkRuns, resp, err := c.client.Checks.ListCheckRunsForRef(c.ctx, owner, repo, sha, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error listing check runs for %s: %w", sha, err)
+	}
+	c.recordRateLimit(resp)
+	for _, run := range checkRuns.CheckRuns {
+		if run.Name != nil && run.Conclusion != nil && *run.Conclusion == "success" {
+			succeeded[*run.Name] = true
+		}
+	}
+
+	status, statusResp, err := c.client.Repositories.GetCombinedStatus(c.ctx, owner, repo, sha, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting combined status for %s: %w", sha, err)
+	}
+	c.recordRateLimit(statusResp)
+	for _, s := range status.Statuses {
+		if s.Context != nil && s.State != nil && *s.State == "success" {
+			succeeded[*s.Context] = true
+		}
+	}
+
+	var pending []string
+	for _, context := range requiredContexts {
+		if !succeeded[context] {
+			pending = append(pending, context)
+		}
+	}
+	return pending, nil
+}
+
+// enablePullRequestAutoMergeMutation requests strategy as GitHub's GraphQL MergeMethod enum
+// (MERGE, SQUASH, REBASE).
+const enablePullRequestAutoMergeMutation = `
+mutation($prId: ID!, $mergeMethod: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $prId, mergeMethod: $mergeMethod}) {
+    pullRequest { autoMergeRequest { enabledAt } }
+  }
+}`
+
+// enablePullRequestAutoMerge enables GitHub's native auto-merge on the pull request identified by
+// prNodeID, so GitHub merges it itself once branch protection and required checks pass.
+func (c *Client) enablePullRequestAutoMerge(prNodeID, strategy string) error {
+	reqBody, err := json.Marshal(struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}{
+		Query: enablePullRequestAutoMergeMutation,
+		Variables: map[string]any{
+			"prId":        prNodeID,
+			"mergeMethod": strings.ToUpper(strategy),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding auto-merge mutation: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(c.ctx, http.MethodPost, graphQLEndpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("error building auto-merge mutation request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.graphQLHTTPClient().Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("error sending auto-merge mutation: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("auto-merge mutation failed with status %d", httpResp.StatusCode)
+	}
+
+	var parsed struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(&parsed); err != nil {
+		return fmt.Errorf("error decoding auto-merge mutation response: %w", err)
+	}
+	if len(parsed.Errors) > 0 {
+		return fmt.Errorf("auto-merge mutation returned %d error(s): %s", len(parsed.Errors), parsed.Errors[0].Message)
+	}
+
+	fmt.Println("✅ Enabled auto-merge")
+	return nil
+}
@@ -0,0 +1,54 @@
+package github
+
+import "testing"
+
+// TestRenderMergeTemplate covers the CommitTitleTemplate/CommitMessageTemplate rendering
+// MergePullRequestWithOptions uses in place of the hard-coded title MergePullRequest builds.
+func TestRenderMergeTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmpl     string
+		fallback string
+		want     string
+	}{
+		{
+			name:     "empty template uses fallback",
+			tmpl:     "",
+			fallback: "Merge pull request #1: Test",
+			want:     "Merge pull request #1: Test",
+		},
+		{
+			name: "template renders PRNumber and Title",
+			tmpl: "{{.PRNumber}} -- {{.Title}}",
+			want: "#1 -- Test",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderMergeTemplate(tt.tmpl, tt.fallback, mergeCommitTemplateData{PRNumber: "#1", Title: "Test"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMergePullRequestWithOptionsDryRun covers that a dry run never calls the GitHub API
+// regardless of which MergeOptions are set.
+func TestMergePullRequestWithOptionsDryRun(t *testing.T) {
+	client := NewClient("test-token", "owner/repo", true)
+
+	err := client.MergePullRequestWithOptions("#1", "Test PR", MergeOptions{
+		Strategy:         "squash",
+		WaitForChecks:    true,
+		RequiredContexts: []string{"ci/build"},
+		EnableAutoMerge:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// CreatePullRequestRequest is the full set of side effects CreatePullRequestWithRequest can apply
+// when opening a pull request -- reviewers, labels, assignees, milestone, and draft status -- in
+// place of CreatePullRequest's title/body/head/base alone, for classroom workflows where an
+// assignment PR needs specific TAs as reviewers, grading labels, or should start as a draft.
+type CreatePullRequestRequest struct {
+	Title string
+	Body  string
+	Head  string
+	Base  string
+
+	Draft         bool
+	Reviewers     []string
+	TeamReviewers []string
+	Labels        []string
+	Assignees     []string
+	Milestone     *int
+}
+
+// CreatePullRequestWithRequest creates a pull request per req, then applies its reviewers, labels,
+// assignees, and milestone as separate, idempotent follow-up calls (requesting the same reviewer
+// or label twice, or re-setting the same milestone, is a no-op on GitHub's side), so a failure
+// partway through can simply be retried without re-creating the pull request. Dry-run mode prints
+// every planned side effect instead of calling the GitHub API.
+func (c *Client) CreatePullRequestWithRequest(req CreatePullRequestRequest) (string, error) {
+	if c.dryRun {
+		fmt.Printf("[DRY RUN] Would create pull request:\n")
+		fmt.Printf("  Title: %s\n", req.Title)
+		fmt.Printf("  Head: %s\n", req.Head)
+		fmt.Printf("  Base: %s\n", req.Base)
+		fmt.Printf("  Draft: %t\n", req.Draft)
+		if len(req.Reviewers) > 0 {
+			fmt.Printf("  Reviewers: %v\n", req.Reviewers)
+		}
+		if len(req.TeamReviewers) > 0 {
+			fmt.Printf("  Team reviewers: %v\n", req.TeamReviewers)
+		}
+		if len(req.Labels) > 0 {
+			fmt.Printf("  Labels: %v\n", req.Labels)
+		}
+		if len(req.Assignees) > 0 {
+			fmt.Printf("  Assignees: %v\n", req.Assignees)
+		}
+		if req.Milestone != nil {
+			fmt.Printf("  Milestone: #%d\n", *req.Milestone)
+		}
+		fmt.Printf("[DRY RUN] Simulated pull request #1\n")
+		return "#1", nil
+	}
+
+	parts := strings.Split(c.repositoryName, "/")
+	if len(parts) != 2 {
+		return "", fmt.Errorf("invalid repository name format: %s", c.repositoryName)
+	}
+	owner, repo := parts[0], parts[1]
+
+	newPR := &github.NewPullRequest{
+		Title: &req.Title,
+		Body:  &req.Body,
+		Head:  &req.Head,
+		Base:  &req.Base,
+		Draft: &req.Draft,
+	}
+
+	pr, resp, err := c.client.PullRequests.Create(c.ctx, owner, repo, newPR)
+	if err != nil {
+		return "", fmt.Errorf("error creating pull request: %w", err)
+	}
+	c.recordRateLimit(resp)
+
+	prNumber := fmt.Sprintf("#%d", *pr.Number)
+	fmt.Printf("✅ Created pull request %s: %s\n", prNumber, req.Title)
+
+	if len(req.Reviewers) > 0 || len(req.TeamReviewers) > 0 {
+		_, reviewersResp, reviewersErr := c.client.PullRequests.RequestReviewers(c.ctx, owner, repo, *pr.Number, github.ReviewersRequest{
+			Reviewers:     req.Reviewers,
+			TeamReviewers: req.TeamReviewers,
+		})
+		if reviewersErr != nil {
+			return prNumber, fmt.Errorf("error requesting reviewers for pull request %s: %w", prNumber, reviewersErr)
+		}
+		c.recordRateLimit(reviewersResp)
+	}
+
+	if len(req.Labels) > 0 {
+		if err := c.AddLabels(prNumber, req.Labels); err != nil {
+			return prNumber, err
+		}
+	}
+
+	if len(req.Assignees) > 0 {
+		_, assigneesResp, assigneesErr := c.client.Issues.AddAssignees(c.ctx, owner, repo, *pr.Number, req.Assignees)
+		if assigneesErr != nil {
+			return prNumber, fmt.Errorf("error adding assignees to pull request %s: %w", prNumber, assigneesErr)
+		}
+		c.recordRateLimit(assigneesResp)
+	}
+
+	if req.Milestone != nil {
+		update := &github.IssueRequest{Milestone: req.Milestone}
+		_, milestoneResp, milestoneErr := c.client.Issues.Edit(c.ctx, owner, repo, *pr.Number, update)
+		if milestoneErr != nil {
+			return prNumber, fmt.Errorf("error setting milestone on pull request %s: %w", prNumber, milestoneErr)
+		}
+		c.recordRateLimit(milestoneResp)
+	}
+
+	return prNumber, nil
+}
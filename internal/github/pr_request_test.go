@@ -0,0 +1,43 @@
+package github
+
+import "testing"
+
+// TestCreatePullRequestWithRequestDryRun covers that a dry run enumerates every planned side
+// effect (reviewers, labels, assignees, milestone, draft) without calling the GitHub API.
+func TestCreatePullRequestWithRequestDryRun(t *testing.T) {
+	client := NewClient("test-token", "owner/repo", true)
+	milestone := 3
+
+	prNum, err := client.CreatePullRequestWithRequest(CreatePullRequestRequest{
+		Title:         "Test PR",
+		Body:          "body",
+		Head:          "feature-branch",
+		Base:          "main",
+		Draft:         true,
+		Reviewers:     []string{"alice"},
+		TeamReviewers: []string{"tas"},
+		Labels:        []string{"assignment:hw3"},
+		Assignees:     []string{"bob"},
+		Milestone:     &milestone,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prNum != "#1" {
+		t.Errorf("expected simulated PR #1 in dry-run mode, got %s", prNum)
+	}
+}
+
+// TestCreatePullRequestIsAThinWrapper covers that the legacy CreatePullRequest signature still
+// behaves the same as before now that it delegates to CreatePullRequestWithRequest.
+func TestCreatePullRequestIsAThinWrapper(t *testing.T) {
+	client := NewClient("test-token", "owner/repo", true)
+
+	prNum, err := client.CreatePullRequest("Test PR", "body", "feature-branch", "main")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prNum != "#1" {
+		t.Errorf("expected simulated PR #1 in dry-run mode, got %s", prNum)
+	}
+}
@@ -0,0 +1,132 @@
+package github
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how retryTransport handles GitHub's secondary/abuse rate limits and
+// transient server errors, so a single abuse-detection trigger or blip doesn't abort a batch run
+// across hundreds of student repos.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent, including the first try.
+	MaxAttempts int
+	// BaseDelay is the backoff delay before the first retry of a 502/503/504.
+	BaseDelay time.Duration
+	// Factor is the exponential backoff multiplier applied to BaseDelay on each subsequent retry.
+	Factor float64
+	// MaxDelay caps the backoff delay computed from BaseDelay and Factor.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy NewClient and NewAppClient use unless a caller opts into
+// a custom one via NewClientWithRetryPolicy/NewAppClientWithRetryPolicy.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Second,
+		Factor:      2,
+		MaxDelay:    60 * time.Second,
+	}
+}
+
+// retryTransport is an http.RoundTripper that retries GitHub API requests against secondary
+// (abuse) rate limits and transient 5xx errors, honoring Retry-After and X-RateLimit-Reset
+// headers where GitHub provides them. A 422 (Unprocessable Entity) is never retried, since it
+// indicates a malformed request that retrying can't fix.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+	sleep  func(time.Duration) // overridable in tests; defaults to time.Sleep
+}
+
+func newRetryTransport(base http.RoundTripper, policy RetryPolicy) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	return &retryTransport{base: base, policy: policy, sleep: time.Sleep}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var bodyBytes []byte
+	if req.Body != nil {
+		var err error
+		bodyBytes, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 1; attempt <= t.policy.MaxAttempts; attempt++ {
+		if bodyBytes != nil {
+			req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		}
+
+		resp, err = t.base.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+
+		if attempt == t.policy.MaxAttempts || !t.shouldRetry(resp) {
+			return resp, nil
+		}
+
+		delay := t.delayFor(resp, attempt)
+		resp.Body.Close()
+		t.sleep(delay)
+	}
+
+	return resp, err
+}
+
+// shouldRetry reports whether resp's status warrants another attempt: a secondary rate limit
+// (403 with Retry-After or an exhausted X-RateLimit-Remaining) or a transient 5xx. 422 is
+// explicitly excluded so a malformed request fails fast instead of retrying MaxAttempts times.
+func (t *retryTransport) shouldRetry(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusUnprocessableEntity:
+		return false
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusForbidden:
+		return resp.Header.Get("Retry-After") != "" || resp.Header.Get("X-RateLimit-Remaining") == "0"
+	default:
+		return false
+	}
+}
+
+// delayFor computes how long to sleep before retrying resp's request: Retry-After or
+// X-RateLimit-Reset when GitHub provides one, otherwise exponential backoff with jitter.
+func (t *retryTransport) delayFor(resp *http.Response, attempt int) time.Duration {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" && resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if unix, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			if until := time.Until(time.Unix(unix, 0)); until > 0 {
+				return until
+			}
+		}
+	}
+
+	backoff := float64(t.policy.BaseDelay) * math.Pow(t.policy.Factor, float64(attempt-1))
+	if max := float64(t.policy.MaxDelay); backoff > max {
+		backoff = max
+	}
+	jitter := 1 + (rand.Float64()-0.5)*0.2 // +/-10% jitter
+	return time.Duration(backoff * jitter)
+}
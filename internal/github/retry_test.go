@@ -0,0 +1,123 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper for stubbing retryTransport's base.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func newTestResponse(status int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: status, Header: header, Body: http.NoBody}
+}
+
+// TestRetryTransportRetriesTransientServerErrors covers the 502/503/504 exponential-backoff path.
+func TestRetryTransportRetriesTransientServerErrors(t *testing.T) {
+	attempts := 0
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts < 3 {
+			return newTestResponse(http.StatusServiceUnavailable, nil), nil
+		}
+		return newTestResponse(http.StatusOK, nil), nil
+	})
+
+	transport := newRetryTransport(base, RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, Factor: 2, MaxDelay: time.Millisecond})
+	transport.sleep = func(time.Duration) {}
+
+	resp, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/o/r", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected eventual 200, got %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+// TestRetryTransportNeverRetries422 covers that a malformed request fails fast.
+func TestRetryTransportNeverRetries422(t *testing.T) {
+	attempts := 0
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return newTestResponse(http.StatusUnprocessableEntity, nil), nil
+	})
+
+	transport := newRetryTransport(base, DefaultRetryPolicy())
+	transport.sleep = func(time.Duration) { t.Fatal("should not sleep/retry on 422") }
+
+	resp, err := transport.RoundTrip(httptest.NewRequest(http.MethodPost, "https://api.github.com/repos/o/r/pulls", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 to pass through, got %d", resp.StatusCode)
+	}
+	if attempts != 1 {
+		t.Errorf("expected exactly 1 attempt, got %d", attempts)
+	}
+}
+
+// TestRetryTransportHonorsRetryAfter covers the secondary (abuse) rate-limit path, where GitHub
+// sends a 403 with a Retry-After header instead of a bare 5xx.
+func TestRetryTransportHonorsRetryAfter(t *testing.T) {
+	attempts := 0
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			header := http.Header{}
+			header.Set("Retry-After", "0")
+			return newTestResponse(http.StatusForbidden, header), nil
+		}
+		return newTestResponse(http.StatusOK, nil), nil
+	})
+
+	var slept time.Duration
+	transport := newRetryTransport(base, DefaultRetryPolicy())
+	transport.sleep = func(d time.Duration) { slept = d }
+
+	resp, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/o/r", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected eventual 200, got %d", resp.StatusCode)
+	}
+	if slept != 0 {
+		t.Errorf("expected Retry-After: 0 to translate to a zero sleep, got %v", slept)
+	}
+}
+
+// TestRetryTransportGivesUpAfterMaxAttempts covers that persistent failures surface the last
+// response instead of retrying forever.
+func TestRetryTransportGivesUpAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return newTestResponse(http.StatusBadGateway, nil), nil
+	})
+
+	transport := newRetryTransport(base, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, Factor: 2, MaxDelay: time.Millisecond})
+	transport.sleep = func(time.Duration) {}
+
+	resp, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/o/r", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected the last 502 to be returned, got %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Errorf("expected exactly MaxAttempts=3 attempts, got %d", attempts)
+	}
+}
@@ -0,0 +1,201 @@
+package github
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// Signer signs payload (a Git commit object's canonical byte representation) and returns a
+// detached RFC 4880 (OpenPGP) or equivalent signature, so MergePullRequestWithOptions can produce
+// a verified merge commit without this package needing to know whether the key lives on disk, in
+// a KMS, or behind a GitHub App's own signing endpoint.
+type Signer interface {
+	Sign(payload []byte) ([]byte, error)
+}
+
+// CommitAuthor identifies the name, email, and timestamp recorded on a signed commit's author and
+// committer fields.
+type CommitAuthor struct {
+	Name  string
+	Email string
+	When  time.Time
+}
+
+// buildCommitPayload renders the canonical Git commit object Signer.Sign signs over:
+// "tree <sha>\n[parent <sha>\n]...author <name> <email> <unix> <tz>\ncommitter ...\n\n<message>".
+// This must byte-for-byte match what `git cat-file commit <sha>` would print for the same
+// tree/parents/author/message, since that's what a client verifying the signature re-derives.
+func buildCommitPayload(treeSHA string, parentSHAs []string, author, committer CommitAuthor, message string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "tree %s\n", treeSHA)
+	for _, parent := range parentSHAs {
+		fmt.Fprintf(&b, "parent %s\n", parent)
+	}
+	fmt.Fprintf(&b, "author %s <%s> %s\n", author.Name, author.Email, formatGitTimestamp(author.When))
+	fmt.Fprintf(&b, "committer %s <%s> %s\n", committer.Name, committer.Email, formatGitTimestamp(committer.When))
+	b.WriteString("\n")
+	b.WriteString(message)
+	return b.String()
+}
+
+// formatGitTimestamp renders when as Git's native "<unix-seconds> <+/-HHMM>" author/committer
+// timestamp format.
+func formatGitTimestamp(when time.Time) string {
+	return fmt.Sprintf("%d %s", when.Unix(), when.Format("-0700"))
+}
+
+// createSignedCommit builds a new, GPG-signed commit object on owner/repo via the Git Data API --
+// pointing at treeSHA with parentSHAs -- signed by signer, and returns its SHA. It's the building
+// block MergePullRequestWithOptions uses in place of PullRequests.Merge when opts.SignCommits is
+// set, since the Merge endpoint itself has no way to attach a caller-supplied signature.
+func (c *Client) createSignedCommit(owner, repo, treeSHA string, parentSHAs []string, author, committer CommitAuthor, message string, signer Signer) (string, error) {
+	payload := buildCommitPayload(treeSHA, parentSHAs, author, committer, message)
+
+	signature, err := signer.Sign([]byte(payload))
+	if err != nil {
+		return "", fmt.Errorf("error signing commit: %w", err)
+	}
+
+	commit := &github.Commit{
+		Message: &message,
+		Tree:    &github.Tree{SHA: &treeSHA},
+		Author: &github.CommitAuthor{
+			Name:  &author.Name,
+			Email: &author.Email,
+			Date:  &github.Timestamp{Time: author.When},
+		},
+		Committer: &github.CommitAuthor{
+			Name:  &committer.Name,
+			Email: &committer.Email,
+			Date:  &github.Timestamp{Time: committer.When},
+		},
+	}
+	for _, parentSHA := range parentSHAs {
+		parentSHA := parentSHA
+		commit.Parents = append(commit.Parents, &github.Commit{SHA: &parentSHA})
+	}
+
+	createOpts := &github.CreateCommitOptions{
+		Signature: github.String(armorSignature(signature)),
+	}
+	created, resp, err := c.client.Git.CreateCommit(c.ctx, owner, repo, commit, createOpts)
+	if err != nil {
+		return "", fmt.Errorf("error creating signed commit: %w", err)
+	}
+	c.recordRateLimit(resp)
+
+	return created.GetSHA(), nil
+}
+
+// armorSignature wraps a raw detached signature in ASCII-armor if it isn't already, since the Git
+// Data API expects the commit's gpgsig-equivalent signature field in armored form. A Signer
+// backed by a GitHub App's own signing endpoint may already return an armored signature; one
+// backed by an on-disk GPG key or a KMS typically returns a raw binary signature that needs
+// wrapping here.
+func armorSignature(signature []byte) string {
+	text := string(signature)
+	if strings.HasPrefix(text, "-----BEGIN PGP SIGNATURE-----") {
+		return text
+	}
+	encoded := base64.StdEncoding.EncodeToString(signature)
+	checksum := base64.StdEncoding.EncodeToString(crc24(signature))
+	var b strings.Builder
+	b.WriteString("-----BEGIN PGP SIGNATURE-----\n\n")
+	for i := 0; i < len(encoded); i += 64 {
+		end := i + 64
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		b.WriteString(encoded[i:end])
+		b.WriteString("\n")
+	}
+	b.WriteString("=")
+	b.WriteString(checksum)
+	b.WriteString("\n")
+	b.WriteString("-----END PGP SIGNATURE-----\n")
+	return b.String()
+}
+
+// crc24Init and crc24Poly are RFC 4880's fixed parameters for the 24-bit CRC that armored OpenPGP
+// data carries as its "=XXXX" checksum line, immediately before the "-----END ...-----" footer.
+const (
+	crc24Init = 0xB704CE
+	crc24Poly = 0x864CFB
+)
+
+// crc24 computes RFC 4880's 24-bit CRC over data and returns it as 3 big-endian bytes, ready for
+// base64-encoding into an armor checksum line.
+func crc24(data []byte) []byte {
+	crc := uint32(crc24Init)
+	for _, b := range data {
+		crc ^= uint32(b) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= crc24Poly
+			}
+		}
+	}
+	crc &= 0xFFFFFF
+	return []byte{byte(crc >> 16), byte(crc >> 8), byte(crc)}
+}
+
+// mergeWithSignedCommit implements the MergeOptions.SignCommits path of MergePullRequestWithOptions:
+// it builds a new commit directly via the Git Data API -- reusing pr's head tree with the base
+// branch's current commit as its sole parent, which is exactly a squash merge -- signs it with
+// opts.Signer, and advances the base branch's ref to it. Strategies other than squash would need
+// a three-way merge tree GitHub itself would have to compute, which is out of scope here.
+func (c *Client) mergeWithSignedCommit(owner, repo, prNumber string, pr *github.PullRequest, commitTitle string, opts MergeOptions) error {
+	if opts.Signer == nil {
+		return fmt.Errorf("MergeOptions.SignCommits requires a Signer")
+	}
+	if opts.Strategy != "" && opts.Strategy != "squash" {
+		return fmt.Errorf("MergeOptions.SignCommits only supports the %q strategy, got %q", "squash", opts.Strategy)
+	}
+
+	headCommit, resp, err := c.client.Git.GetCommit(c.ctx, owner, repo, pr.GetHead().GetSHA())
+	if err != nil {
+		return fmt.Errorf("error fetching head commit for pull request %s: %w", prNumber, err)
+	}
+	c.recordRateLimit(resp)
+
+	baseSHA := pr.GetBase().GetSHA()
+	now := time.Now()
+	author, committer := opts.Author, opts.Committer
+	if author == (CommitAuthor{}) {
+		author = CommitAuthor{Name: "github-actions[bot]", Email: "github-actions[bot]@users.noreply.github.com", When: now}
+	}
+	if committer == (CommitAuthor{}) {
+		committer = author
+	}
+
+	newSHA, err := c.createSignedCommit(owner, repo, headCommit.GetTree().GetSHA(), []string{baseSHA}, author, committer, commitTitle, opts.Signer)
+	if err != nil {
+		return fmt.Errorf("error creating signed merge commit for pull request %s: %w", prNumber, err)
+	}
+
+	if err := c.updateRef(owner, repo, "heads/"+pr.GetBase().GetRef(), newSHA); err != nil {
+		return fmt.Errorf("error advancing %s to signed merge commit for pull request %s: %w", pr.GetBase().GetRef(), prNumber, err)
+	}
+
+	fmt.Printf("✅ Merged pull request %s with signed commit %s\n", prNumber, newSHA)
+	return nil
+}
+
+// updateRef points ref (e.g. "heads/main") at newSHA via the Git Data API, the final step of
+// landing a signed merge commit once createSignedCommit has produced it.
+func (c *Client) updateRef(owner, repo, ref, newSHA string) error {
+	_, resp, err := c.client.Git.UpdateRef(c.ctx, owner, repo, &github.Reference{
+		Ref:    github.String("refs/" + ref),
+		Object: &github.GitObject{SHA: &newSHA},
+	}, false)
+	if err != nil {
+		return fmt.Errorf("error updating ref %s to %s: %w", ref, newSHA, err)
+	}
+	c.recordRateLimit(resp)
+	return nil
+}
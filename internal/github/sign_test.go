@@ -0,0 +1,82 @@
+package github
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestBuildCommitPayloadMatchesGitCommitObjectFormat covers that the rendered payload follows
+// Git's canonical commit object layout, since a signature only verifies if this exactly matches
+// what a client re-derives from the commit's actual tree/parents/author/message.
+func TestBuildCommitPayloadMatchesGitCommitObjectFormat(t *testing.T) {
+	when := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	author := CommitAuthor{Name: "Ada Lovelace", Email: "ada@example.com", When: when}
+
+	payload := buildCommitPayload("treeSHA", []string{"parentSHA"}, author, author, "Merge pull request #1: Test")
+
+	want := "tree treeSHA\n" +
+		"parent parentSHA\n" +
+		"author Ada Lovelace <ada@example.com> 1767323045 +0000\n" +
+		"committer Ada Lovelace <ada@example.com> 1767323045 +0000\n" +
+		"\n" +
+		"Merge pull request #1: Test"
+	if payload != want {
+		t.Errorf("got:\n%q\nwant:\n%q", payload, want)
+	}
+}
+
+// TestArmorSignatureWrapsRawBytes covers that a raw binary signature is wrapped in ASCII-armor,
+// while an already-armored one (e.g. from a GitHub App's signing endpoint) passes through as-is.
+func TestArmorSignatureWrapsRawBytes(t *testing.T) {
+	armored := armorSignature([]byte{0x01, 0x02, 0x03})
+	if !strings.HasPrefix(armored, "-----BEGIN PGP SIGNATURE-----") || !strings.HasSuffix(strings.TrimSpace(armored), "-----END PGP SIGNATURE-----") {
+		t.Errorf("expected armored signature, got %q", armored)
+	}
+
+	alreadyArmored := "-----BEGIN PGP SIGNATURE-----\n\nabc\n-----END PGP SIGNATURE-----\n"
+	if got := armorSignature([]byte(alreadyArmored)); got != alreadyArmored {
+		t.Errorf("expected already-armored signature to pass through unchanged, got %q", got)
+	}
+}
+
+// TestArmorSignatureIncludesCRC24Checksum covers that the RFC 4880 "=XXXX" checksum line is
+// present right before the END footer -- a strict verifier rejects armored data without one.
+func TestArmorSignatureIncludesCRC24Checksum(t *testing.T) {
+	armored := armorSignature([]byte{0x01, 0x02, 0x03})
+	lines := strings.Split(strings.TrimRight(armored, "\n"), "\n")
+
+	if len(lines) < 2 {
+		t.Fatalf("expected at least a checksum and END line, got %q", armored)
+	}
+	checksumLine := lines[len(lines)-2]
+	if !strings.HasPrefix(checksumLine, "=") {
+		t.Fatalf("expected a '=' checksum line before the END footer, got %q", checksumLine)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(checksumLine, "="))
+	if err != nil {
+		t.Fatalf("checksum line is not valid base64: %v", err)
+	}
+	if len(decoded) != 3 {
+		t.Errorf("expected a 3-byte (24-bit) CRC, got %d bytes", len(decoded))
+	}
+	if got := crc24([]byte{0x01, 0x02, 0x03}); string(decoded) != string(got) {
+		t.Errorf("checksum line decodes to %x, want %x", decoded, got)
+	}
+}
+
+// TestMergePullRequestWithOptionsSignCommitsDryRun covers that a dry run never calls the Git Data
+// API even with SignCommits set.
+func TestMergePullRequestWithOptionsSignCommitsDryRun(t *testing.T) {
+	client := NewClient("test-token", "owner/repo", true)
+
+	err := client.MergePullRequestWithOptions("#1", "Test PR", MergeOptions{
+		Strategy:    "squash",
+		SignCommits: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
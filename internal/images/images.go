@@ -0,0 +1,344 @@
+// Package images implements a small, Hugo-inspired image processing pipeline used when building
+// assignment pull request bodies: Decode a source image into an immutable Resource, then derive
+// new Resources from it with Resize, Fit, and Fingerprint, each returning a fresh Resource rather
+// than mutating the one it was called on. Resources are cached by the source content hash plus
+// the operation applied, so processing the same static asset for multiple assignments -- or
+// across repeated runs of the creator -- never re-encodes bytes it has already produced.
+package images
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/chai2010/webp"
+	"github.com/disintegration/imaging"
+)
+
+// Format identifies one of the raster image formats the pipeline can decode and encode.
+type Format string
+
+// Supported formats. JPEG's extension() is ".jpg" to match the convention already used for
+// static assets rewritten by instructions.Processor.
+const (
+	PNG  Format = "png"
+	JPEG Format = "jpeg"
+	GIF  Format = "gif"
+	WebP Format = "webp"
+)
+
+// ParseFormat converts a format name (case-insensitively, accepting "jpg" as an alias for
+// "jpeg") to a Format, returning an error for anything else.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "png":
+		return PNG, nil
+	case "jpg", "jpeg":
+		return JPEG, nil
+	case "gif":
+		return GIF, nil
+	case "webp":
+		return WebP, nil
+	default:
+		return "", fmt.Errorf("unsupported image format %q", name)
+	}
+}
+
+// extension returns the file extension (including the leading dot) conventionally used for f.
+func (f Format) extension() string {
+	if f == JPEG {
+		return ".jpg"
+	}
+	return "." + string(f)
+}
+
+// Resource is one image artifact in the pipeline -- either the freshly decoded source image or
+// the output of a Resize, Fit, or Fingerprint operation applied to one. Resources are immutable:
+// every operation returns a new Resource and leaves its receiver untouched.
+type Resource struct {
+	Width        int
+	Height       int
+	RelPermalink string // path of this artifact relative to the assignment directory
+
+	format Format
+	bytes  []byte
+	cache  *Cache
+}
+
+// Bytes returns the encoded image data for this Resource, ready to be written to RelPermalink.
+func (r *Resource) Bytes() []byte {
+	return r.bytes
+}
+
+// Cache memoizes Resources by a key derived from the source content hash and the operation
+// applied to it, so decoding and re-deriving the same source image for two assignments that
+// share a static asset only encodes the bytes once.
+type Cache struct {
+	mu    sync.Mutex
+	items map[string]*Resource
+}
+
+// NewCache creates an empty Cache.
+func NewCache() *Cache {
+	return &Cache{items: make(map[string]*Resource)}
+}
+
+// getOrCompute returns the Resource already cached under key, or calls compute and caches its
+// result if this is the first time key has been seen.
+func (c *Cache) getOrCompute(key string, compute func() (*Resource, error)) (*Resource, error) {
+	c.mu.Lock()
+	if r, ok := c.items[key]; ok {
+		c.mu.Unlock()
+		return r, nil
+	}
+	c.mu.Unlock()
+
+	r, err := compute()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.items[key] = r
+	c.mu.Unlock()
+	return r, nil
+}
+
+// Processor decodes and caches Resources for one assignment-processing run, so repeated
+// references to the same source image across a run's README files share one Cache.
+type Processor struct {
+	cache *Cache
+}
+
+// NewProcessor creates a Processor with a fresh, empty Cache.
+func NewProcessor() *Processor {
+	return &Processor{cache: NewCache()}
+}
+
+// Decode reads raw image bytes (PNG, JPEG, GIF, or WebP) and wraps them as the root Resource of
+// a processing pipeline. relPermalink is the artifact's path relative to the assignment
+// directory, unchanged until an operation renames it (see Resource.Fingerprint).
+func (p *Processor) Decode(data []byte, relPermalink string) (*Resource, error) {
+	key := "decode:" + relPermalink + ":" + contentHash(data)
+	return p.cache.getOrCompute(key, func() (*Resource, error) {
+		format, err := sniffFormat(data)
+		if err != nil {
+			return nil, err
+		}
+
+		img, err := decodeImage(data, format)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s image: %w", format, err)
+		}
+
+		bounds := img.Bounds()
+		return &Resource{
+			Width:        bounds.Dx(),
+			Height:       bounds.Dy(),
+			RelPermalink: relPermalink,
+			format:       format,
+			bytes:        data,
+			cache:        p.cache,
+		}, nil
+	})
+}
+
+// Resize scales r to spec, a dimension string of the form "800x" (fixed width, proportional
+// height), "x600" (fixed height, proportional width), or "800x600" (both, ignoring aspect
+// ratio), optionally followed by a space and a target format to convert to at the same time
+// (e.g. "800x webp").
+func (r *Resource) Resize(spec string) (*Resource, error) {
+	width, height, format, err := parseSpec(spec, r.format)
+	if err != nil {
+		return nil, err
+	}
+
+	key := "resize:" + spec + ":" + contentHash(r.bytes)
+	return r.cache.getOrCompute(key, func() (*Resource, error) {
+		img, err := decodeImage(r.bytes, r.format)
+		if err != nil {
+			return nil, err
+		}
+		return r.encodeDerived(imaging.Resize(img, width, height, imaging.Lanczos), format)
+	})
+}
+
+// Fit scales r down to fit within spec's bounding box (e.g. "1200x800"), preserving aspect ratio
+// and never upscaling, optionally converting format the same way Resize does. Unlike Resize,
+// both a width and a height are required since a bounding box needs both.
+func (r *Resource) Fit(spec string) (*Resource, error) {
+	width, height, format, err := parseSpec(spec, r.format)
+	if err != nil {
+		return nil, err
+	}
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("image size spec %q: Fit requires both a width and a height", spec)
+	}
+
+	key := "fit:" + spec + ":" + contentHash(r.bytes)
+	return r.cache.getOrCompute(key, func() (*Resource, error) {
+		img, err := decodeImage(r.bytes, r.format)
+		if err != nil {
+			return nil, err
+		}
+		return r.encodeDerived(imaging.Fit(img, width, height, imaging.Lanczos), format)
+	})
+}
+
+// Fingerprint returns a Resource identical to r except that its RelPermalink has the first 8 hex
+// characters of r's content hash inserted before the file extension (e.g.
+// "static/overview.png" becomes "static/overview.a1b2c3d4.png"), so a changed image gets a new
+// URL and a cache never serves a stale one.
+func (r *Resource) Fingerprint() (*Resource, error) {
+	key := "fingerprint:" + r.RelPermalink + ":" + contentHash(r.bytes)
+	return r.cache.getOrCompute(key, func() (*Resource, error) {
+		sum := contentHash(r.bytes)[:8]
+		ext := path.Ext(r.RelPermalink)
+		base := strings.TrimSuffix(r.RelPermalink, ext)
+
+		return &Resource{
+			Width:        r.Width,
+			Height:       r.Height,
+			RelPermalink: fmt.Sprintf("%s.%s%s", base, sum, ext),
+			format:       r.format,
+			bytes:        r.bytes,
+			cache:        r.cache,
+		}, nil
+	})
+}
+
+// encodeDerived builds the Resource produced by applying a Resize/Fit transform to r: img is the
+// already-transformed pixels, encoded to format and given a RelPermalink whose extension matches.
+func (r *Resource) encodeDerived(img image.Image, format Format) (*Resource, error) {
+	encoded, err := encodeImage(img, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s image: %w", format, err)
+	}
+
+	bounds := img.Bounds()
+	return &Resource{
+		Width:        bounds.Dx(),
+		Height:       bounds.Dy(),
+		RelPermalink: withExtension(r.RelPermalink, format),
+		format:       format,
+		bytes:        encoded,
+		cache:        r.cache,
+	}, nil
+}
+
+// parseSpec parses a Resize/Fit spec (see Resource.Resize) into its width, height, and target
+// format, defaulting the format to fallback when spec doesn't name one.
+func parseSpec(spec string, fallback Format) (width, height int, format Format, err error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return 0, 0, "", fmt.Errorf("empty image size spec")
+	}
+
+	format = fallback
+	if len(fields) > 1 {
+		if format, err = ParseFormat(fields[1]); err != nil {
+			return 0, 0, "", err
+		}
+	}
+
+	dims := fields[0]
+	widthStr, heightStr, ok := strings.Cut(dims, "x")
+	if !ok {
+		return 0, 0, "", fmt.Errorf("invalid image size spec %q: expected WxH, Wx, or xH", dims)
+	}
+
+	if widthStr != "" {
+		if width, err = strconv.Atoi(widthStr); err != nil {
+			return 0, 0, "", fmt.Errorf("invalid width in image size spec %q: %w", dims, err)
+		}
+	}
+	if heightStr != "" {
+		if height, err = strconv.Atoi(heightStr); err != nil {
+			return 0, 0, "", fmt.Errorf("invalid height in image size spec %q: %w", dims, err)
+		}
+	}
+	if width == 0 && height == 0 {
+		return 0, 0, "", fmt.Errorf("image size spec %q must set a width, a height, or both", dims)
+	}
+
+	return width, height, format, nil
+}
+
+// withExtension replaces relPermalink's extension with the one conventionally used for format.
+func withExtension(relPermalink string, format Format) string {
+	ext := path.Ext(relPermalink)
+	return strings.TrimSuffix(relPermalink, ext) + format.extension()
+}
+
+// contentHash returns the hex-encoded SHA-256 digest of data, used both as the cache key
+// component identifying a given set of bytes and as the fingerprint embedded by Fingerprint.
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// sniffFormat identifies format from data's leading magic bytes.
+func sniffFormat(data []byte) (Format, error) {
+	switch {
+	case len(data) >= 8 && bytes.Equal(data[:8], []byte("\x89PNG\r\n\x1a\n")):
+		return PNG, nil
+	case len(data) >= 3 && bytes.Equal(data[:3], []byte{0xFF, 0xD8, 0xFF}):
+		return JPEG, nil
+	case len(data) >= 6 && (bytes.Equal(data[:6], []byte("GIF87a")) || bytes.Equal(data[:6], []byte("GIF89a"))):
+		return GIF, nil
+	case len(data) >= 12 && bytes.Equal(data[:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WEBP")):
+		return WebP, nil
+	default:
+		return "", fmt.Errorf("unrecognized image format")
+	}
+}
+
+// decodeImage decodes data, known to already be in format, into pixels.
+func decodeImage(data []byte, format Format) (image.Image, error) {
+	reader := bytes.NewReader(data)
+	switch format {
+	case PNG:
+		return png.Decode(reader)
+	case JPEG:
+		return jpeg.Decode(reader)
+	case GIF:
+		return gif.Decode(reader)
+	case WebP:
+		return webp.Decode(reader)
+	default:
+		return nil, fmt.Errorf("unsupported image format %q", format)
+	}
+}
+
+// encodeImage encodes img as format.
+func encodeImage(img image.Image, format Format) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+
+	switch format {
+	case PNG:
+		err = png.Encode(&buf, img)
+	case JPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	case GIF:
+		err = gif.Encode(&buf, img, nil)
+	case WebP:
+		err = webp.Encode(&buf, img, &webp.Options{Quality: 80})
+	default:
+		return nil, fmt.Errorf("unsupported image format %q", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
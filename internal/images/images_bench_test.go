@@ -0,0 +1,54 @@
+package images
+
+import "testing"
+
+// BenchmarkDecodeFitFingerprintCached benchmarks the common pipeline -- Decode, Fit,
+// Fingerprint -- repeated for the same source bytes, which should hit Cache after the first
+// iteration instead of re-encoding.
+func BenchmarkDecodeFitFingerprintCached(b *testing.B) {
+	data, err := encodePNG(1600, 900)
+	if err != nil {
+		b.Fatalf("failed to encode test PNG: %v", err)
+	}
+	p := NewProcessor()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		source, err := p.Decode(data, "static/overview.png")
+		if err != nil {
+			b.Fatalf("Decode returned error: %v", err)
+		}
+		fitted, err := source.Fit("800x800")
+		if err != nil {
+			b.Fatalf("Fit returned error: %v", err)
+		}
+		if _, err := fitted.Fingerprint(); err != nil {
+			b.Fatalf("Fingerprint returned error: %v", err)
+		}
+	}
+}
+
+// BenchmarkDecodeFitFingerprintUncached benchmarks the same pipeline with a fresh Processor (and
+// therefore an empty Cache) each iteration, as a baseline for how much the cache saves.
+func BenchmarkDecodeFitFingerprintUncached(b *testing.B) {
+	data, err := encodePNG(1600, 900)
+	if err != nil {
+		b.Fatalf("failed to encode test PNG: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := NewProcessor()
+		source, err := p.Decode(data, "static/overview.png")
+		if err != nil {
+			b.Fatalf("Decode returned error: %v", err)
+		}
+		fitted, err := source.Fit("800x800")
+		if err != nil {
+			b.Fatalf("Fit returned error: %v", err)
+		}
+		if _, err := fitted.Fingerprint(); err != nil {
+			b.Fatalf("Fingerprint returned error: %v", err)
+		}
+	}
+}
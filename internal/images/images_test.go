@@ -0,0 +1,210 @@
+package images
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// encodePNG encodes a solid-color width x height PNG, small enough for fast tests while still
+// giving Resize/Fit something to actually scale.
+func encodePNG(width, height int) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// testPNG is encodePNG for tests, which can fail via t instead of returning an error.
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	data, err := encodePNG(width, height)
+	if err != nil {
+		t.Fatalf("failed to encode test PNG: %v", err)
+	}
+	return data
+}
+
+func TestDecodeReadsWidthAndHeight(t *testing.T) {
+	p := NewProcessor()
+	resource, err := p.Decode(testPNG(t, 400, 200), "static/overview.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	if resource.Width != 400 || resource.Height != 200 {
+		t.Errorf("expected 400x200, got %dx%d", resource.Width, resource.Height)
+	}
+	if resource.RelPermalink != "static/overview.png" {
+		t.Errorf("expected RelPermalink to be unchanged by Decode, got %q", resource.RelPermalink)
+	}
+}
+
+func TestResizeScalesProportionally(t *testing.T) {
+	p := NewProcessor()
+	source, err := p.Decode(testPNG(t, 800, 400), "static/overview.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	resized, err := source.Resize("200x")
+	if err != nil {
+		t.Fatalf("Resize returned error: %v", err)
+	}
+
+	if resized.Width != 200 || resized.Height != 100 {
+		t.Errorf("expected 200x100 (preserving 2:1 aspect ratio), got %dx%d", resized.Width, resized.Height)
+	}
+}
+
+func TestResizeConvertsFormatWhenSpecNamesOne(t *testing.T) {
+	p := NewProcessor()
+	source, err := p.Decode(testPNG(t, 100, 100), "static/overview.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	converted, err := source.Resize("50x webp")
+	if err != nil {
+		t.Fatalf("Resize returned error: %v", err)
+	}
+
+	if !strings.HasSuffix(converted.RelPermalink, ".webp") {
+		t.Errorf("expected RelPermalink to end in .webp, got %q", converted.RelPermalink)
+	}
+}
+
+func TestFitRequiresBothDimensions(t *testing.T) {
+	p := NewProcessor()
+	source, err := p.Decode(testPNG(t, 100, 100), "static/overview.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	if _, err := source.Fit("200x"); err == nil {
+		t.Error("expected Fit to reject a spec missing a height")
+	}
+}
+
+func TestFitNeverUpscalesBeyondBoundingBox(t *testing.T) {
+	p := NewProcessor()
+	source, err := p.Decode(testPNG(t, 1600, 800), "static/overview.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	fitted, err := source.Fit("400x400")
+	if err != nil {
+		t.Fatalf("Fit returned error: %v", err)
+	}
+
+	if fitted.Width > 400 || fitted.Height > 400 {
+		t.Errorf("expected result within the 400x400 box, got %dx%d", fitted.Width, fitted.Height)
+	}
+	if fitted.Width != 400 {
+		t.Errorf("expected the wider dimension to reach the box edge, got width %d", fitted.Width)
+	}
+}
+
+func TestFingerprintInsertsHashBeforeExtension(t *testing.T) {
+	p := NewProcessor()
+	source, err := p.Decode(testPNG(t, 10, 10), "static/overview.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	fingerprinted, err := source.Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint returned error: %v", err)
+	}
+
+	if !strings.HasPrefix(fingerprinted.RelPermalink, "static/overview.") || !strings.HasSuffix(fingerprinted.RelPermalink, ".png") {
+		t.Errorf("expected static/overview.<hash>.png, got %q", fingerprinted.RelPermalink)
+	}
+	if fingerprinted.RelPermalink == source.RelPermalink {
+		t.Error("expected Fingerprint to change RelPermalink")
+	}
+}
+
+func TestFingerprintDifferentContentGivesDifferentHash(t *testing.T) {
+	p := NewProcessor()
+	a, err := p.Decode(testPNG(t, 10, 10), "static/a.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	b, err := p.Decode(testPNG(t, 20, 20), "static/b.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	fingerprintedA, err := a.Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint returned error: %v", err)
+	}
+	fingerprintedB, err := b.Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint returned error: %v", err)
+	}
+
+	if fingerprintedA.RelPermalink == fingerprintedB.RelPermalink {
+		t.Error("expected different source content to produce different fingerprints")
+	}
+}
+
+func TestDeriveIsImmutable(t *testing.T) {
+	p := NewProcessor()
+	source, err := p.Decode(testPNG(t, 800, 400), "static/overview.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	if _, err := source.Resize("200x"); err != nil {
+		t.Fatalf("Resize returned error: %v", err)
+	}
+
+	if source.Width != 800 || source.Height != 400 {
+		t.Errorf("expected source to be unchanged by deriving from it, got %dx%d", source.Width, source.Height)
+	}
+}
+
+func TestDecodeCachesByContentAndPermalink(t *testing.T) {
+	p := NewProcessor()
+	data := testPNG(t, 50, 50)
+
+	first, err := p.Decode(data, "static/overview.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	second, err := p.Decode(data, "static/overview.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	if first != second {
+		t.Error("expected repeated Decode of identical bytes and permalink to return the cached Resource")
+	}
+}
+
+func TestResizeRejectsInvalidSpec(t *testing.T) {
+	p := NewProcessor()
+	source, err := p.Decode(testPNG(t, 10, 10), "static/overview.png")
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	if _, err := source.Resize("not-a-spec"); err == nil {
+		t.Error("expected an error for a spec with no 'x' separator")
+	}
+}
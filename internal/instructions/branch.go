@@ -0,0 +1,83 @@
+package instructions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultBranchCandidates is the ordered list of branch names tried when auto-detecting the
+// repository's default branch
+var DefaultBranchCandidates = []string{"main", "master", "trunk", "develop"}
+
+// BranchResolver resolves a repository's default branch out of an ordered candidate list
+type BranchResolver interface {
+	Resolve(repositoryRoot string, candidates []string) (string, error)
+}
+
+// GitRefBranchResolver resolves the default branch by inspecting the on-disk .git directory:
+// it prefers the currently checked-out branch (via HEAD) when it is one of the candidates, then
+// falls back to the first candidate that exists as a local ref, and finally the first candidate
+// in the list.
+type GitRefBranchResolver struct{}
+
+// Resolve implements BranchResolver
+func (GitRefBranchResolver) Resolve(repositoryRoot string, candidates []string) (string, error) {
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no branch candidates provided")
+	}
+
+	gitDir := filepath.Join(repositoryRoot, ".git")
+
+	if headBranch, ok := readHeadBranch(gitDir); ok {
+		for _, candidate := range candidates {
+			if headBranch == candidate {
+				return headBranch, nil
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if _, err := os.Stat(filepath.Join(gitDir, "refs", "heads", candidate)); err == nil {
+			return candidate, nil
+		}
+	}
+
+	return candidates[0], nil
+}
+
+// readHeadBranch reads the branch name HEAD currently points at, if any
+func readHeadBranch(gitDir string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", false
+	}
+
+	const refPrefix = "ref: refs/heads/"
+	content := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(content, refPrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(content, refPrefix), true
+}
+
+// NewWithAutoDetectedBranch creates a processor whose branch is resolved automatically:
+// override takes precedence if non-empty, otherwise resolver is used against
+// DefaultBranchCandidates, falling back to the first candidate if resolution fails.
+func NewWithAutoDetectedBranch(repositoryRoot, assignmentPath, override string, resolver BranchResolver) *Processor {
+	branch := override
+	if branch == "" {
+		if resolver == nil {
+			resolver = GitRefBranchResolver{}
+		}
+		detected, err := resolver.Resolve(repositoryRoot, DefaultBranchCandidates)
+		if err != nil {
+			detected = DefaultBranchCandidates[0]
+		}
+		branch = detected
+	}
+
+	return NewWithDefaults(branch, assignmentPath)
+}
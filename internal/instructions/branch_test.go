@@ -0,0 +1,46 @@
+package instructions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGitRefBranchResolverDetectsMasterOnlyRepo seeds a repo with only a "master" branch and
+// confirms detection selects it instead of defaulting to "main"
+func TestGitRefBranchResolverDetectsMasterOnlyRepo(t *testing.T) {
+	root := t.TempDir()
+	refsHeadsDir := filepath.Join(root, ".git", "refs", "heads")
+	if err := os.MkdirAll(refsHeadsDir, 0755); err != nil {
+		t.Fatalf("failed to create refs/heads: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, ".git", "HEAD"), []byte("ref: refs/heads/master\n"), 0644); err != nil {
+		t.Fatalf("failed to write HEAD: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(refsHeadsDir, "master"), []byte("deadbeef\n"), 0644); err != nil {
+		t.Fatalf("failed to write master ref: %v", err)
+	}
+
+	branch, err := (GitRefBranchResolver{}).Resolve(root, DefaultBranchCandidates)
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if branch != "master" {
+		t.Errorf("expected branch 'master', got %q", branch)
+	}
+}
+
+// TestGitRefBranchResolverFallsBackToFirstCandidate confirms detection falls back to the first
+// candidate when no .git directory is present at all
+func TestGitRefBranchResolverFallsBackToFirstCandidate(t *testing.T) {
+	root := t.TempDir()
+
+	branch, err := (GitRefBranchResolver{}).Resolve(root, DefaultBranchCandidates)
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if branch != DefaultBranchCandidates[0] {
+		t.Errorf("expected fallback branch %q, got %q", DefaultBranchCandidates[0], branch)
+	}
+}
@@ -5,38 +5,208 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"assignment-pull-request/internal/constants"
+	"assignment-pull-request/internal/images"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
+// ReadmeCandidate describes a single README basename/extension combination to probe for
+type ReadmeCandidate struct {
+	Basename  string
+	Extension string
+}
+
+// markdownExtensions lists extensions for which markdown-specific rewriting (e.g. image links) applies
+var markdownExtensions = map[string]bool{
+	".md":       true,
+	".markdown": true,
+}
+
+// DefaultReadmeCandidates is the priority-ordered list of README files searched for in an assignment
+// directory: Markdown first, then reStructuredText, AsciiDoc, Org, and finally plain text.
+var DefaultReadmeCandidates = []ReadmeCandidate{
+	{"README", ".md"}, {"readme", ".md"},
+	{"README", ".markdown"}, {"readme", ".markdown"},
+	{"README", ".rst"}, {"readme", ".rst"},
+	{"README", ".adoc"}, {"readme", ".adoc"},
+	{"README", ".asciidoc"}, {"readme", ".asciidoc"},
+	{"README", ".org"}, {"readme", ".org"},
+	{"README", ""}, {"readme", ""},
+	{"README", ".txt"}, {"readme", ".txt"},
+}
+
+// readmeDocDirs are subdirectories searched for a README when none is found directly in the assignment path
+var readmeDocDirs = []string{"docs", "doc"}
+
+// defaultSourceBranches are the branch names treated as the "authoring" branch whose absolute
+// links get retargeted to the assignment branch when no explicit source branches are configured
+var defaultSourceBranches = []string{"main", "master"}
+
+// LanguageRoots maps a language code (e.g. "en", "ja") to the content directory root under which
+// that language's assignment folders live -- one root tree per language, each mirroring the same
+// relative assignment paths (e.g. content/en/cs101/hw-1 and content/ja/cs101/hw-1). Default names
+// the language a Processor falls back to when PreferredLanguage isn't set.
+type LanguageRoots struct {
+	Dirs    map[string]string
+	Default string
+}
+
+// NewLanguageRoots validates dirs before returning a ready-to-use LanguageRoots: no two roots may
+// be nested inside one another, since a path under the overlap would be ambiguous about which
+// language it belongs to, and defaultLanguage, if set, must name one of dirs.
+func NewLanguageRoots(dirs map[string]string, defaultLanguage string) (*LanguageRoots, error) {
+	cleaned := make(map[string]string, len(dirs))
+	langs := make([]string, 0, len(dirs))
+	for lang, dir := range dirs {
+		cleaned[lang] = filepath.Clean(dir)
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	for i, a := range langs {
+		for _, b := range langs[i+1:] {
+			if dirsOverlap(cleaned[a], cleaned[b]) {
+				return nil, fmt.Errorf("content directories for languages %q and %q overlap: %q and %q", a, b, cleaned[a], cleaned[b])
+			}
+		}
+	}
+
+	if defaultLanguage != "" {
+		if _, ok := cleaned[defaultLanguage]; !ok {
+			return nil, fmt.Errorf("default content language %q is not one of the configured content languages", defaultLanguage)
+		}
+	}
+
+	return &LanguageRoots{Dirs: cleaned, Default: defaultLanguage}, nil
+}
+
+// dirsOverlap reports whether a and b are the same directory or one is nested inside the other
+func dirsOverlap(a, b string) bool {
+	if relAB, err := filepath.Rel(a, b); err == nil && !strings.HasPrefix(relAB, "..") {
+		return true
+	}
+	if relBA, err := filepath.Rel(b, a); err == nil && !strings.HasPrefix(relBA, "..") {
+		return true
+	}
+	return false
+}
+
+// relativeAssignmentPath reports which configured language root assignmentPath falls under, and
+// assignmentPath's path relative to that root -- the coordinate used to find the same assignment
+// under a different language's root via pathFor.
+func (lr *LanguageRoots) relativeAssignmentPath(assignmentPath string) (relPath string, ok bool) {
+	cleanPath := filepath.Clean(assignmentPath)
+	for _, dir := range lr.Dirs {
+		rel, err := filepath.Rel(dir, cleanPath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			continue
+		}
+		return rel, true
+	}
+	return "", false
+}
+
+// pathFor joins relPath (as returned by relativeAssignmentPath) onto lang's content root,
+// returning false if lang isn't one of lr.Dirs
+func (lr *LanguageRoots) pathFor(lang, relPath string) (string, bool) {
+	dir, ok := lr.Dirs[lang]
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(dir, relPath), true
+}
+
 // Processor handles reading and processing instruction files for a specific assignment
 type Processor struct {
-	branch         string
-	assignmentPath string
+	branch           string
+	assignmentPath   string
+	readmeCandidates []ReadmeCandidate
+	repositoryName   string   // owner/repo slug used to identify same-repository links, e.g. "owner/repo"
+	sourceBranches   []string // branches whose absolute links are retargeted to the assignment branch
+
+	// Languages, if set, lets findReadmeFile look for a translated README: first under a sibling
+	// language's content root (directory placement), then as a language-suffixed filename (e.g.
+	// README.ja.md) in assignmentPath itself -- and lets CreatePullRequestBody emit one section
+	// per available translation when more than one is found.
+	Languages *LanguageRoots
+	// PreferredLanguage, if set, is tried before Languages.Default when resolving a translation.
+	PreferredLanguage string
+
+	// Images, if set, lets rewriteImageLinks run each referenced image (both "![]()" and
+	// "<img src>" syntax) through an image processing pipeline -- fitting it within ImageSpec
+	// and fingerprinting it -- before rewriting its link to the processed artifact. Left unset,
+	// links are rewritten to the original file as before this field existed.
+	Images *images.Processor
+	// ImageSpec is the images.Resource.Fit spec applied to every image before Fingerprint;
+	// defaults to constants.DefaultImageFitSpec when empty.
+	ImageSpec string
+	// ProcessedAssets collects the assignment-relative paths of any new artifacts rewriteImageLinks
+	// wrote to disk via Images, so the caller can stage and commit them alongside the README.
+	ProcessedAssets []string
+
+	// RawBaseURL, if set, makes rewriteImagePath point image links at a rawassets.Handler serving
+	// this origin (e.g. "https://assignments.example.com") instead of the in-tree GitHub blob URL
+	// used by default -- useful when an image lives outside the pull request's own tree. Ignored
+	// when repositoryName isn't set, since the raw URL needs "owner/repo" too.
+	RawBaseURL string
+
+	// Root, if set, is prepended to every path this Processor actually reads or writes on disk
+	// (README lookup, image decode/fingerprint output), while assignmentPath itself stays
+	// repo-relative for link generation. This lets a caller (e.g. creator.Creator's worker pool)
+	// point a Processor at an assignment checked out in an isolated git worktree without the
+	// worktree's directory leaking into the PR body's links.
+	Root string
+}
+
+// diskPath joins path onto Root for an actual filesystem read/write, leaving path itself
+// (used for link generation and ProcessedAssets) repo-relative.
+func (p *Processor) diskPath(path string) string {
+	return filepath.Join(p.Root, path)
 }
 
 // New creates a new instructions processor for the given assignment path
 func New(assignmentPath string) *Processor {
 	return &Processor{
-		branch:         "main", // Default fallback
-		assignmentPath: assignmentPath,
+		branch:           "main", // Default fallback
+		assignmentPath:   assignmentPath,
+		readmeCandidates: DefaultReadmeCandidates,
+		sourceBranches:   defaultSourceBranches,
 	}
 }
 
 // NewWithDefaults creates a new instructions processor with branch and assignment path
 func NewWithDefaults(branch, assignmentPath string) *Processor {
 	return &Processor{
-		branch:         branch,
-		assignmentPath: assignmentPath,
+		branch:           branch,
+		assignmentPath:   assignmentPath,
+		readmeCandidates: DefaultReadmeCandidates,
+		sourceBranches:   defaultSourceBranches,
 	}
 }
 
-// CreatePullRequestBody creates pull request body content from the processor's assignment path
+// NewWithRepository creates a new instructions processor that also rewrites absolute links
+// pointing at the given repository's source branches to the assignment branch
+func NewWithRepository(branch, assignmentPath, repositoryName string) *Processor {
+	p := NewWithDefaults(branch, assignmentPath)
+	p.repositoryName = repositoryName
+	return p
+}
+
+// CreatePullRequestBody creates pull request body content from the processor's assignment path.
+// When Languages is configured and more than one translation is found, the body includes one
+// section per available translation instead of picking a single README.
 func (p *Processor) CreatePullRequestBody() (string, error) {
+	if p.Languages != nil {
+		if translations := p.findTranslatedReadmes(); len(translations) > 1 {
+			return p.createMultiLanguagePullRequestBody(translations), nil
+		}
+	}
+
 	// Try to find README.md in the assignment directory
 	readmePath := p.findReadmeFile()
 
@@ -54,30 +224,128 @@ func (p *Processor) CreatePullRequestBody() (string, error) {
 	return p.createGenericPullRequestBody(), nil
 }
 
-// findReadmeFile looks for README.md in the assignment directory
+// findReadmeFile looks for a README file in the assignment directory, preferring a translation
+// (see languagePreferenceOrder) before trying each configured candidate in priority order. If
+// none is found directly in the assignment path, it also checks the common documentation
+// subdirectories before giving up.
 func (p *Processor) findReadmeFile() string {
-	candidates := []string{
-		filepath.Join(p.assignmentPath, constants.ReadmeFileName),
-		filepath.Join(p.assignmentPath, constants.ReadmeFileNameLowerCase),
+	for _, lang := range p.languagePreferenceOrder() {
+		if readme := p.findTranslatedReadmeIn(p.assignmentPath, lang); readme != "" {
+			return readme
+		}
+	}
+
+	if readme := p.findReadmeIn(p.assignmentPath); readme != "" {
+		return readme
+	}
+
+	for _, docDir := range readmeDocDirs {
+		if readme := p.findReadmeIn(filepath.Join(p.assignmentPath, docDir)); readme != "" {
+			return readme
+		}
+	}
+
+	return ""
+}
+
+// languagePreferenceOrder returns the language codes to try, most specific first:
+// PreferredLanguage, then Languages.Default, with duplicates removed. Empty if Languages is unset.
+func (p *Processor) languagePreferenceOrder() []string {
+	if p.Languages == nil {
+		return nil
+	}
+
+	var order []string
+	seen := make(map[string]bool)
+	for _, lang := range []string{p.PreferredLanguage, p.Languages.Default} {
+		if lang != "" && !seen[lang] {
+			order = append(order, lang)
+			seen[lang] = true
+		}
+	}
+	return order
+}
+
+// findTranslatedReadmeIn looks for lang's README for the assignment at dir, trying (1) directory
+// placement -- the same assignment mirrored under lang's own content root -- before (2) a
+// language-suffixed filename (e.g. README.ja.md) in dir itself, since a dedicated translated
+// directory is a stronger signal than a same-directory suffixed file.
+func (p *Processor) findTranslatedReadmeIn(dir, lang string) string {
+	if relPath, ok := p.Languages.relativeAssignmentPath(dir); ok {
+		if translatedDir, ok := p.Languages.pathFor(lang, relPath); ok {
+			if readme := p.findReadmeIn(translatedDir); readme != "" {
+				return readme
+			}
+		}
+	}
+
+	for _, candidate := range p.readmeCandidates {
+		path := filepath.Join(dir, fmt.Sprintf("%s.%s%s", candidate.Basename, lang, candidate.Extension))
+		if _, err := os.Stat(p.diskPath(path)); err == nil {
+			return path
+		}
+	}
+
+	return ""
+}
+
+// findTranslatedReadmes returns, for each configured language with a README for this assignment
+// (see findTranslatedReadmeIn), the path to that language's README, keyed by language code.
+func (p *Processor) findTranslatedReadmes() map[string]string {
+	readmes := make(map[string]string)
+	for lang := range p.Languages.Dirs {
+		if readme := p.findTranslatedReadmeIn(p.assignmentPath, lang); readme != "" {
+			readmes[lang] = readme
+		}
+	}
+	return readmes
+}
+
+// createMultiLanguagePullRequestBody renders one section per translation in readmes, sorted by
+// language code for deterministic output, skipping any translation that fails to read.
+func (p *Processor) createMultiLanguagePullRequestBody(readmes map[string]string) string {
+	langs := make([]string, 0, len(readmes))
+	for lang := range readmes {
+		langs = append(langs, lang)
 	}
+	sort.Strings(langs)
 
-	for _, candidate := range candidates {
-		if _, err := os.Stat(candidate); err == nil {
-			return candidate
+	var sections strings.Builder
+	for _, lang := range langs {
+		content, err := p.readAndProcessReadme(readmes[lang])
+		if err != nil {
+			fmt.Printf("Warning: failed to read README file '%s': %v\n", readmes[lang], err)
+			continue
 		}
+		fmt.Fprintf(&sections, "<details>\n<summary>%s</summary>\n\n%s\n</details>\n\n", lang, content)
 	}
 
+	return sections.String()
+}
+
+// findReadmeIn searches a single directory for the first matching README candidate
+func (p *Processor) findReadmeIn(dir string) string {
+	for _, candidate := range p.readmeCandidates {
+		path := filepath.Join(dir, candidate.Basename+candidate.Extension)
+		if _, err := os.Stat(p.diskPath(path)); err == nil {
+			return path
+		}
+	}
 	return ""
 }
 
-// readAndProcessReadme reads the README file and processes image links
+// readAndProcessReadme reads the README file (a repo-relative path, see diskPath) and processes
+// image links
 func (p *Processor) readAndProcessReadme(readmePath string) (string, error) {
-	content, err := os.ReadFile(readmePath)
+	content, err := os.ReadFile(p.diskPath(readmePath))
 	if err != nil {
 		return "", fmt.Errorf("failed to read README file: %w", err)
 	}
 
-	processedContent := p.rewriteImageLinks(string(content))
+	processedContent := string(content)
+	if markdownExtensions[strings.ToLower(filepath.Ext(readmePath))] {
+		processedContent = p.rewriteLinks(processedContent)
+	}
 
 	// Wrap the content in a nice pull request format
 	wrappedContent := fmt.Sprintf(`%s
@@ -87,47 +355,198 @@ func (p *Processor) readAndProcessReadme(readmePath string) (string, error) {
 	return wrappedContent, nil
 }
 
-// rewriteImageLinks rewrites relative image links to reference the assignment path
-func (p *Processor) rewriteImageLinks(content string) string {
-	// Regex to match markdown image syntax: ![alt text](relative/path/to/image)
-	// Note: This handles standard paths; escaped parentheses in paths are extremely rare
-	imageRegex := regexp.MustCompile(`!\[([^\]]*)\]\(([^)]+)\)`)
+// rewriteLinks rewrites relative image links to reference the assignment path, and patches
+// absolute GitHub links that point at one of the configured source branches of the same
+// repository so they follow the student into the assignment branch.
+func (p *Processor) rewriteLinks(content string) string {
+	content = p.rewriteImageLinks(content)
+	return p.rewriteCrossBranchLinks(content)
+}
+
+// imageRegex matches markdown image syntax: ![alt text](relative/path/to/image)
+// Note: This handles standard paths; escaped parentheses in paths are extremely rare
+var imageRegex = regexp.MustCompile(`!\[([^\]]*)\]\(([^)]+)\)`)
 
-	return imageRegex.ReplaceAllStringFunc(content, func(match string) string {
+// imgTagRegex matches an HTML <img> tag's src attribute, e.g. <img src="static/workflow.png">
+var imgTagRegex = regexp.MustCompile(`(<img[^>]*\ssrc=")([^"]+)(")`)
+
+// rewriteImageLinks rewrites relative image links -- both markdown "![]()" syntax and HTML
+// "<img src>" tags -- to reference the assignment path, running each through Images first when
+// configured (see rewriteImagePath).
+func (p *Processor) rewriteImageLinks(content string) string {
+	content = imageRegex.ReplaceAllStringFunc(content, func(match string) string {
 		submatches := imageRegex.FindStringSubmatch(match)
 		if len(submatches) != 3 {
 			return match // Return original if parsing fails
 		}
+		return fmt.Sprintf("![%s](%s)", submatches[1], p.rewriteImagePath(submatches[2]))
+	})
+
+	return imgTagRegex.ReplaceAllStringFunc(content, func(match string) string {
+		submatches := imgTagRegex.FindStringSubmatch(match)
+		if len(submatches) != 4 {
+			return match // Return original if parsing fails
+		}
+		return submatches[1] + p.rewriteImagePath(submatches[2]) + submatches[3]
+	})
+}
+
+// rewriteImagePath resolves a single relative image path referenced by a markdown image or
+// <img> tag: running it through Images if configured (see processImage) and rewriting it to a
+// GitHub blob URL scoped to the assignment branch, formatted for use in PR descriptions. Absolute
+// URLs and paths are returned unchanged.
+func (p *Processor) rewriteImagePath(imagePath string) string {
+	// Skip if it's already an absolute URL
+	if strings.HasPrefix(imagePath, "http://") || strings.HasPrefix(imagePath, "https://") {
+		return imagePath
+	}
+
+	// Skip if it's already an absolute path from repo root (Unix-style in markdown)
+	if strings.HasPrefix(imagePath, "/") {
+		return imagePath
+	}
+
+	// Check if it's an absolute path (cross-platform)
+	if filepath.IsAbs(imagePath) {
+		return imagePath
+	}
+
+	relativePath := imagePath
+	if processedPath, ok := p.processImage(imagePath); ok {
+		relativePath = processedPath
+	}
+
+	if p.RawBaseURL != "" && p.repositoryName != "" {
+		return p.buildRawURL(relativePath)
+	}
+
+	// Rewrite relative path for GitHub pull requests and issues
+	// Join the assignment path with the relative image path
+	rewrittenPath := filepath.ToSlash(filepath.Join(p.assignmentPath, relativePath))
+
+	// For pull requests and issues, use blob URL format with ?raw=true
+	// This ensures images display correctly in PR descriptions
+	return fmt.Sprintf("../blob/%s/%s?raw=true", p.branch, rewrittenPath)
+}
+
+// buildRawURL points relativePath (an image path relative to the assignment directory) at a
+// rawassets.Handler serving p.RawBaseURL, following the same
+// /<owner>/<repo>/assignments/raw/<assignment-path>/<file> shape the handler parses.
+func (p *Processor) buildRawURL(relativePath string) string {
+	assetPath := filepath.ToSlash(filepath.Join(p.assignmentPath, relativePath))
+	return fmt.Sprintf("%s/%s/assignments/raw/%s", strings.TrimSuffix(p.RawBaseURL, "/"), p.repositoryName, assetPath)
+}
+
+// processImage runs the image at imagePath (relative to p.assignmentPath) through p.Images --
+// fitting it within p.ImageSpec and fingerprinting it -- writes the result next to the source
+// image, records it in p.ProcessedAssets, and returns the rewritten relative path. It returns
+// ok=false, leaving the original link untouched, when Images isn't configured or the source
+// image can't be read or processed.
+func (p *Processor) processImage(imagePath string) (relPath string, ok bool) {
+	if p.Images == nil {
+		return "", false
+	}
 
-		altText := submatches[1]
-		imagePath := submatches[2]
+	sourcePath := filepath.Join(p.assignmentPath, imagePath)
+	data, err := os.ReadFile(p.diskPath(sourcePath))
+	if err != nil {
+		return "", false
+	}
 
-		// Skip if it's already an absolute URL
-		if strings.HasPrefix(imagePath, "http://") || strings.HasPrefix(imagePath, "https://") {
+	spec := p.ImageSpec
+	if spec == "" {
+		spec = constants.DefaultImageFitSpec
+	}
+
+	source, err := p.Images.Decode(data, filepath.ToSlash(imagePath))
+	if err != nil {
+		fmt.Printf("Warning: failed to decode image '%s': %v\n", sourcePath, err)
+		return "", false
+	}
+
+	fitted, err := source.Fit(spec)
+	if err != nil {
+		fmt.Printf("Warning: failed to fit image '%s' to %q: %v\n", sourcePath, spec, err)
+		return "", false
+	}
+
+	fingerprinted, err := fitted.Fingerprint()
+	if err != nil {
+		fmt.Printf("Warning: failed to fingerprint image '%s': %v\n", sourcePath, err)
+		return "", false
+	}
+
+	outputPath := filepath.Join(p.assignmentPath, filepath.FromSlash(fingerprinted.RelPermalink))
+	diskOutputPath := p.diskPath(outputPath)
+	if err := os.MkdirAll(filepath.Dir(diskOutputPath), 0755); err != nil {
+		fmt.Printf("Warning: failed to create directory for processed image '%s': %v\n", diskOutputPath, err)
+		return "", false
+	}
+	if err := os.WriteFile(diskOutputPath, fingerprinted.Bytes(), 0644); err != nil {
+		fmt.Printf("Warning: failed to write processed image '%s': %v\n", diskOutputPath, err)
+		return "", false
+	}
+
+	// outputPath (repo-relative, not diskOutputPath) is what the caller stages with `git add`.
+	p.ProcessedAssets = append(p.ProcessedAssets, outputPath)
+	return fingerprinted.RelPermalink, true
+}
+
+// crossBranchLinkRegex matches non-image markdown links: [text](https://...)
+var crossBranchLinkRegex = regexp.MustCompile(`(?:[^!]|^)\[[^\]]*\]\((https?://[^)]+)\)`)
+
+// githubRefLinkRegex extracts the owner, repo, ref-type, branch and remaining path from a
+// github.com blob/tree/raw/blame URL, e.g. https://github.com/owner/repo/blob/main/path
+var githubRefLinkRegex = regexp.MustCompile(`^https://github\.com/([^/]+)/([^/]+)/(blob|tree|raw|blame)/([^/]+)(/.*)?$`)
+
+// rewriteCrossBranchLinks retargets absolute GitHub links that point at one of the configured
+// repository's source branches (e.g. "main") so they point at the assignment branch instead.
+// Links to other repositories, other branches, or non-GitHub hosts are left untouched.
+func (p *Processor) rewriteCrossBranchLinks(content string) string {
+	if p.repositoryName == "" {
+		return content
+	}
+
+	owner, repo, ok := strings.Cut(p.repositoryName, "/")
+	if !ok {
+		return content
+	}
+
+	sourceBranches := p.sourceBranches
+	if len(sourceBranches) == 0 {
+		sourceBranches = defaultSourceBranches
+	}
+
+	return crossBranchLinkRegex.ReplaceAllStringFunc(content, func(match string) string {
+		urlSubmatch := crossBranchLinkRegex.FindStringSubmatch(match)
+		if len(urlSubmatch) != 2 {
 			return match
 		}
+		url := urlSubmatch[1]
 
-		// Skip if it's already an absolute path from repo root (Unix-style in markdown)
-		if strings.HasPrefix(imagePath, "/") {
+		refMatch := githubRefLinkRegex.FindStringSubmatch(url)
+		if refMatch == nil {
 			return match
 		}
 
-		// Check if it's an absolute path (cross-platform)
-		if filepath.IsAbs(imagePath) {
+		matchedOwner, matchedRepo, refType, branch, rest := refMatch[1], refMatch[2], refMatch[3], refMatch[4], refMatch[5]
+		if matchedOwner != owner || matchedRepo != repo {
 			return match
 		}
 
-		// Rewrite relative path for GitHub pull requests and issues
-		// Join the assignment path with the relative image path
-		rewrittenPath := filepath.Join(p.assignmentPath, imagePath)
-		// Ensure we use forward slashes for GitHub compatibility
-		rewrittenPath = filepath.ToSlash(rewrittenPath)
-
-		// For pull requests and issues, use blob URL format with ?raw=true
-		// This ensures images display correctly in PR descriptions
-		rewrittenPath = fmt.Sprintf("../blob/%s/%s?raw=true", p.branch, rewrittenPath)
+		isSourceBranch := false
+		for _, sourceBranch := range sourceBranches {
+			if branch == sourceBranch {
+				isSourceBranch = true
+				break
+			}
+		}
+		if !isSourceBranch {
+			return match
+		}
 
-		return fmt.Sprintf("![%s](%s)", altText, rewrittenPath)
+		rewrittenURL := fmt.Sprintf("https://github.com/%s/%s/%s/%s%s", owner, repo, refType, p.branch, rest)
+		return strings.Replace(match, url, rewrittenURL, 1)
 	})
 }
 
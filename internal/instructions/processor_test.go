@@ -0,0 +1,220 @@
+package instructions
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"assignment-pull-request/internal/images"
+)
+
+// TestNewLanguageRootsRejectsOverlap confirms a nested pair of content directories is rejected at
+// construction time rather than producing ambiguous translation lookups later.
+func TestNewLanguageRootsRejectsOverlap(t *testing.T) {
+	_, err := NewLanguageRoots(map[string]string{
+		"en": "content/en",
+		"ja": "content/en/ja",
+	}, "")
+	if err == nil {
+		t.Fatal("expected an error for nested content directories")
+	}
+	if !strings.Contains(err.Error(), "overlap") {
+		t.Errorf("expected error to mention the overlap, got: %v", err)
+	}
+}
+
+// TestNewLanguageRootsRejectsUnknownDefault confirms a defaultContentLanguage that doesn't name
+// one of the configured directories is rejected at construction time.
+func TestNewLanguageRootsRejectsUnknownDefault(t *testing.T) {
+	_, err := NewLanguageRoots(map[string]string{"en": "content/en"}, "ja")
+	if err == nil {
+		t.Fatal("expected an error for an unknown default content language")
+	}
+}
+
+// TestFindReadmeFilePrefersTranslatedDirectoryOverSuffixedFile builds content/en and content/ja
+// roots with a mirrored assignment directory, plus a same-directory README.ja.md suffix, and
+// confirms directory placement (the dedicated translated directory) wins over the filename suffix.
+func TestFindReadmeFilePrefersTranslatedDirectoryOverSuffixedFile(t *testing.T) {
+	root := t.TempDir()
+	enDir := filepath.Join(root, "content", "en", "cs101", "hw-1")
+	jaDir := filepath.Join(root, "content", "ja", "cs101", "hw-1")
+	mustMkdirAll(t, enDir)
+	mustMkdirAll(t, jaDir)
+
+	mustWriteFile(t, filepath.Join(enDir, "README.md"), "# English")
+	mustWriteFile(t, filepath.Join(jaDir, "README.md"), "# Japanese (directory)")
+	mustWriteFile(t, filepath.Join(enDir, "README.ja.md"), "# Japanese (suffix)")
+
+	languages, err := NewLanguageRoots(map[string]string{
+		"en": filepath.Join(root, "content", "en"),
+		"ja": filepath.Join(root, "content", "ja"),
+	}, "en")
+	if err != nil {
+		t.Fatalf("Unexpected error creating LanguageRoots: %v", err)
+	}
+
+	p := NewWithDefaults("main", enDir)
+	p.Languages = languages
+	p.PreferredLanguage = "ja"
+
+	readme := p.findReadmeFile()
+	if readme != filepath.Join(jaDir, "README.md") {
+		t.Errorf("expected the translated directory's README, got %q", readme)
+	}
+}
+
+// TestCreatePullRequestBodyEmitsSectionPerTranslation confirms the PR body contains one
+// language-tagged section per available translation when more than one is found.
+func TestCreatePullRequestBodyEmitsSectionPerTranslation(t *testing.T) {
+	root := t.TempDir()
+	enDir := filepath.Join(root, "content", "en", "cs101", "hw-1")
+	jaDir := filepath.Join(root, "content", "ja", "cs101", "hw-1")
+	mustMkdirAll(t, enDir)
+	mustMkdirAll(t, jaDir)
+
+	mustWriteFile(t, filepath.Join(enDir, "README.md"), "# English instructions")
+	mustWriteFile(t, filepath.Join(jaDir, "README.md"), "# Japanese instructions")
+
+	languages, err := NewLanguageRoots(map[string]string{
+		"en": filepath.Join(root, "content", "en"),
+		"ja": filepath.Join(root, "content", "ja"),
+	}, "en")
+	if err != nil {
+		t.Fatalf("Unexpected error creating LanguageRoots: %v", err)
+	}
+
+	p := NewWithDefaults("main", enDir)
+	p.Languages = languages
+
+	body, err := p.CreatePullRequestBody()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, want := range []string{"<summary>en</summary>", "English instructions", "<summary>ja</summary>", "Japanese instructions"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected body to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+// TestRewriteImageLinksHandlesImgTag confirms an HTML <img src> is rewritten the same way as the
+// markdown image syntax.
+func TestRewriteImageLinksHandlesImgTag(t *testing.T) {
+	p := NewWithDefaults("assignment-1", "cs101/hw-1")
+
+	content := `<img src="static/workflow.png" alt="workflow">`
+	rewritten := p.rewriteImageLinks(content)
+
+	expected := "../blob/assignment-1/cs101/hw-1/static/workflow.png?raw=true"
+	if !strings.Contains(rewritten, expected) {
+		t.Errorf("expected rewritten content to contain %q, got:\n%s", expected, rewritten)
+	}
+}
+
+// TestRewriteImageLinksUsesImagePipelineWhenConfigured confirms that, when Images is set, a
+// referenced image is fit, fingerprinted, written to disk, recorded in ProcessedAssets, and that
+// the rewritten link points at the fingerprinted artifact rather than the original file.
+func TestRewriteImageLinksUsesImagePipelineWhenConfigured(t *testing.T) {
+	root := t.TempDir()
+	assignmentPath := filepath.Join(root, "cs101", "hw-1")
+	mustMkdirAll(t, filepath.Join(assignmentPath, "static"))
+
+	imgData := encodeTestPNG(t, 2000, 1000)
+	if err := os.WriteFile(filepath.Join(assignmentPath, "static", "overview.png"), imgData, 0644); err != nil {
+		t.Fatalf("failed to write test image: %v", err)
+	}
+
+	p := NewWithDefaults("main", assignmentPath)
+	p.Images = images.NewProcessor()
+	p.ImageSpec = "500x500"
+
+	rewritten := p.rewriteImageLinks("![overview](static/overview.png)")
+
+	if strings.Contains(rewritten, "static/overview.png?raw=true") {
+		t.Errorf("expected the link to be rewritten to a processed artifact, got:\n%s", rewritten)
+	}
+	if len(p.ProcessedAssets) != 1 {
+		t.Fatalf("expected exactly one processed asset, got %d: %v", len(p.ProcessedAssets), p.ProcessedAssets)
+	}
+	if _, err := os.Stat(p.ProcessedAssets[0]); err != nil {
+		t.Errorf("expected processed asset to exist on disk at %s: %v", p.ProcessedAssets[0], err)
+	}
+}
+
+// TestRewriteImageLinksUsesRawBaseURLWhenConfigured confirms that, when RawBaseURL and a
+// repository are both set, image links point at the raw-asset endpoint instead of a GitHub blob
+// URL -- mirroring the markdown BenchmarkRewriteImageLinks exercises for the plain in-tree case.
+func TestRewriteImageLinksUsesRawBaseURLWhenConfigured(t *testing.T) {
+	p := NewWithRepository("assignment-1", "test/fixtures/assignments/assignment-complex", "owner/repo")
+	p.RawBaseURL = "https://assignments.example.com/"
+
+	content := `
+Here's an overview diagram:
+![overview](static/overview.png)
+
+And here's the detailed workflow:
+<img src="static/workflow.png" alt="workflow">
+`
+	rewritten := p.rewriteImageLinks(content)
+
+	expected := "https://assignments.example.com/owner/repo/assignments/raw/test/fixtures/assignments/assignment-complex/static/overview.png"
+	if !strings.Contains(rewritten, expected) {
+		t.Errorf("expected rewritten content to contain %q, got:\n%s", expected, rewritten)
+	}
+	if strings.Contains(rewritten, "../blob/") {
+		t.Errorf("expected no blob URLs once RawBaseURL is configured, got:\n%s", rewritten)
+	}
+}
+
+// TestRewriteImageLinksIgnoresRawBaseURLWithoutRepository confirms RawBaseURL is ignored (falling
+// back to the in-tree blob rewrite) when no repository was configured, since the raw URL needs
+// "owner/repo" too.
+func TestRewriteImageLinksIgnoresRawBaseURLWithoutRepository(t *testing.T) {
+	p := NewWithDefaults("assignment-1", "cs101/hw-1")
+	p.RawBaseURL = "https://assignments.example.com"
+
+	rewritten := p.rewriteImageLinks("![overview](static/overview.png)")
+
+	if !strings.Contains(rewritten, "../blob/assignment-1/cs101/hw-1/static/overview.png?raw=true") {
+		t.Errorf("expected the default blob rewrite without a repository, got:\n%s", rewritten)
+	}
+}
+
+// encodeTestPNG encodes a solid-color width x height PNG for use as a source image in tests.
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 10, G: 20, B: 30, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode test PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func mustMkdirAll(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", dir, err)
+	}
+}
+
+func mustWriteFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,129 @@
+// Package rawassets serves assignment static assets over HTTP at
+// /<owner>/<repo>/assignments/raw/<assignment-path>/<file>, mirroring the URL shape of a wiki's
+// /raw/* route, so an image link rewritten by instructions.Processor can point somewhere that
+// actually has the bytes even when the file lives outside the pull request's own tree.
+package rawassets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Backend abstracts the storage a Handler streams assignment assets from. LocalBackend, which
+// serves a plain checkout on disk, is the only implementation in this package.
+type Backend interface {
+	// Open returns the bytes of file, found at assignmentPath relative to the backend's root. It
+	// returns an error satisfying os.IsNotExist when the asset is missing.
+	Open(assignmentPath, file string) ([]byte, error)
+}
+
+// LocalBackend serves assets from a local filesystem root -- the checkout a Creator run already
+// has on disk.
+type LocalBackend struct {
+	Root string
+}
+
+// NewLocalBackend creates a LocalBackend rooted at root.
+func NewLocalBackend(root string) *LocalBackend {
+	return &LocalBackend{Root: root}
+}
+
+// Open implements Backend by reading assignmentPath/file under Root. It returns an
+// os.IsNotExist-satisfying error (rather than reading) if the joined path would escape Root, so a
+// path-traversal attempt reads the same as a missing file instead of reaching outside Root.
+func (b *LocalBackend) Open(assignmentPath, file string) ([]byte, error) {
+	joined := filepath.Join(b.Root, assignmentPath, file)
+	rel, err := filepath.Rel(b.Root, joined)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, os.ErrNotExist
+	}
+	return os.ReadFile(joined)
+}
+
+// Handler serves assignment static assets at
+// /<owner>/<repo>/assignments/raw/<assignment-path>/<file>, streaming bytes from Backend with the
+// right Content-Type and ETag/If-None-Match support so a browser or GitHub's PR viewer can cache
+// them.
+type Handler struct {
+	RepositoryName string // "owner/repo"; requests for any other repository 404
+	Backend        Backend
+}
+
+// NewHandler creates a Handler serving repositoryName's assets from backend.
+func NewHandler(repositoryName string, backend Backend) *Handler {
+	return &Handler{RepositoryName: repositoryName, Backend: backend}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	assignmentPath, file, ok := h.parsePath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := h.Backend.Open(assignmentPath, file)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	etag := fmt.Sprintf(`"%s"`, contentHash(data))
+	if r.Header.Get("If-None-Match") == etag {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(file))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", etag)
+	w.Write(data)
+}
+
+// parsePath extracts assignmentPath and file from a request path shaped
+// /<owner>/<repo>/assignments/raw/<assignment-path>/<file>, where <assignment-path> may itself
+// contain slashes. Since net/http doesn't clean r.URL.Path for a bare handler (only behind
+// http.ServeMux), a ".." segment anywhere in the path is rejected outright rather than relying on
+// LocalBackend.Open's containment check alone.
+func (h *Handler) parsePath(urlPath string) (assignmentPath, file string, ok bool) {
+	owner, repo, found := strings.Cut(h.RepositoryName, "/")
+	if !found {
+		return "", "", false
+	}
+
+	prefix := fmt.Sprintf("/%s/%s/assignments/raw/", owner, repo)
+	rest, found := strings.CutPrefix(urlPath, prefix)
+	if !found || rest == "" {
+		return "", "", false
+	}
+
+	for _, segment := range strings.Split(rest, "/") {
+		if segment == ".." {
+			return "", "", false
+		}
+	}
+
+	idx := strings.LastIndex(rest, "/")
+	if idx < 0 {
+		return "", "", false
+	}
+
+	return rest[:idx], rest[idx+1:], true
+}
+
+// contentHash returns the hex-encoded SHA-256 digest of data, used as the ETag.
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,135 @@
+package rawassets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHandlerServesAssetWithContentTypeAndETag(t *testing.T) {
+	root := t.TempDir()
+	assignmentDir := filepath.Join(root, "cs101", "hw-1")
+	if err := os.MkdirAll(filepath.Join(assignmentDir, "static"), 0755); err != nil {
+		t.Fatalf("failed to create assignment dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(assignmentDir, "static", "overview.png"), []byte("fake-png-bytes"), 0644); err != nil {
+		t.Fatalf("failed to write test asset: %v", err)
+	}
+
+	handler := NewHandler("owner/repo", NewLocalBackend(root))
+
+	req := httptest.NewRequest(http.MethodGet, "/owner/repo/assignments/raw/cs101/hw-1/static/overview.png", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if rec.Body.String() != "fake-png-bytes" {
+		t.Errorf("expected the asset bytes, got %q", rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "image/png" {
+		t.Errorf("expected Content-Type image/png, got %q", ct)
+	}
+	if rec.Header().Get("ETag") == "" {
+		t.Error("expected an ETag header")
+	}
+}
+
+func TestHandlerReturnsNotModifiedForMatchingETag(t *testing.T) {
+	root := t.TempDir()
+	assignmentDir := filepath.Join(root, "cs101", "hw-1", "static")
+	if err := os.MkdirAll(assignmentDir, 0755); err != nil {
+		t.Fatalf("failed to create assignment dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(assignmentDir, "overview.png"), []byte("fake-png-bytes"), 0644); err != nil {
+		t.Fatalf("failed to write test asset: %v", err)
+	}
+
+	handler := NewHandler("owner/repo", NewLocalBackend(root))
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/owner/repo/assignments/raw/cs101/hw-1/static/overview.png", nil))
+	etag := first.Header().Get("ETag")
+
+	req := httptest.NewRequest(http.MethodGet, "/owner/repo/assignments/raw/cs101/hw-1/static/overview.png", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304, got %d", rec.Code)
+	}
+}
+
+func TestHandlerReturnsNotFoundForMissingAsset(t *testing.T) {
+	handler := NewHandler("owner/repo", NewLocalBackend(t.TempDir()))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/owner/repo/assignments/raw/cs101/hw-1/static/missing.png", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestLocalBackendOpenRejectsPathEscapingRoot(t *testing.T) {
+	root := t.TempDir()
+	secretDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(secretDir, "secret.txt"), []byte("top secret"), 0644); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	rel, err := filepath.Rel(root, filepath.Join(secretDir, "secret.txt"))
+	if err != nil {
+		t.Fatalf("failed to compute relative path: %v", err)
+	}
+
+	if _, err := NewLocalBackend(root).Open(rel, ""); !os.IsNotExist(err) {
+		t.Errorf("expected an os.IsNotExist error for a path escaping root, got %v", err)
+	}
+}
+
+func TestHandlerReturnsNotFoundForPathTraversal(t *testing.T) {
+	root := t.TempDir()
+	secretDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(secretDir, "secret.txt"), []byte("top secret"), 0644); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	handler := NewHandler("owner/repo", NewLocalBackend(root))
+
+	rel, err := filepath.Rel(root, filepath.Join(secretDir, "secret.txt"))
+	if err != nil {
+		t.Fatalf("failed to compute relative path: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/owner/repo/assignments/raw/"+filepath.ToSlash(rel), nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a path traversal attempt, got %d", rec.Code)
+	}
+}
+
+func TestHandlerReturnsNotFoundForOtherRepository(t *testing.T) {
+	root := t.TempDir()
+	assignmentDir := filepath.Join(root, "cs101", "hw-1", "static")
+	if err := os.MkdirAll(assignmentDir, 0755); err != nil {
+		t.Fatalf("failed to create assignment dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(assignmentDir, "overview.png"), []byte("fake-png-bytes"), 0644); err != nil {
+		t.Fatalf("failed to write test asset: %v", err)
+	}
+
+	handler := NewHandler("owner/repo", NewLocalBackend(root))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/other/repo/assignments/raw/cs101/hw-1/static/overview.png", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a different repository, got %d", rec.Code)
+	}
+}
@@ -3,6 +3,7 @@ package regex
 import (
 	"fmt"
 	"regexp"
+	"regexp/syntax"
 	"strings"
 )
 
@@ -38,7 +39,6 @@ func NewPatternProcessorWithCommaSeparated(patterns string) *PatternProcessor {
 	return pp
 }
 
-
 // AddPatterns adds string patterns to the processor with automatic deduplication
 func (pp *PatternProcessor) AddPatterns(patterns []string) {
 	for _, pattern := range patterns {
@@ -78,6 +78,38 @@ func (pp *PatternProcessor) addPattern(pattern string) {
 	}
 }
 
+// PatternTemplate pairs a regex/glob pattern with the branch-name template to render when it
+// matches, as parsed from a "pattern => template" config entry by ParseRegexPatterns. Template
+// is empty for a plain pattern with no "=>", meaning the caller should fall back to its own
+// default naming behavior.
+type PatternTemplate struct {
+	Pattern  string
+	Template string
+}
+
+// templateSeparator splits a "pattern => template" config entry. Only the first occurrence
+// counts, so a template is free to contain "=>" itself.
+const templateSeparator = " => "
+
+// ParseRegexPatterns parses a comma-separated config string into pattern/template pairs,
+// splitting on the same escaped-comma syntax as ParseCommaSeparated, then peeling an optional
+// "pattern => template" suffix off each entry.
+func ParseRegexPatterns(patterns string) []PatternTemplate {
+	parts := ParseCommaSeparated(patterns)
+	result := make([]PatternTemplate, 0, len(parts))
+	for _, part := range parts {
+		if pattern, template, ok := strings.Cut(part, templateSeparator); ok {
+			result = append(result, PatternTemplate{
+				Pattern:  strings.TrimSpace(pattern),
+				Template: strings.TrimSpace(template),
+			})
+			continue
+		}
+		result = append(result, PatternTemplate{Pattern: part})
+	}
+	return result
+}
+
 // parseCommaSeparated parses a comma-separated string of regex patterns into a slice
 // Supports escaping commas with \, to allow commas within regex patterns
 func ParseCommaSeparated(patterns string) []string {
@@ -117,3 +149,459 @@ func (pp *PatternProcessor) compilePatterns() error {
 	return nil
 }
 
+// regexTagPrefix and globTagPrefix let callers explicitly tag a pattern as a raw regex or a
+// glob; an untagged pattern is treated as a glob
+const (
+	regexTagPrefix = "regex:"
+	globTagPrefix  = "glob:"
+)
+
+// globTokenPattern matches the tokens a glob pattern is translated from: "**", "*", "?", a
+// "[...]" character class, and a "{name}" named wildcard -- optionally suffixed ":*" or ":**" to
+// pick what the capture itself matches ("{name}" and "{name:*}" are equivalent, both capturing a
+// single path segment; "{name:**}" captures across "/" the way a bare "**" does).
+var globTokenPattern = regexp.MustCompile(`\*\*|\*|\?|\[[^\]]*\]|\{[A-Za-z_][A-Za-z0-9_]*(:\*\*|:\*)?\}`)
+
+// SyntaxRegex and SyntaxGlob are the two pattern syntaxes Processor.SetDefaultSyntax chooses
+// between for an untagged pattern (one without an explicit "regex:"/"glob:" prefix).
+const (
+	SyntaxRegex = "regex"
+	SyntaxGlob  = "glob"
+)
+
+// Processor handles mixed regex/glob pattern parsing, compilation, and automatic deduplication.
+// Patterns may be tagged with a "regex:" or "glob:" prefix to pick how they're interpreted; an
+// untagged pattern is treated as a glob, e.g. "assignments/{week}/{name}".
+type Processor struct {
+	patterns   []string
+	patternSet map[string]bool
+	// templates is index-aligned with patterns: templates[i] is the branch-name template paired
+	// with patterns[i] via "pattern => template" config syntax, or "" if that pattern has none.
+	templates []string
+	// bodyTemplates is index-aligned with patterns: bodyTemplates[i] is the PR-body template
+	// paired with patterns[i] via AddPatternConfigs, or "" if that pattern has none.
+	bodyTemplates []string
+	// defaultTemplate is the branch-name template applied to a pattern that has no per-pattern
+	// template of its own, e.g. from the BRANCH_TEMPLATE env var. Empty means fall back to the
+	// alphabetical named-group auto-join instead.
+	defaultTemplate string
+	// defaultSyntax is how an untagged pattern (no "regex:"/"glob:" prefix) is interpreted, e.g.
+	// from the PATTERN_SYNTAX env var. Empty (the zero value) means SyntaxGlob, preserving every
+	// existing caller's behavior from before SetDefaultSyntax existed.
+	defaultSyntax    string
+	compiledPatterns []*regexp.Regexp
+	// prefixes is index-aligned with patterns: prefixes[i] is the literal leading path segments
+	// of patterns[i], precomputed alongside compiledPatterns for ChildMayMatch pruning
+	prefixes []literalPrefix
+	compiled bool
+}
+
+// New creates a new Processor
+func New() *Processor {
+	return &Processor{
+		patterns:   make([]string, 0),
+		patternSet: make(map[string]bool),
+	}
+}
+
+// NewWithPatterns creates a new Processor with the given patterns
+func NewWithPatterns(patterns []string) *Processor {
+	p := New()
+	p.AddPatterns(patterns)
+	return p
+}
+
+// NewFromCommaSeparated creates a new Processor with comma-separated patterns
+func NewFromCommaSeparated(patterns string) *Processor {
+	p := New()
+	p.AddCommaSeparated(patterns)
+	return p
+}
+
+// AddPatterns adds string patterns to the processor with automatic deduplication
+func (p *Processor) AddPatterns(patterns []string) {
+	for _, pattern := range patterns {
+		p.addPattern(pattern, "", "")
+	}
+}
+
+// AddPatternTemplates adds pattern/template pairs to the processor with automatic deduplication
+// on the pattern text, e.g. the output of ParseRegexPatterns
+func (p *Processor) AddPatternTemplates(patternTemplates []PatternTemplate) {
+	for _, pt := range patternTemplates {
+		p.addPattern(pt.Pattern, pt.Template, "")
+	}
+}
+
+// AddCommaSeparated adds comma-separated patterns to the processor with automatic deduplication,
+// recognizing the "pattern => template" syntax on each entry (see ParseRegexPatterns)
+func (p *Processor) AddCommaSeparated(patterns string) {
+	p.AddPatternTemplates(ParseRegexPatterns(patterns))
+}
+
+// PatternConfig pairs a pattern with both a branch-name template and a PR-body template,
+// for callers (e.g. a structured config file) that configure patterns directly rather than
+// through the "pattern => template" comma-separated string syntax.
+type PatternConfig struct {
+	Pattern        string
+	BranchTemplate string
+	BodyTemplate   string
+}
+
+// AddPatternConfigs adds pattern configs to the processor with automatic deduplication on the
+// pattern text, same as AddPatternTemplates but additionally carrying a per-pattern body template
+func (p *Processor) AddPatternConfigs(configs []PatternConfig) {
+	for _, c := range configs {
+		p.addPattern(c.Pattern, c.BranchTemplate, c.BodyTemplate)
+	}
+}
+
+// addPattern adds a single pattern and its (possibly empty) branch/body templates with automatic
+// deduplication on the pattern text
+func (p *Processor) addPattern(pattern, branchTemplate, bodyTemplate string) {
+	if pattern != "" && !p.patternSet[pattern] {
+		p.patterns = append(p.patterns, pattern)
+		p.templates = append(p.templates, branchTemplate)
+		p.bodyTemplates = append(p.bodyTemplates, bodyTemplate)
+		p.patternSet[pattern] = true
+		p.compiled = false
+	}
+}
+
+// Patterns returns the string patterns (already deduplicated), including any regex:/glob: tags
+func (p *Processor) Patterns() []string {
+	return p.patterns
+}
+
+// Templates returns the branch-name template paired with each pattern at the same index (see
+// Patterns), empty for a pattern added with no "=> template" suffix
+func (p *Processor) Templates() []string {
+	return p.templates
+}
+
+// BodyTemplates returns the PR-body template paired with each pattern at the same index (see
+// Patterns), empty for a pattern added without one via AddPatternConfigs
+func (p *Processor) BodyTemplates() []string {
+	return p.bodyTemplates
+}
+
+// SetDefaultTemplate sets the branch-name template used for a pattern that has no per-pattern
+// template of its own (see Templates), e.g. to apply a single BRANCH_TEMPLATE env var across every
+// pattern that doesn't override it.
+func (p *Processor) SetDefaultTemplate(template string) {
+	p.defaultTemplate = template
+}
+
+// DefaultTemplate returns the branch-name template set via SetDefaultTemplate, or "" if none was set.
+func (p *Processor) DefaultTemplate() string {
+	return p.defaultTemplate
+}
+
+// SetDefaultSyntax sets how an untagged pattern (no "regex:"/"glob:" prefix) is interpreted:
+// SyntaxGlob (the default if never called) or SyntaxRegex. A "regex:"/"glob:" tag on an
+// individual pattern always wins over this default, the same way a per-pattern template wins
+// over SetDefaultTemplate.
+func (p *Processor) SetDefaultSyntax(syntax string) {
+	p.defaultSyntax = syntax
+	p.compiled = false
+}
+
+// DefaultSyntax returns the syntax set via SetDefaultSyntax, or "" (meaning SyntaxGlob) if none
+// was set.
+func (p *Processor) DefaultSyntax() string {
+	return p.defaultSyntax
+}
+
+// Compiled returns the compiled regex patterns, compiling them automatically if needed. Glob
+// patterns are translated to an equivalent named-group regex before compilation.
+func (p *Processor) Compiled() ([]*regexp.Regexp, error) {
+	if !p.compiled {
+		if err := p.compilePatterns(); err != nil {
+			return nil, err
+		}
+		p.compiled = true
+	}
+	return p.compiledPatterns, nil
+}
+
+// compilePatterns compiles all patterns, translating untagged or glob:-tagged patterns from
+// glob syntax into a regex first
+func (p *Processor) compilePatterns() error {
+	compiled := make([]*regexp.Regexp, len(p.patterns))
+	prefixes := make([]literalPrefix, len(p.patterns))
+	for i, pattern := range p.patterns {
+		regexSource, err := toRegexSource(pattern, p.defaultSyntax)
+		if err != nil {
+			return err
+		}
+		compiledPattern, err := regexp.Compile(regexSource)
+		if err != nil {
+			return fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+		}
+		compiled[i] = compiledPattern
+		prefixes[i] = literalPrefixOf(pattern, p.defaultSyntax)
+	}
+	p.compiledPatterns = compiled
+	p.prefixes = prefixes
+	return nil
+}
+
+// ChildMayMatch reports whether any pattern could possibly match a path at or below dir, a
+// slash-separated directory path relative to the walk root. Callers walking the filesystem call
+// this before descending into a directory and prune the entire subtree with filepath.SkipDir
+// when it returns false, avoiding the O(files x patterns) cost of testing every descendant
+// individually (the same optimization restic uses for its scanner). Patterns are compiled
+// automatically if needed; a compile error makes ChildMayMatch conservatively report true so
+// callers fall back to the full-path matching in Compiled() and surface the error there instead.
+func (p *Processor) ChildMayMatch(dir string) bool {
+	if _, err := p.Compiled(); err != nil {
+		return true
+	}
+
+	var dirSegments []string
+	if dir != "" {
+		dirSegments = strings.Split(dir, "/")
+	}
+
+	for _, prefix := range p.prefixes {
+		if prefix.allows(dirSegments) {
+			return true
+		}
+	}
+	return false
+}
+
+// literalPrefix is a pattern's literal leading path segments -- everything before the first
+// regex metacharacter or glob wildcard token -- used by ChildMayMatch to prune a directory
+// without testing it against the full pattern. complete is true when the whole pattern is
+// exactly these literal segments with nothing variable after them, meaning a path deeper than
+// segments can never match.
+type literalPrefix struct {
+	segments []string
+	complete bool
+}
+
+// allows reports whether dirSegments could be, or be an ancestor of, a path this prefix permits:
+// every segment they have in common must match, and a complete prefix additionally rules out any
+// dirSegments longer than itself.
+func (lp literalPrefix) allows(dirSegments []string) bool {
+	limit := len(lp.segments)
+	if len(dirSegments) < limit {
+		limit = len(dirSegments)
+	}
+	for i := 0; i < limit; i++ {
+		if dirSegments[i] != lp.segments[i] {
+			return false
+		}
+	}
+	return !lp.complete || len(dirSegments) <= len(lp.segments)
+}
+
+// literalPrefixOf computes pattern's literal prefix: a "regex:"-tagged pattern (or an untagged
+// one when defaultSyntax is SyntaxRegex) is walked via regexp/syntax, anything else is treated as
+// a glob and split at its first wildcard token.
+func literalPrefixOf(pattern, defaultSyntax string) literalPrefix {
+	if rest, ok := strings.CutPrefix(pattern, regexTagPrefix); ok {
+		return literalPrefixFromRegexSource(rest)
+	}
+	if rest, ok := strings.CutPrefix(pattern, globTagPrefix); ok {
+		return literalPrefixFromGlob(rest)
+	}
+	if defaultSyntax == SyntaxRegex {
+		return literalPrefixFromRegexSource(pattern)
+	}
+	return literalPrefixFromGlob(pattern)
+}
+
+// literalPrefixFromGlob finds the literal text before glob's first wildcard token ("**", "*", "?",
+// a "[...]" class or a "{name}" named wildcard) and splits it into path segments.
+func literalPrefixFromGlob(glob string) literalPrefix {
+	literal, complete := glob, true
+	if loc := globTokenPattern.FindStringIndex(glob); loc != nil {
+		literal, complete = glob[:loc[0]], false
+	}
+	return newLiteralPrefix(literal, complete)
+}
+
+// literalPrefixFromRegexSource walks the parsed AST of source, accumulating leading literal
+// runes until it hits a non-literal, non-anchor operator, and splits the result into path
+// segments. An unparseable source yields an empty prefix, which ChildMayMatch treats as "always
+// allow" so the caller falls back to full matching.
+func literalPrefixFromRegexSource(source string) literalPrefix {
+	parsed, err := syntax.Parse(source, syntax.Perl)
+	if err != nil {
+		return literalPrefix{}
+	}
+
+	var buf []rune
+	complete := appendLiteralPrefix(parsed.Simplify(), &buf)
+	return newLiteralPrefix(string(buf), complete)
+}
+
+// appendLiteralPrefix appends re's leading literal runes to buf and reports whether re (and,
+// recursively, every subexpression after it in an enclosing concatenation) is nothing but
+// literal text and anchors, i.e. whether buf is re's entire match with nothing variable left.
+func appendLiteralPrefix(re *syntax.Regexp, buf *[]rune) bool {
+	switch re.Op {
+	case syntax.OpLiteral:
+		*buf = append(*buf, re.Rune...)
+		return true
+	case syntax.OpBeginLine, syntax.OpBeginText, syntax.OpEndLine, syntax.OpEndText, syntax.OpEmptyMatch:
+		return true
+	case syntax.OpCapture:
+		if len(re.Sub) == 1 {
+			return appendLiteralPrefix(re.Sub[0], buf)
+		}
+		return false
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !appendLiteralPrefix(sub, buf) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// newLiteralPrefix splits literal into path segments. When the prefix isn't complete, its final
+// segment is dropped: the wildcard that cut the literal short may continue that same segment
+// (e.g. "week-" before "*"), so it can't be compared for exact equality, only the segments before
+// it can.
+func newLiteralPrefix(literal string, complete bool) literalPrefix {
+	if literal == "" {
+		return literalPrefix{}
+	}
+
+	segments := strings.Split(literal, "/")
+	if !complete {
+		segments = segments[:len(segments)-1]
+	}
+	return literalPrefix{segments: segments, complete: complete}
+}
+
+// toRegexSource resolves a tagged pattern string to the regex source it should be compiled
+// from: a "regex:" tagged pattern is used as-is, a "glob:" tagged one is translated via
+// globToRegex, and an untagged pattern follows defaultSyntax (SyntaxGlob, the zero value, unless
+// the caller set SyntaxRegex via Processor.SetDefaultSyntax).
+func toRegexSource(pattern, defaultSyntax string) (string, error) {
+	if rest, ok := strings.CutPrefix(pattern, regexTagPrefix); ok {
+		return rest, nil
+	}
+	if rest, ok := strings.CutPrefix(pattern, globTagPrefix); ok {
+		return globToRegex(rest), nil
+	}
+	if defaultSyntax == SyntaxRegex {
+		return pattern, nil
+	}
+	return globToRegex(pattern), nil
+}
+
+// excludeRule is a single compiled exclude pattern plus whether it was negated with a leading
+// "!", as produced by NewExcludeMatcher
+type excludeRule struct {
+	compiled *regexp.Regexp
+	negate   bool
+}
+
+// ExcludeMatcher evaluates a comma-separated list of exclude patterns (regex or glob, tagged the
+// same way as Processor patterns) against a path with gitignore semantics: the last rule that
+// matches wins, so a pattern prefixed with "!" can re-include a path an earlier pattern excluded.
+// A nil *ExcludeMatcher excludes nothing, so callers can leave it unset when no exclude patterns
+// were configured.
+type ExcludeMatcher struct {
+	rules []excludeRule
+}
+
+// NewExcludeMatcher parses and compiles patterns (using the same escaped-comma syntax as
+// ParseCommaSeparated and the same regex:/glob: tagging as Processor) into an ExcludeMatcher. An
+// empty patterns string yields a matcher that excludes nothing.
+func NewExcludeMatcher(patterns string) (*ExcludeMatcher, error) {
+	parts := ParseCommaSeparated(patterns)
+	rules := make([]excludeRule, 0, len(parts))
+	for _, part := range parts {
+		pattern, negate := part, false
+		if rest, ok := strings.CutPrefix(part, "!"); ok {
+			pattern, negate = rest, true
+		}
+
+		regexSource, err := toRegexSource(pattern, "")
+		if err != nil {
+			return nil, err
+		}
+		compiled, err := regexp.Compile(regexSource)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern '%s': %w", part, err)
+		}
+		rules = append(rules, excludeRule{compiled: compiled, negate: negate})
+	}
+
+	return &ExcludeMatcher{rules: rules}, nil
+}
+
+// Excludes reports whether path should be excluded, evaluating rules in order and letting the
+// last match win so a later "!" rule can override an earlier exclude.
+func (m *ExcludeMatcher) Excludes(path string) bool {
+	if m == nil {
+		return false
+	}
+
+	excluded := false
+	for _, rule := range m.rules {
+		if rule.compiled.MatchString(path) {
+			excluded = !rule.negate
+		}
+	}
+	return excluded
+}
+
+// globToRegex translates a glob pattern into an anchored regex source: "**" expands to ".*", "*"
+// to "[^/]*", "?" to "[^/]", a "[...]" character class is carried over almost verbatim (a leading
+// "!" negation is rewritten to the regex "^" convention), and a named wildcard becomes a named
+// capturing group -- "{name}" and "{name:*}" both "(?P<name>[^/]+)", "{name:**}"
+// "(?P<name>.*)". Everything else is treated as a literal and escaped.
+func globToRegex(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	lastEnd := 0
+	for _, loc := range globTokenPattern.FindAllStringIndex(glob, -1) {
+		start, end := loc[0], loc[1]
+		sb.WriteString(regexp.QuoteMeta(glob[lastEnd:start]))
+		sb.WriteString(globTokenToRegex(glob[start:end]))
+		lastEnd = end
+	}
+	sb.WriteString(regexp.QuoteMeta(glob[lastEnd:]))
+
+	sb.WriteString("$")
+	return sb.String()
+}
+
+// globTokenToRegex translates a single token matched by globTokenPattern into its regex
+// equivalent.
+func globTokenToRegex(token string) string {
+	switch {
+	case token == "**":
+		return ".*"
+	case token == "*":
+		return "[^/]*"
+	case token == "?":
+		return "[^/]"
+	case strings.HasPrefix(token, "["):
+		inner := token[1 : len(token)-1]
+		inner = strings.TrimPrefix(inner, "!")
+		if inner != token[1:len(token)-1] {
+			return "[^" + inner + "]"
+		}
+		return "[" + inner + "]"
+	default:
+		name, capture := token[1:len(token)-1], "[^/]+"
+		if rest, ok := strings.CutSuffix(name, ":**"); ok {
+			name, capture = rest, ".*"
+		} else if rest, ok := strings.CutSuffix(name, ":*"); ok {
+			name = rest
+		}
+		return fmt.Sprintf("(?P<%s>%s)", name, capture)
+	}
+}
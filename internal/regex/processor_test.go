@@ -0,0 +1,287 @@
+package regex
+
+import "testing"
+
+// TestExcludeMatcher covers the gitignore-style last-match-wins semantics described on
+// ExcludeMatcher: a later pattern in the list overrides an earlier one, and a "!"-prefixed
+// pattern re-includes a path an earlier pattern excluded.
+func TestExcludeMatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns string
+		path     string
+		excluded bool
+	}{
+		{
+			name:     "no patterns excludes nothing",
+			patterns: "",
+			path:     "assignments/template-1",
+			excluded: false,
+		},
+		{
+			name:     "a matching pattern excludes the path",
+			patterns: "assignments/template-*",
+			path:     "assignments/template-1",
+			excluded: true,
+		},
+		{
+			name:     "a non-matching pattern leaves the path included",
+			patterns: "assignments/template-*",
+			path:     "assignments/hw-1",
+			excluded: false,
+		},
+		{
+			name:     "a later negated pattern re-includes a path excluded earlier",
+			patterns: "assignments/template-*,!assignments/template-keep",
+			path:     "assignments/template-keep",
+			excluded: false,
+		},
+		{
+			name:     "negation only overrides paths it matches",
+			patterns: "assignments/template-*,!assignments/template-keep",
+			path:     "assignments/template-1",
+			excluded: true,
+		},
+		{
+			name:     "last matching pattern wins, even re-excluding after a re-include",
+			patterns: "assignments/template-*,!assignments/template-*,assignments/template-1",
+			path:     "assignments/template-1",
+			excluded: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := NewExcludeMatcher(tt.patterns)
+			if err != nil {
+				t.Fatalf("unexpected error building matcher: %v", err)
+			}
+			if got := matcher.Excludes(tt.path); got != tt.excluded {
+				t.Errorf("Excludes(%q) = %t, want %t", tt.path, got, tt.excluded)
+			}
+		})
+	}
+}
+
+// TestExcludeMatcherNilIsNoOp verifies that a nil *ExcludeMatcher (the zero value for an unset
+// Processor.ExcludePattern/RootExcludePattern field) excludes nothing.
+func TestExcludeMatcherNilIsNoOp(t *testing.T) {
+	var matcher *ExcludeMatcher
+	if matcher.Excludes("anything") {
+		t.Error("nil ExcludeMatcher should exclude nothing")
+	}
+}
+
+// TestNewExcludeMatcherInvalidPattern verifies that an exclude pattern which fails to compile
+// surfaces a helpful error instead of panicking or being silently ignored.
+func TestNewExcludeMatcherInvalidPattern(t *testing.T) {
+	_, err := NewExcludeMatcher("regex:(unterminated")
+	if err == nil {
+		t.Fatal("expected an error for an invalid exclude pattern")
+	}
+}
+
+// TestProcessorChildMayMatch covers the literal-prefix pruning used to skip a directory's whole
+// subtree before testing it against the full pattern, for both glob and "regex:"-tagged
+// patterns.
+func TestProcessorChildMayMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		dir      string
+		mayMatch bool
+	}{
+		{
+			name:     "glob: ancestor of the literal prefix may match",
+			patterns: []string{"assignments/target/{name}"},
+			dir:      "assignments",
+			mayMatch: true,
+		},
+		{
+			name:     "glob: sibling that diverges from the literal prefix cannot match",
+			patterns: []string{"assignments/target/{name}"},
+			dir:      "assignments/bucket-7",
+			mayMatch: false,
+		},
+		{
+			name:     "glob: directory within the literal prefix may match",
+			patterns: []string{"assignments/target/{name}"},
+			dir:      "assignments/target",
+			mayMatch: true,
+		},
+		{
+			name:     "glob: fully literal pattern cannot match anything deeper than itself",
+			patterns: []string{"assignments/readme"},
+			dir:      "assignments/readme/nested",
+			mayMatch: false,
+		},
+		{
+			name:     "regex: literal prefix from the AST prunes a diverging sibling",
+			patterns: []string{`regex:^assignments/(?P<course>cs101)/(?P<name>hw-\d+)$`},
+			dir:      "assignments/cs999",
+			mayMatch: false,
+		},
+		{
+			name:     "regex: literal prefix from the AST allows a matching ancestor",
+			patterns: []string{`regex:^assignments/(?P<course>cs101)/(?P<name>hw-\d+)$`},
+			dir:      "assignments/cs101",
+			mayMatch: true,
+		},
+		{
+			name:     "a leading wildcard leaves no literal prefix to prune against",
+			patterns: []string{"*/target"},
+			dir:      "anything",
+			mayMatch: true,
+		},
+		{
+			name:     "at least one of several patterns allowing the dir is enough",
+			patterns: []string{"assignments/target/{name}", "other/{name}"},
+			dir:      "other",
+			mayMatch: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewWithPatterns(tt.patterns)
+			if got := p.ChildMayMatch(tt.dir); got != tt.mayMatch {
+				t.Errorf("ChildMayMatch(%q) = %t, want %t", tt.dir, got, tt.mayMatch)
+			}
+		})
+	}
+}
+
+// TestProcessorAddPatternConfigs covers pairing a pattern with both a branch-name and a PR-body
+// template via AddPatternConfigs, the structured alternative to the "pattern => template" string
+// syntax used by .assignmentsrc.
+func TestProcessorAddPatternConfigs(t *testing.T) {
+	p := New()
+	p.AddPatternConfigs([]PatternConfig{
+		{Pattern: "assignments/{name}", BranchTemplate: "{{.name}}", BodyTemplate: "See {{.name}} for instructions"},
+		{Pattern: "labs/{name}"},
+	})
+
+	patterns := p.Patterns()
+	if len(patterns) != 2 || patterns[0] != "assignments/{name}" || patterns[1] != "labs/{name}" {
+		t.Fatalf("Expected both patterns in order, got %v", patterns)
+	}
+
+	templates := p.Templates()
+	if templates[0] != "{{.name}}" || templates[1] != "" {
+		t.Errorf("Expected branch templates [%q, \"\"], got %v", "{{.name}}", templates)
+	}
+
+	bodyTemplates := p.BodyTemplates()
+	if bodyTemplates[0] != "See {{.name}} for instructions" || bodyTemplates[1] != "" {
+		t.Errorf("Expected body templates [%q, \"\"], got %v", "See {{.name}} for instructions", bodyTemplates)
+	}
+}
+
+// TestProcessorDefaultTemplate covers SetDefaultTemplate/DefaultTemplate, the BRANCH_TEMPLATE
+// fallback applied to patterns without their own template.
+func TestProcessorDefaultTemplate(t *testing.T) {
+	p := New()
+	if got := p.DefaultTemplate(); got != "" {
+		t.Fatalf("Expected no default template set, got %q", got)
+	}
+
+	p.SetDefaultTemplate("{{.course}}-wk{{.week}}")
+	if got := p.DefaultTemplate(); got != "{{.course}}-wk{{.week}}" {
+		t.Errorf("Expected %q, got %q", "{{.course}}-wk{{.week}}", got)
+	}
+}
+
+// TestGlobTokens covers the glob tokens globToRegex translates: "?", character classes (with
+// glob's "!" negation rewritten to regex's "^"), and the "{name}"/"{name:*}"/"{name:**}" named
+// wildcard variants.
+func TestGlobTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		matches bool
+	}{
+		{
+			name:    "? matches exactly one non-slash character",
+			pattern: "assignments/hw-?",
+			path:    "assignments/hw-1",
+			matches: true,
+		},
+		{
+			name:    "? does not match two characters",
+			pattern: "assignments/hw-?",
+			path:    "assignments/hw-10",
+			matches: false,
+		},
+		{
+			name:    "character class matches an included character",
+			pattern: "assignments/hw-[0-9]",
+			path:    "assignments/hw-5",
+			matches: true,
+		},
+		{
+			name:    "negated character class excludes the listed character",
+			pattern: "assignments/hw-[!0-9]",
+			path:    "assignments/hw-x",
+			matches: true,
+		},
+		{
+			name:    "negated character class rejects a listed character",
+			pattern: "assignments/hw-[!0-9]",
+			path:    "assignments/hw-5",
+			matches: false,
+		},
+		{
+			name:    "{name} is equivalent to {name:*}, one path segment",
+			pattern: "assignments/{name}",
+			path:    "assignments/hw-1/nested",
+			matches: false,
+		},
+		{
+			name:    "{name:**} captures across path segments",
+			pattern: "assignments/{rest:**}",
+			path:    "assignments/hw-1/nested",
+			matches: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewWithPatterns([]string{tt.pattern})
+			compiled, err := p.Compiled()
+			if err != nil {
+				t.Fatalf("unexpected compile error: %v", err)
+			}
+			if got := compiled[0].MatchString(tt.path); got != tt.matches {
+				t.Errorf("pattern %q matching %q = %t, want %t", tt.pattern, tt.path, got, tt.matches)
+			}
+		})
+	}
+}
+
+// TestProcessorDefaultSyntax covers SetDefaultSyntax/DefaultSyntax: an untagged pattern follows
+// PATTERN_SYNTAX (SyntaxRegex or SyntaxGlob), but an explicit "regex:"/"glob:" tag always wins.
+func TestProcessorDefaultSyntax(t *testing.T) {
+	p := New()
+	if got := p.DefaultSyntax(); got != "" {
+		t.Fatalf("Expected no default syntax set, got %q", got)
+	}
+
+	p.SetDefaultSyntax(SyntaxRegex)
+	if got := p.DefaultSyntax(); got != SyntaxRegex {
+		t.Errorf("Expected %q, got %q", SyntaxRegex, got)
+	}
+
+	p.AddPatterns([]string{`^assignments/(?P<name>hw-\d+)$`, "glob:labs/{name}"})
+	compiled, err := p.Compiled()
+	if err != nil {
+		t.Fatalf("unexpected compile error: %v", err)
+	}
+
+	if !compiled[0].MatchString("assignments/hw-1") {
+		t.Error("untagged pattern should have been compiled as a raw regex under SyntaxRegex")
+	}
+	if !compiled[1].MatchString("labs/section-a") {
+		t.Error("glob:-tagged pattern should still be compiled as a glob under SyntaxRegex")
+	}
+}
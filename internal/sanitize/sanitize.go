@@ -0,0 +1,92 @@
+// Package sanitize builds the bluemonday HTML policy the Creator runs every assignment-authored
+// pull request body through before posting it to GitHub: bluemonday's UGC (user-generated
+// content) policy as a base, loosened just enough for the handful of elements assignment READMEs
+// commonly use that UGC doesn't already allow -- GitHub-flavored task-list checkboxes and
+// class-named code/list blocks for syntax highlighting -- plus a caller-configurable allowlist of
+// additional URL schemes.
+package sanitize
+
+import (
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// safeClassPattern restricts the "class" attribute bluemonday allows on code/div/ul/ol/dl to
+// CSS-identifier-like tokens (letters, digits, hyphens, underscores, separated by spaces) -- the
+// same shape GitHub itself generates for syntax-highlighted code blocks and task lists.
+var safeClassPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+(\s[a-zA-Z0-9_-]+)*$`)
+
+// Config customizes the sanitization policy built by NewPolicy beyond bluemonday's UGC defaults.
+type Config struct {
+	// AllowedURLSchemes are additional URL schemes permitted in links and images, beyond the
+	// http/https/mailto bluemonday's UGC policy already allows -- e.g. "xmpp" for a course's chat
+	// invite links. "javascript" is always rejected regardless of this list.
+	AllowedURLSchemes []string
+}
+
+// NewPolicy builds a bluemonday policy for assignment pull request bodies: bluemonday's UGC
+// policy, plus
+//   - "class" on code/div/ul/ol/dl, restricted to safeClassPattern
+//   - "type=checkbox" plus "checked"/"disabled" on input, for GitHub-flavored task lists
+//   - a bare "label" element with no attributes, a task list checkbox's caption
+//   - config.AllowedURLSchemes, for custom link schemes a course might use
+func NewPolicy(config Config) *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+
+	policy.AllowAttrs("class").Matching(safeClassPattern).OnElements("code", "div", "ul", "ol", "dl")
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+	policy.AllowElements("label")
+
+	schemes := make([]string, 0, len(config.AllowedURLSchemes))
+	for _, scheme := range config.AllowedURLSchemes {
+		if strings.EqualFold(scheme, "javascript") {
+			continue // never allowed, regardless of configuration
+		}
+		schemes = append(schemes, scheme)
+	}
+	if len(schemes) > 0 {
+		policy.AllowURLSchemes(schemes...)
+	}
+
+	return policy
+}
+
+// Sanitizer sanitizes assignment-authored HTML (and the HTML embedded in otherwise-Markdown
+// content) before it's posted as a pull request body.
+type Sanitizer struct {
+	policy *bluemonday.Policy
+}
+
+// New creates a Sanitizer from config (see NewPolicy).
+func New(config Config) *Sanitizer {
+	return &Sanitizer{policy: NewPolicy(config)}
+}
+
+// Sanitize runs content through the Sanitizer's policy, stripping any disallowed element,
+// attribute, or URL scheme -- including "javascript:" links, which bluemonday's UGC policy
+// already rejects.
+func (s *Sanitizer) Sanitize(content string) string {
+	return s.policy.Sanitize(content)
+}
+
+// NormalizeLink rewrites link, a link target found in assignment-authored content, against
+// assignmentPath the same way instructions.Processor's image-link rewriting resolves a relative
+// image path against the assignment directory. Absolute URLs (including a rejected
+// "javascript:" scheme, which is left for Sanitize to strip) and root-relative paths are returned
+// unchanged; ok reports whether link was relative and so rewritten.
+func NormalizeLink(link, assignmentPath string) (normalized string, ok bool) {
+	if link == "" || strings.HasPrefix(link, "/") || filepath.IsAbs(link) {
+		return link, false
+	}
+
+	if parsed, err := url.Parse(link); err == nil && parsed.IsAbs() {
+		return link, false
+	}
+
+	return filepath.ToSlash(filepath.Join(assignmentPath, link)), true
+}
@@ -0,0 +1,94 @@
+package sanitize
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeStripsJavascriptLinks(t *testing.T) {
+	s := New(Config{})
+
+	got := s.Sanitize(`<a href="javascript:alert(1)">click me</a>`)
+	if got == `<a href="javascript:alert(1)">click me</a>` {
+		t.Errorf("expected the javascript: link to be stripped, got %q", got)
+	}
+}
+
+func TestSanitizeAllowsTaskListCheckboxes(t *testing.T) {
+	s := New(Config{})
+
+	content := `<input type="checkbox" checked disabled> <label>Done</label>`
+	got := s.Sanitize(content)
+
+	for _, want := range []string{`type="checkbox"`, "checked", "disabled", "<label>Done</label>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected sanitized output to contain %q, got %q", want, got)
+		}
+	}
+}
+
+func TestSanitizeAllowsSafeClassNames(t *testing.T) {
+	s := New(Config{})
+
+	got := s.Sanitize(`<div class="highlight language-go">x</div>`)
+	if !strings.Contains(got, `class="highlight language-go"`) {
+		t.Errorf("expected a safe class name to survive sanitization, got %q", got)
+	}
+}
+
+func TestSanitizeStripsUnsafeClassNames(t *testing.T) {
+	s := New(Config{})
+
+	got := s.Sanitize(`<div class="foo; background:url(javascript:alert(1))">x</div>`)
+	if strings.Contains(got, "background:url") {
+		t.Errorf("expected an unsafe class value to be stripped, got %q", got)
+	}
+}
+
+func TestSanitizeAllowsConfiguredURLScheme(t *testing.T) {
+	s := New(Config{AllowedURLSchemes: []string{"xmpp"}})
+
+	got := s.Sanitize(`<a href="xmpp:course@chat.example.com">chat</a>`)
+	if !strings.Contains(got, `href="xmpp:course@chat.example.com"`) {
+		t.Errorf("expected the configured xmpp scheme to survive sanitization, got %q", got)
+	}
+}
+
+func TestSanitizeConfigCannotAllowJavascriptScheme(t *testing.T) {
+	s := New(Config{AllowedURLSchemes: []string{"javascript"}})
+
+	got := s.Sanitize(`<a href="javascript:alert(1)">click me</a>`)
+	if strings.Contains(got, "javascript:alert") {
+		t.Errorf("expected javascript: to remain rejected even if configured, got %q", got)
+	}
+}
+
+func TestNormalizeLinkRewritesRelativeLink(t *testing.T) {
+	got, ok := NormalizeLink("docs/setup.md", "cs101/hw-1")
+	if !ok {
+		t.Fatal("expected a relative link to be rewritten")
+	}
+	if got != "cs101/hw-1/docs/setup.md" {
+		t.Errorf("expected cs101/hw-1/docs/setup.md, got %q", got)
+	}
+}
+
+func TestNormalizeLinkLeavesAbsoluteURLUnchanged(t *testing.T) {
+	got, ok := NormalizeLink("https://example.com/docs", "cs101/hw-1")
+	if ok {
+		t.Error("expected an absolute URL to be left unchanged")
+	}
+	if got != "https://example.com/docs" {
+		t.Errorf("expected the URL to be returned verbatim, got %q", got)
+	}
+}
+
+func TestNormalizeLinkLeavesRootRelativeLinkUnchanged(t *testing.T) {
+	got, ok := NormalizeLink("/docs/setup.md", "cs101/hw-1")
+	if ok {
+		t.Error("expected a root-relative link to be left unchanged")
+	}
+	if got != "/docs/setup.md" {
+		t.Errorf("expected the link to be returned verbatim, got %q", got)
+	}
+}
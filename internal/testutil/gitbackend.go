@@ -0,0 +1,105 @@
+package testutil
+
+import (
+	"context"
+
+	"assignment-pull-request/internal/git"
+)
+
+// FakeBackend is an in-memory git.Backend for tests that drive higher-level logic (e.g.
+// checkout.Processor) without a real git binary or git.Client. It delegates every method to a
+// FakeClient's per-domain fakes, so the two share one set of recorded Calls and seeded results.
+type FakeBackend struct {
+	*FakeClient
+}
+
+var _ git.Backend = (*FakeBackend)(nil)
+
+// NewFakeBackend creates a FakeBackend backed by a fresh FakeClient
+func NewFakeBackend() *FakeBackend {
+	return &FakeBackend{FakeClient: NewFakeClient()}
+}
+
+func (b *FakeBackend) SwitchToBranch(ctx context.Context, branchName string) error {
+	return b.Branches().SwitchToBranch(ctx, branchName)
+}
+
+func (b *FakeBackend) CreateAndSwitchToBranch(ctx context.Context, branchName string) error {
+	return b.Branches().CreateAndSwitchToBranch(ctx, branchName)
+}
+
+func (b *FakeBackend) AddFile(ctx context.Context, filePath string) error {
+	return b.WorkTree().AddFile(ctx, filePath)
+}
+
+func (b *FakeBackend) Commit(ctx context.Context, message string) error {
+	return b.Commits().Commit(ctx, message)
+}
+
+func (b *FakeBackend) FetchAll(ctx context.Context) error {
+	return b.Remotes().FetchAll(ctx)
+}
+
+func (b *FakeBackend) PushAllBranches(ctx context.Context) error {
+	return b.Remotes().PushAllBranches(ctx)
+}
+
+func (b *FakeBackend) PushBranch(ctx context.Context, branchName string) error {
+	return b.Remotes().PushBranch(ctx, branchName)
+}
+
+func (b *FakeBackend) MergeBranchToMain(ctx context.Context, branchName string) error {
+	return b.Branches().MergeBranchToMain(ctx, branchName)
+}
+
+func (b *FakeBackend) UpdateBranchFromMain(ctx context.Context, branchName string) error {
+	return b.Branches().UpdateBranchFromMain(ctx, branchName)
+}
+
+func (b *FakeBackend) PullMainFromRemote(ctx context.Context) error {
+	return b.Remotes().PullMainFromRemote(ctx)
+}
+
+func (b *FakeBackend) GetLocalBranches(ctx context.Context) (map[string]bool, error) {
+	return b.Branches().GetLocalBranches(ctx)
+}
+
+func (b *FakeBackend) GetRemoteBranches(ctx context.Context, defaultBranch string) (map[string]bool, error) {
+	return b.Remotes().GetRemoteBranches(ctx, defaultBranch)
+}
+
+func (b *FakeBackend) GetCurrentBranch(ctx context.Context) (string, error) {
+	return b.Branches().GetCurrentBranch(ctx)
+}
+
+func (b *FakeBackend) InitSparseCheckout(ctx context.Context) error {
+	return b.Sparse().InitSparseCheckout(ctx)
+}
+
+func (b *FakeBackend) InitSparseCheckoutCone(ctx context.Context) error {
+	return b.Sparse().InitSparseCheckoutCone(ctx)
+}
+
+func (b *FakeBackend) SetSparseCheckoutPaths(ctx context.Context, paths []string) error {
+	return b.Sparse().SetSparseCheckoutPaths(ctx, paths)
+}
+
+func (b *FakeBackend) DisableSparseCheckout(ctx context.Context) error {
+	return b.Sparse().DisableSparseCheckout(ctx)
+}
+
+func (b *FakeBackend) ApplyCheckout(ctx context.Context) error {
+	return b.Sparse().ApplyCheckout(ctx)
+}
+
+func (b *FakeBackend) IsRepository(ctx context.Context) (bool, error) {
+	return b.WorkTree().IsRepository(ctx)
+}
+
+func (b *FakeBackend) GetCommitHash(ctx context.Context) (string, error) {
+	return b.Commits().GetCommitHash(ctx)
+}
+
+func (b *FakeBackend) GetShortCommitHash(ctx context.Context) (string, error) {
+	return b.Commits().GetShortCommitHash(ctx)
+}
@@ -0,0 +1,265 @@
+package testutil
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"assignment-pull-request/internal/git"
+)
+
+// FakeClient is an in-memory git.Client that records every SafeCmd it would have run instead of
+// executing it, so tests can assert on git interactions (and inject canned results/errors)
+// without spinning up a real git binary.
+type FakeClient struct {
+	mu    sync.Mutex
+	Calls []*git.SafeCmd
+
+	// LocalBranches, RemoteBranches and CurrentBranch seed the results returned by
+	// GetLocalBranches, GetRemoteBranches and GetCurrentBranch respectively.
+	LocalBranches   map[string]bool
+	RemoteBranches  map[string]bool
+	CurrentBranch   string
+	CommitHash      string
+	ShortCommitHash string
+	IsRepo          bool
+
+	// Err, when non-nil, is returned by every operation instead of succeeding.
+	Err error
+}
+
+var _ git.Client = (*FakeClient)(nil)
+
+// NewFakeClient creates a FakeClient with empty branch sets, ready to record calls
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		LocalBranches:  make(map[string]bool),
+		RemoteBranches: make(map[string]bool),
+		IsRepo:         true,
+	}
+}
+
+// record appends cmd to Calls under lock, since InWorktree hands this same FakeClient to every
+// concurrent worker
+func (f *FakeClient) record(cmd *git.SafeCmd) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, cmd)
+}
+
+func (f *FakeClient) Branches() git.BranchService   { return fakeBranches{f} }
+func (f *FakeClient) Remotes() git.RemoteService    { return fakeRemotes{f} }
+func (f *FakeClient) Sparse() git.SparseService     { return fakeSparse{f} }
+func (f *FakeClient) Commits() git.CommitService    { return fakeCommits{f} }
+func (f *FakeClient) WorkTree() git.WorkTreeService { return fakeWorkTree{f} }
+func (f *FakeClient) Lfs() git.LFSService           { return fakeLfs{f} }
+
+// InWorktree records nothing and returns f itself (with f.Err): a FakeClient has no real filesystem/worktree
+// state to isolate, so every worker in a test just shares the same recorder.
+func (f *FakeClient) InWorktree(_ string) (git.Client, error) {
+	return f, f.Err
+}
+
+type fakeBranches struct{ f *FakeClient }
+
+func (s fakeBranches) SwitchToBranch(_ context.Context, branchName string) error {
+	s.f.record(git.NewSafeCmd("checkout").WithRevArgs(branchName))
+	return s.f.Err
+}
+
+func (s fakeBranches) CreateAndSwitchToBranch(_ context.Context, branchName string) error {
+	s.f.record(git.NewSafeCmd("checkout").WithFlag("-b", branchName))
+	return s.f.Err
+}
+
+func (s fakeBranches) MergeBranchToMain(_ context.Context, branchName string) error {
+	s.f.record(git.NewSafeCmd("merge").WithFlag("--no-ff", "").WithRevArgs(branchName))
+	return s.f.Err
+}
+
+func (s fakeBranches) UpdateBranchFromMain(_ context.Context, branchName string) error {
+	s.f.record(git.NewSafeCmd("merge").WithFlag("--no-ff", "").WithRevArgs("main"))
+	return s.f.Err
+}
+
+func (s fakeBranches) GetLocalBranches(_ context.Context) (map[string]bool, error) {
+	s.f.record(git.NewSafeCmd("branch"))
+	if s.f.Err != nil {
+		return nil, s.f.Err
+	}
+	return s.f.LocalBranches, nil
+}
+
+func (s fakeBranches) GetCurrentBranch(_ context.Context) (string, error) {
+	s.f.record(git.NewSafeCmd("rev-parse").WithFlag("--abbrev-ref", "").WithRevArgs("HEAD"))
+	if s.f.Err != nil {
+		return "", s.f.Err
+	}
+	return s.f.CurrentBranch, nil
+}
+
+func (s fakeBranches) CreateWorktreeForNewBranch(_ context.Context, dir, branchName, startPoint string) error {
+	s.f.record(git.NewSafeCmd("worktree").WithSubSubCmd("add").WithFlag("-b", branchName).WithRevArgs(dir, startPoint))
+	return s.f.Err
+}
+
+func (s fakeBranches) AddWorktreeForBranch(_ context.Context, dir, branchName string) error {
+	s.f.record(git.NewSafeCmd("worktree").WithSubSubCmd("add").WithRevArgs(dir, branchName))
+	return s.f.Err
+}
+
+func (s fakeBranches) RemoveWorktree(_ context.Context, dir string) error {
+	s.f.record(git.NewSafeCmd("worktree").WithSubSubCmd("remove").WithFlag("--force", "").WithRevArgs(dir))
+	return s.f.Err
+}
+
+func (s fakeBranches) DeleteBranch(_ context.Context, branchName string) error {
+	s.f.record(git.NewSafeCmd("branch").WithFlag("-D", branchName))
+	return s.f.Err
+}
+
+type fakeRemotes struct{ f *FakeClient }
+
+func (s fakeRemotes) FetchAll(_ context.Context) error {
+	s.f.record(git.NewSafeCmd("fetch").WithFlag("--all", ""))
+	return s.f.Err
+}
+
+func (s fakeRemotes) PushAllBranches(_ context.Context) error {
+	s.f.record(git.NewSafeCmd("push").WithFlag("--all", "").WithRevArgs("origin"))
+	return s.f.Err
+}
+
+func (s fakeRemotes) PushBranch(_ context.Context, branchName string) error {
+	s.f.record(git.NewSafeCmd("push").WithRevArgs("origin", branchName))
+	return s.f.Err
+}
+
+func (s fakeRemotes) PushBranches(ctx context.Context, branchNames []string) error {
+	var multiErr git.MultiError
+	for _, branchName := range branchNames {
+		if err := s.PushBranch(ctx, branchName); err != nil {
+			multiErr.Errors = append(multiErr.Errors, err)
+		}
+	}
+	if len(multiErr.Errors) > 0 {
+		return &multiErr
+	}
+	return nil
+}
+
+func (s fakeRemotes) PullMainFromRemote(_ context.Context) error {
+	s.f.record(git.NewSafeCmd("checkout").WithRevArgs("main"))
+	s.f.record(git.NewSafeCmd("pull").WithRevArgs("origin", "main"))
+	return s.f.Err
+}
+
+func (s fakeRemotes) GetRemoteBranches(_ context.Context, _ string) (map[string]bool, error) {
+	s.f.record(git.NewSafeCmd("branch").WithFlag("-r", ""))
+	if s.f.Err != nil {
+		return nil, s.f.Err
+	}
+	return s.f.RemoteBranches, nil
+}
+
+func (s fakeRemotes) DeleteRemoteBranch(_ context.Context, branchName string) error {
+	s.f.record(git.NewSafeCmd("push").WithFlag("--delete", "").WithRevArgs("origin", branchName))
+	return s.f.Err
+}
+
+type fakeSparse struct{ f *FakeClient }
+
+func (s fakeSparse) InitSparseCheckout(_ context.Context) error {
+	s.f.record(git.NewSafeCmd("sparse-checkout").WithSubSubCmd("init"))
+	return s.f.Err
+}
+
+func (s fakeSparse) InitSparseCheckoutCone(_ context.Context) error {
+	s.f.record(git.NewSafeCmd("sparse-checkout").WithSubSubCmd("init").WithFlag("--cone", ""))
+	return s.f.Err
+}
+
+func (s fakeSparse) SetSparseCheckoutPaths(_ context.Context, paths []string) error {
+	s.f.record(git.NewSafeCmd("sparse-checkout").WithSubSubCmd("set").WithArgs(paths...))
+	return s.f.Err
+}
+
+func (s fakeSparse) DisableSparseCheckout(_ context.Context) error {
+	s.f.record(git.NewSafeCmd("sparse-checkout").WithSubSubCmd("disable"))
+	return s.f.Err
+}
+
+func (s fakeSparse) ApplyCheckout(_ context.Context) error {
+	s.f.record(git.NewSafeCmd("read-tree").WithFlag("-m", "").WithFlag("-u", "").WithRevArgs("HEAD"))
+	return s.f.Err
+}
+
+type fakeCommits struct{ f *FakeClient }
+
+func (s fakeCommits) Commit(_ context.Context, message string) error {
+	s.f.record(git.NewSafeCmd("commit").WithFlag("-m", message))
+	return s.f.Err
+}
+
+func (s fakeCommits) GetCommitHash(_ context.Context) (string, error) {
+	s.f.record(git.NewSafeCmd("rev-parse").WithRevArgs("HEAD"))
+	if s.f.Err != nil {
+		return "", s.f.Err
+	}
+	return s.f.CommitHash, nil
+}
+
+func (s fakeCommits) GetShortCommitHash(_ context.Context) (string, error) {
+	s.f.record(git.NewSafeCmd("rev-parse").WithFlag("--short", "").WithRevArgs("HEAD"))
+	if s.f.Err != nil {
+		return "", s.f.Err
+	}
+	return s.f.ShortCommitHash, nil
+}
+
+type fakeWorkTree struct{ f *FakeClient }
+
+func (s fakeWorkTree) AddFile(_ context.Context, filePath string) error {
+	s.f.record(git.NewSafeCmd("add").WithArgs(filePath))
+	return s.f.Err
+}
+
+func (s fakeWorkTree) IsRepository(_ context.Context) (bool, error) {
+	s.f.record(git.NewSafeCmd("rev-parse").WithFlag("--git-dir", ""))
+	if s.f.Err != nil {
+		return false, s.f.Err
+	}
+	return s.f.IsRepo, nil
+}
+
+type fakeLfs struct{ f *FakeClient }
+
+func (s fakeLfs) Install(_ context.Context) error {
+	s.f.record(git.NewSafeCmd("lfs").WithSubSubCmd("install").WithFlag("--local", ""))
+	return s.f.Err
+}
+
+func (s fakeLfs) Track(_ context.Context, patterns []string) error {
+	s.f.record(git.NewSafeCmd("lfs").WithSubSubCmd("track").WithArgs(patterns...))
+	return s.f.Err
+}
+
+func (s fakeLfs) Pull(_ context.Context, includes, excludes []string) error {
+	cmd := git.NewSafeCmd("lfs").WithSubSubCmd("pull")
+	if len(includes) > 0 {
+		cmd = cmd.WithFlag("--include", strings.Join(includes, ","))
+	}
+	if len(excludes) > 0 {
+		cmd = cmd.WithFlag("--exclude", strings.Join(excludes, ","))
+	}
+	s.f.record(cmd)
+	return s.f.Err
+}
+
+func (s fakeLfs) PointerFor(_ context.Context, _ string) (git.LFSPointer, error) {
+	s.f.record(git.NewSafeCmd("lfs").WithSubSubCmd("pointer"))
+	if s.f.Err != nil {
+		return git.LFSPointer{}, s.f.Err
+	}
+	return git.LFSPointer{}, nil
+}
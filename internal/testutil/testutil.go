@@ -2,15 +2,38 @@
 package testutil
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
 )
 
+// updateGolden is registered under -update so `go test ./... -update` regenerates every golden
+// file AssertGoldenFile/AssertGoldenTree compares against, instead of failing on a mismatch.
+var updateGolden = flag.Bool("update", false, "update golden files instead of comparing against them")
+
 // TempWorkspace creates a temporary workspace with a common directory structure for testing
 type TempWorkspace struct {
 	RootDir string
 	t       *testing.T
+
+	// ExtractedPaths records every path written by SetupTarTestFixture/SetupZipFixture, relative
+	// to RootDir, so AssertTreeMatches or a custom cleanup hook can operate over exactly what an
+	// archive fixture contributed.
+	ExtractedPaths []string
 }
 
 // NewTempWorkspace creates a new temporary workspace for testing
@@ -131,6 +154,371 @@ Implement various sorting algorithms.
 	}
 }
 
+// TestEnv bundles a TempWorkspace with an EnvSetup and the working directory change
+// WithTestEnvironment makes on the caller's behalf, so a test can tear all three down through one
+// cleanup function regardless of whether the test returns normally, calls t.FailNow, or panics.
+type TestEnv struct {
+	Workspace *TempWorkspace
+	Env       *EnvSetup
+
+	t            *testing.T
+	originalDir  string
+	cleanupHooks []func()
+	cleanedUp    bool
+}
+
+// WithTestEnvironment creates a TempWorkspace and EnvSetup, changes the working directory to the
+// workspace root, and returns both the resulting TestEnv and a cleanup function that restores the
+// working directory and environment variables and runs any hooks registered via OnCleanup.
+//
+// The cleanup function is also registered with t.Cleanup, so it runs even if the test panics or
+// calls t.FailNow -- unlike the old ChangeToWorkspace/EnvSetup.Restore pattern, where a panicking
+// test skipped both and left os.Chdir state polluted for every later test in the package. Callers
+// don't need to call the returned function themselves; it's returned mainly so a test can trigger
+// cleanup early (e.g. before asserting on state that must be cleaned up first).
+func WithTestEnvironment(t *testing.T) (env *TestEnv, cleanup func()) {
+	t.Helper()
+
+	ws := NewTempWorkspace(t)
+	envSetup := NewEnvSetup()
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	if err := os.Chdir(ws.RootDir); err != nil {
+		t.Fatalf("Failed to change to workspace directory: %v", err)
+	}
+
+	env = &TestEnv{
+		Workspace:   ws,
+		Env:         envSetup,
+		t:           t,
+		originalDir: originalDir,
+	}
+
+	cleanup = func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Logf("Warning: recovered from panic during test environment cleanup: %v", r)
+			}
+		}()
+		env.cleanup()
+	}
+	t.Cleanup(cleanup)
+
+	return env, cleanup
+}
+
+// OnCleanup registers fn to run during teardown, in last-registered-first-run order, before the
+// workspace directory and environment variables are restored.
+func (env *TestEnv) OnCleanup(fn func()) {
+	env.cleanupHooks = append(env.cleanupHooks, fn)
+}
+
+// cleanup runs registered hooks and restores the environment and working directory. It's safe to
+// call more than once: only the first call (whether triggered explicitly or via t.Cleanup) has
+// any effect.
+func (env *TestEnv) cleanup() {
+	if env.cleanedUp {
+		return
+	}
+	env.cleanedUp = true
+
+	for i := len(env.cleanupHooks) - 1; i >= 0; i-- {
+		env.cleanupHooks[i]()
+	}
+
+	env.Env.Restore()
+
+	if err := os.Chdir(env.originalDir); err != nil {
+		env.t.Logf("Warning: failed to restore original directory: %v", err)
+	}
+}
+
+// File describes a single entry in a CreateFromSpec tree when a plain string or []byte isn't
+// enough to say what's wanted. Use it in place of those when a file needs a specific permission
+// mode or should be a symlink rather than a regular file.
+type File struct {
+	Mode    os.FileMode // permission bits for a regular file; defaults to 0644 if zero
+	Content string      // file contents; ignored when Symlink is set
+	Symlink string      // if set, Content and Mode are ignored and a symlink to this target is created instead
+}
+
+// CreateFromSpec materializes a whole directory tree under the workspace root from a nested Go
+// map, mirroring restic's archiver createFilesAt fixture DSL. Each key is a file or directory
+// name; each value is one of:
+//   - string or []byte: the contents of a regular file
+//   - File: a regular file or symlink with explicit mode/target
+//   - map[string]interface{}: a subdirectory, recursed into
+//
+// This lets a test describe a complex assignment layout (multi-week courses, nested static/
+// folders, per-file modes) as one literal instead of a sequence of CreateAssignment calls.
+func (tw *TempWorkspace) CreateFromSpec(spec map[string]interface{}) {
+	tw.t.Helper()
+	tw.createFromSpecAt(tw.RootDir, spec)
+}
+
+// CreateFromFile loads a spec from a YAML (.yml/.yaml) or JSON (.json) file at path and passes it
+// to CreateFromSpec.
+func (tw *TempWorkspace) CreateFromFile(path string) {
+	tw.t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		tw.t.Fatalf("Failed to read spec file %s: %v", path, err)
+	}
+
+	var spec map[string]interface{}
+	switch ext := filepath.Ext(path); ext {
+	case ".yml", ".yaml":
+		if err := yaml.Unmarshal(data, &spec); err != nil {
+			tw.t.Fatalf("Failed to parse YAML spec file %s: %v", path, err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &spec); err != nil {
+			tw.t.Fatalf("Failed to parse JSON spec file %s: %v", path, err)
+		}
+	default:
+		tw.t.Fatalf("Unsupported spec file extension %q for %s", ext, path)
+	}
+
+	tw.CreateFromSpec(spec)
+}
+
+// SetupTarTestFixture extracts the tar archive at archivePath into RootDir, preserving directory
+// structure, regular file modes, and symlinks, and appends every extracted path (relative to
+// RootDir) to ExtractedPaths. The archive may be gzip- or bzip2-compressed or uncompressed;
+// compression is detected from the file's magic bytes rather than its extension, so a misnamed
+// *.tar.gz still extracts correctly.
+//
+// This is the same ergonomic win restic's archiver tests get from SetupTarTestFixture: a
+// multi-megabyte real-world assignment export can be checked into testdata/ and loaded in one
+// line, instead of being reconstructed procedurally via CreateAssignmentWithImages.
+func (tw *TempWorkspace) SetupTarTestFixture(archivePath string) {
+	tw.t.Helper()
+
+	data, err := os.ReadFile(archivePath)
+	if err != nil {
+		tw.t.Fatalf("Failed to read tar fixture %s: %v", archivePath, err)
+	}
+
+	reader, err := decompressReader(data)
+	if err != nil {
+		tw.t.Fatalf("Failed to open tar fixture %s: %v", archivePath, err)
+	}
+
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			tw.t.Fatalf("Failed to read tar entry in %s: %v", archivePath, err)
+		}
+
+		path := filepath.Join(tw.RootDir, header.Name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, os.FileMode(header.Mode)); err != nil {
+				tw.t.Fatalf("Failed to create directory %s: %v", path, err)
+			}
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				tw.t.Fatalf("Failed to create parent directory for %s: %v", path, err)
+			}
+			if err := os.Symlink(header.Linkname, path); err != nil {
+				tw.t.Fatalf("Failed to create symlink %s -> %s: %v", path, header.Linkname, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				tw.t.Fatalf("Failed to create parent directory for %s: %v", path, err)
+			}
+			content, err := io.ReadAll(tr)
+			if err != nil {
+				tw.t.Fatalf("Failed to read tar entry %s: %v", header.Name, err)
+			}
+			if err := os.WriteFile(path, content, os.FileMode(header.Mode)); err != nil {
+				tw.t.Fatalf("Failed to write file %s: %v", path, err)
+			}
+		default:
+			continue
+		}
+
+		tw.ExtractedPaths = append(tw.ExtractedPaths, header.Name)
+	}
+}
+
+// SetupZipFixture extracts the zip archive at archivePath into RootDir, preserving directory
+// structure and regular file modes, and appends every extracted path (relative to RootDir) to
+// ExtractedPaths. The zip format has no first-class symlink entry type, so symlinks stored the way
+// Info-ZIP does (mode bit set, target as the entry's content) are restored as symlinks too.
+func (tw *TempWorkspace) SetupZipFixture(archivePath string) {
+	tw.t.Helper()
+
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		tw.t.Fatalf("Failed to open zip fixture %s: %v", archivePath, err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		path := filepath.Join(tw.RootDir, f.Name)
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, f.Mode()); err != nil {
+				tw.t.Fatalf("Failed to create directory %s: %v", path, err)
+			}
+			tw.ExtractedPaths = append(tw.ExtractedPaths, f.Name)
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			tw.t.Fatalf("Failed to open zip entry %s: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			tw.t.Fatalf("Failed to read zip entry %s: %v", f.Name, err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			tw.t.Fatalf("Failed to create parent directory for %s: %v", path, err)
+		}
+
+		if f.Mode()&os.ModeSymlink != 0 {
+			if err := os.Symlink(string(content), path); err != nil {
+				tw.t.Fatalf("Failed to create symlink %s -> %s: %v", path, string(content), err)
+			}
+		} else if err := os.WriteFile(path, content, f.Mode()); err != nil {
+			tw.t.Fatalf("Failed to write file %s: %v", path, err)
+		}
+
+		tw.ExtractedPaths = append(tw.ExtractedPaths, f.Name)
+	}
+}
+
+// decompressReader wraps data in a gzip or bzip2 reader based on its magic bytes, or returns it
+// unwrapped if it looks like a plain (uncompressed) tar stream.
+func decompressReader(data []byte) (io.Reader, error) {
+	switch {
+	case len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b:
+		return gzip.NewReader(bytes.NewReader(data))
+	case len(data) >= 3 && data[0] == 'B' && data[1] == 'Z' && data[2] == 'h':
+		return bzip2.NewReader(bytes.NewReader(data)), nil
+	default:
+		return bytes.NewReader(data), nil
+	}
+}
+
+// createFromSpecAt recurses into spec, creating its entries under dir.
+func (tw *TempWorkspace) createFromSpecAt(dir string, spec map[string]interface{}) {
+	tw.t.Helper()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		tw.t.Fatalf("Failed to create directory %s: %v", dir, err)
+	}
+
+	for name, value := range spec {
+		path := filepath.Join(dir, name)
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			tw.createFromSpecAt(path, v)
+		case string:
+			tw.writeSpecFile(path, []byte(v), 0644)
+		case []byte:
+			tw.writeSpecFile(path, v, 0644)
+		case File:
+			tw.writeSpecEntry(path, v)
+		default:
+			tw.t.Fatalf("Unsupported spec value %T for %s", value, path)
+		}
+	}
+}
+
+// writeSpecEntry creates a single File entry, either a symlink or a regular file.
+func (tw *TempWorkspace) writeSpecEntry(path string, f File) {
+	tw.t.Helper()
+
+	if f.Symlink != "" {
+		if err := os.Symlink(f.Symlink, path); err != nil {
+			tw.t.Fatalf("Failed to create symlink %s -> %s: %v", path, f.Symlink, err)
+		}
+		return
+	}
+
+	mode := f.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+	tw.writeSpecFile(path, []byte(f.Content), mode)
+}
+
+// writeSpecFile writes content to path with the given mode, creating any parent directories.
+func (tw *TempWorkspace) writeSpecFile(path string, content []byte, mode os.FileMode) {
+	tw.t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		tw.t.Fatalf("Failed to create parent directory for %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, content, mode); err != nil {
+		tw.t.Fatalf("Failed to write file %s: %v", path, err)
+	}
+}
+
+// AssertTreeMatches checks that the workspace's directory tree under relPath matches spec (in the
+// same shape CreateFromSpec accepts), failing the test with every mismatch found rather than
+// stopping at the first one. Symlinks are compared by target, regular files by content; File.Mode
+// is not compared, since permission bits aren't preserved reliably across platforms.
+func (tw *TempWorkspace) AssertTreeMatches(relPath string, spec map[string]interface{}) {
+	tw.t.Helper()
+	tw.assertTreeMatchesAt(filepath.Join(tw.RootDir, relPath), spec)
+}
+
+func (tw *TempWorkspace) assertTreeMatchesAt(dir string, spec map[string]interface{}) {
+	tw.t.Helper()
+
+	for name, value := range spec {
+		path := filepath.Join(dir, name)
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			AssertDirExists(tw.t, path)
+			tw.assertTreeMatchesAt(path, v)
+		case string:
+			tw.assertFileContentEquals(path, v)
+		case []byte:
+			tw.assertFileContentEquals(path, string(v))
+		case File:
+			if v.Symlink != "" {
+				target, err := os.Readlink(path)
+				if err != nil {
+					tw.t.Errorf("Expected %s to be a symlink: %v", path, err)
+					continue
+				}
+				if target != v.Symlink {
+					tw.t.Errorf("Expected %s to link to %s, got %s", path, v.Symlink, target)
+				}
+				continue
+			}
+			tw.assertFileContentEquals(path, v.Content)
+		default:
+			tw.t.Errorf("Unsupported spec value %T for %s", value, path)
+		}
+	}
+}
+
+func (tw *TempWorkspace) assertFileContentEquals(path, expected string) {
+	tw.t.Helper()
+	AssertFileExists(tw.t, path)
+	if got := ReadFileContent(tw.t, path); got != expected {
+		tw.t.Errorf("Expected %s to contain %q, got %q", path, expected, got)
+	}
+}
+
 // ChangeToWorkspace changes the current directory to the workspace root
 func (tw *TempWorkspace) ChangeToWorkspace() (restore func()) {
 	originalDir, err := os.Getwd()
@@ -258,8 +646,17 @@ Implement the solution following the workflow diagram.
 `,
 }
 
+// TestingT is the subset of *testing.T the assertion helpers need. It exists so MockT can stand
+// in for a real *testing.T, letting the helpers themselves be tested against a missing path or a
+// non-matching substring without aborting the outer go test run.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
 // AssertFileExists checks if a file exists and fails the test if it doesn't
-func AssertFileExists(t *testing.T, filePath string) {
+func AssertFileExists(t TestingT, filePath string) {
 	t.Helper()
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		t.Errorf("Expected file %s to exist, but it doesn't", filePath)
@@ -267,7 +664,7 @@ func AssertFileExists(t *testing.T, filePath string) {
 }
 
 // AssertDirExists checks if a directory exists and fails the test if it doesn't
-func AssertDirExists(t *testing.T, dirPath string) {
+func AssertDirExists(t TestingT, dirPath string) {
 	t.Helper()
 	if stat, err := os.Stat(dirPath); os.IsNotExist(err) || !stat.IsDir() {
 		t.Errorf("Expected directory %s to exist, but it doesn't", dirPath)
@@ -275,7 +672,7 @@ func AssertDirExists(t *testing.T, dirPath string) {
 }
 
 // ReadFileContent reads file content and returns it, failing the test if it can't be read
-func ReadFileContent(t *testing.T, filePath string) string {
+func ReadFileContent(t TestingT, filePath string) string {
 	t.Helper()
 	content, err := os.ReadFile(filePath)
 	if err != nil {
@@ -285,7 +682,7 @@ func ReadFileContent(t *testing.T, filePath string) string {
 }
 
 // ContainsString checks if content contains the expected string
-func ContainsString(t *testing.T, content, expected, context string) {
+func ContainsString(t TestingT, content, expected, context string) {
 	t.Helper()
 	if content == "" {
 		t.Errorf("Content is empty in context: %s", context)
@@ -300,6 +697,55 @@ func ContainsString(t *testing.T, content, expected, context string) {
 	}
 }
 
+// MockT embeds a real *testing.T for Helper() and other bookkeeping, but intercepts Fail, Error,
+// Errorf, Fatal, and Fatalf so a test can drive an assertion helper's failure path and inspect the
+// result instead of aborting the outer go test run. Unlike the real *testing.T, Fatal/Fatalf here
+// do not call runtime.Goexit -- they record the failure and return, which is what lets the calling
+// test keep running afterward to make assertions about HasFailed and Messages.
+type MockT struct {
+	*testing.T
+
+	HasFailed bool
+	Messages  []string
+}
+
+// NewMockT creates a MockT wrapping t, used only for Helper() forwarding.
+func NewMockT(t *testing.T) *MockT {
+	t.Helper()
+	return &MockT{T: t}
+}
+
+// Fail marks the MockT as failed, mirroring testing.T.Fail.
+func (m *MockT) Fail() {
+	m.HasFailed = true
+}
+
+// Error marks the MockT as failed and records the formatted message, mirroring testing.T.Error.
+func (m *MockT) Error(args ...interface{}) {
+	m.HasFailed = true
+	m.Messages = append(m.Messages, fmt.Sprint(args...))
+}
+
+// Errorf marks the MockT as failed and records the formatted message, mirroring testing.T.Errorf.
+func (m *MockT) Errorf(format string, args ...interface{}) {
+	m.HasFailed = true
+	m.Messages = append(m.Messages, fmt.Sprintf(format, args...))
+}
+
+// Fatal marks the MockT as failed and records the message, mirroring testing.T.Fatal except that
+// it does not stop the calling goroutine.
+func (m *MockT) Fatal(args ...interface{}) {
+	m.HasFailed = true
+	m.Messages = append(m.Messages, fmt.Sprint(args...))
+}
+
+// Fatalf marks the MockT as failed and records the formatted message, mirroring testing.T.Fatalf
+// except that it does not stop the calling goroutine.
+func (m *MockT) Fatalf(format string, args ...interface{}) {
+	m.HasFailed = true
+	m.Messages = append(m.Messages, fmt.Sprintf(format, args...))
+}
+
 // Contains checks if a string contains a substring (case-insensitive)
 func Contains(content, substr string) bool {
 	return len(content) > 0 && len(substr) > 0 &&
@@ -324,3 +770,148 @@ func findSubstring(content, substr string) bool {
 	}
 	return false
 }
+
+// AssertGoldenFile compares actual against the contents of goldenPath, failing the test with a
+// unified line diff on mismatch. Run the test binary with -update (e.g. `go test ./... -update`)
+// to rewrite goldenPath with actual instead of comparing -- the standard way to accept a change to
+// rendered instructions, transformed markdown, or a generated PR body.
+func AssertGoldenFile(t TestingT, actual []byte, goldenPath string) {
+	t.Helper()
+
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+			t.Fatalf("Failed to create golden file directory for %s: %v", goldenPath, err)
+		}
+		if err := os.WriteFile(goldenPath, actual, 0644); err != nil {
+			t.Fatalf("Failed to write golden file %s: %v", goldenPath, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("Failed to read golden file %s (run with -update to create it): %v", goldenPath, err)
+	}
+
+	if diff := diffLines(want, actual); diff != "" {
+		t.Errorf("%s differs from actual output (-want +got):\n%s", goldenPath, diff)
+	}
+}
+
+// AssertGoldenTree compares every file under dirPath against its counterpart under goldenDir,
+// failing the test with one AssertGoldenFile-style diff per mismatched file. Run with -update to
+// regenerate goldenDir from dirPath instead.
+func AssertGoldenTree(t TestingT, dirPath, goldenDir string) {
+	t.Helper()
+
+	if *updateGolden {
+		if err := os.RemoveAll(goldenDir); err != nil {
+			t.Fatalf("Failed to clear golden directory %s: %v", goldenDir, err)
+		}
+		if err := copyTree(dirPath, goldenDir); err != nil {
+			t.Fatalf("Failed to update golden directory %s: %v", goldenDir, err)
+		}
+		return
+	}
+
+	actualFiles := listFiles(t, dirPath)
+	goldenFiles := listFiles(t, goldenDir)
+
+	for _, name := range goldenFiles {
+		if !containsPath(actualFiles, name) {
+			t.Errorf("Expected %s to contain %s, present in golden directory %s", dirPath, name, goldenDir)
+		}
+	}
+
+	for _, name := range actualFiles {
+		if !containsPath(goldenFiles, name) {
+			t.Errorf("Unexpected file %s in %s, not present in golden directory %s", name, dirPath, goldenDir)
+			continue
+		}
+
+		actual, err := os.ReadFile(filepath.Join(dirPath, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", filepath.Join(dirPath, name), err)
+		}
+		want, err := os.ReadFile(filepath.Join(goldenDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read golden file %s: %v", filepath.Join(goldenDir, name), err)
+		}
+
+		if diff := diffLines(want, actual); diff != "" {
+			t.Errorf("%s differs from its golden copy (-want +got):\n%s", name, diff)
+		}
+	}
+}
+
+// diffLines returns a unified line-level diff between want and got via cmp.Diff, or "" if they're
+// identical.
+func diffLines(want, got []byte) string {
+	return cmp.Diff(strings.Split(string(want), "\n"), strings.Split(string(got), "\n"))
+}
+
+// listFiles returns every regular file under dir, as slash-separated paths relative to dir.
+func listFiles(t TestingT, dir string) []string {
+	t.Helper()
+
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to walk %s: %v", dir, err)
+	}
+
+	sort.Strings(files)
+	return files
+}
+
+// containsPath reports whether paths contains path.
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// copyTree recursively copies the regular files and directories under src into dst, preserving
+// file modes.
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, content, info.Mode())
+	})
+}
@@ -1,6 +1,10 @@
 package testutil
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"os"
 	"path/filepath"
 	"testing"
@@ -264,3 +268,355 @@ func TestAssertHelpers(t *testing.T) {
 	// Test ContainsString
 	ContainsString(t, content, "test", "file content")
 }
+
+func TestAssertFileExistsFailsForMissingFile(t *testing.T) {
+	mockT := NewMockT(t)
+	AssertFileExists(mockT, filepath.Join(t.TempDir(), "missing.txt"))
+
+	if !mockT.HasFailed {
+		t.Error("Expected AssertFileExists to fail for a missing file")
+	}
+}
+
+func TestAssertDirExistsFailsForMissingDir(t *testing.T) {
+	mockT := NewMockT(t)
+	AssertDirExists(mockT, filepath.Join(t.TempDir(), "missing-dir"))
+
+	if !mockT.HasFailed {
+		t.Error("Expected AssertDirExists to fail for a missing directory")
+	}
+}
+
+func TestAssertDirExistsFailsForFileNotDirectory(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "not-a-dir.txt")
+	if err := os.WriteFile(file, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	mockT := NewMockT(t)
+	AssertDirExists(mockT, file)
+
+	if !mockT.HasFailed {
+		t.Error("Expected AssertDirExists to fail when the path is a file, not a directory")
+	}
+}
+
+func TestContainsStringFailsForNonMatchingSubstring(t *testing.T) {
+	mockT := NewMockT(t)
+	ContainsString(mockT, "actual content", "missing substring", "test context")
+
+	if !mockT.HasFailed {
+		t.Error("Expected ContainsString to fail for a non-matching substring")
+	}
+	if len(mockT.Messages) == 0 {
+		t.Error("Expected ContainsString to record a failure message")
+	}
+}
+
+func TestContainsStringPassesForMatchingSubstring(t *testing.T) {
+	mockT := NewMockT(t)
+	ContainsString(mockT, "actual content", "actual", "test context")
+
+	if mockT.HasFailed {
+		t.Errorf("Expected ContainsString to pass, got failures: %v", mockT.Messages)
+	}
+}
+
+func TestCreateFromSpecBuildsNestedTree(t *testing.T) {
+	ws := NewTempWorkspace(t)
+
+	ws.CreateFromSpec(map[string]interface{}{
+		"cs101": map[string]interface{}{
+			"hw-1": map[string]interface{}{
+				"instructions.md": "# Homework 1",
+				"static": map[string]interface{}{
+					"overview.png": []byte("fake-png-bytes"),
+				},
+				"run.sh": File{Mode: 0755, Content: "#!/bin/sh\necho hi\n"},
+			},
+		},
+	})
+
+	AssertFileExists(t, filepath.Join(ws.RootDir, "cs101", "hw-1", "instructions.md"))
+	AssertFileExists(t, filepath.Join(ws.RootDir, "cs101", "hw-1", "static", "overview.png"))
+
+	content := ReadFileContent(t, filepath.Join(ws.RootDir, "cs101", "hw-1", "instructions.md"))
+	ContainsString(t, content, "# Homework 1", "instructions content")
+
+	scriptPath := filepath.Join(ws.RootDir, "cs101", "hw-1", "run.sh")
+	info, err := os.Stat(scriptPath)
+	if err != nil {
+		t.Fatalf("Failed to stat %s: %v", scriptPath, err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("Expected run.sh to be mode 0755, got %v", info.Mode().Perm())
+	}
+}
+
+func TestCreateFromSpecSupportsSymlinks(t *testing.T) {
+	ws := NewTempWorkspace(t)
+
+	ws.CreateFromSpec(map[string]interface{}{
+		"target.txt": "original",
+		"link.txt":   File{Symlink: "target.txt"},
+	})
+
+	linkPath := filepath.Join(ws.RootDir, "link.txt")
+	target, err := os.Readlink(linkPath)
+	if err != nil {
+		t.Fatalf("Expected %s to be a symlink: %v", linkPath, err)
+	}
+	if target != "target.txt" {
+		t.Errorf("Expected symlink target %q, got %q", "target.txt", target)
+	}
+}
+
+func TestCreateFromFileLoadsYAMLSpec(t *testing.T) {
+	ws := NewTempWorkspace(t)
+
+	specPath := filepath.Join(ws.t.TempDir(), "spec.yaml")
+	yamlSpec := "cs101:\n  hw-1:\n    instructions.md: \"# Homework 1\"\n"
+	if err := os.WriteFile(specPath, []byte(yamlSpec), 0644); err != nil {
+		t.Fatalf("Failed to write spec file: %v", err)
+	}
+
+	ws.CreateFromFile(specPath)
+
+	AssertFileExists(t, filepath.Join(ws.RootDir, "cs101", "hw-1", "instructions.md"))
+}
+
+func TestWithTestEnvironmentChangesDirectoryAndRestoresOnCleanup(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+
+	env, cleanup := WithTestEnvironment(t)
+	defer cleanup()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	resolvedWorkspace, err := filepath.EvalSymlinks(env.Workspace.RootDir)
+	if err != nil {
+		t.Fatalf("Failed to resolve workspace symlinks: %v", err)
+	}
+	resolvedCwd, err := filepath.EvalSymlinks(cwd)
+	if err != nil {
+		t.Fatalf("Failed to resolve cwd symlinks: %v", err)
+	}
+	if resolvedCwd != resolvedWorkspace {
+		t.Errorf("Expected cwd %s to be the workspace root %s", resolvedCwd, resolvedWorkspace)
+	}
+
+	cleanup()
+
+	cwd, err = os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	if cwd != originalDir {
+		t.Errorf("Expected cleanup to restore cwd to %s, got %s", originalDir, cwd)
+	}
+}
+
+func TestWithTestEnvironmentRunsOnCleanupHooksAndEnvRestore(t *testing.T) {
+	env, cleanup := WithTestEnvironment(t)
+	defer cleanup()
+
+	env.Env.Set("TESTUTIL_SAMPLE_VAR", "from-test")
+
+	var hookRan bool
+	env.OnCleanup(func() {
+		hookRan = true
+	})
+
+	cleanup()
+
+	if !hookRan {
+		t.Error("Expected OnCleanup hook to run during cleanup")
+	}
+	if os.Getenv("TESTUTIL_SAMPLE_VAR") != "" {
+		t.Errorf("Expected TESTUTIL_SAMPLE_VAR to be restored, got %q", os.Getenv("TESTUTIL_SAMPLE_VAR"))
+	}
+}
+
+func TestSetupTarTestFixtureExtractsGzippedArchive(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "fixture.tar.gz")
+	writeTestTarGz(t, archivePath, map[string]string{
+		"cs101/hw-1/instructions.md":   "# Homework 1",
+		"cs101/hw-1/static/diagram.png": "fake-png-bytes",
+	})
+
+	ws := NewTempWorkspace(t)
+	ws.SetupTarTestFixture(archivePath)
+
+	AssertFileExists(t, filepath.Join(ws.RootDir, "cs101", "hw-1", "instructions.md"))
+	content := ReadFileContent(t, filepath.Join(ws.RootDir, "cs101", "hw-1", "instructions.md"))
+	ContainsString(t, content, "# Homework 1", "instructions content")
+
+	if len(ws.ExtractedPaths) == 0 {
+		t.Error("Expected SetupTarTestFixture to record extracted paths")
+	}
+}
+
+func TestSetupZipFixtureExtractsArchive(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "fixture.zip")
+	writeTestZip(t, archivePath, map[string]string{
+		"cs101/hw-1/instructions.md": "# Homework 1",
+	})
+
+	ws := NewTempWorkspace(t)
+	ws.SetupZipFixture(archivePath)
+
+	AssertFileExists(t, filepath.Join(ws.RootDir, "cs101", "hw-1", "instructions.md"))
+	content := ReadFileContent(t, filepath.Join(ws.RootDir, "cs101", "hw-1", "instructions.md"))
+	ContainsString(t, content, "# Homework 1", "instructions content")
+
+	if len(ws.ExtractedPaths) == 0 {
+		t.Error("Expected SetupZipFixture to record extracted paths")
+	}
+}
+
+// writeTestTarGz writes a gzip-compressed tar archive containing files at archivePath.
+func writeTestTarGz(t *testing.T, archivePath string, files map[string]string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	for name, content := range files {
+		header := &tar.Header{
+			Name: name,
+			Mode: 0644,
+			Size: int64(len(content)),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("Failed to write tar header for %s: %v", name, err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write tar content for %s: %v", name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Failed to close tar writer: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("Failed to close gzip writer: %v", err)
+	}
+
+	if err := os.WriteFile(archivePath, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write tar fixture %s: %v", archivePath, err)
+	}
+}
+
+// writeTestZip writes a zip archive containing files at archivePath.
+func writeTestZip(t *testing.T, archivePath string, files map[string]string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create zip entry for %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write zip content for %s: %v", name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to close zip writer: %v", err)
+	}
+
+	if err := os.WriteFile(archivePath, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write zip fixture %s: %v", archivePath, err)
+	}
+}
+
+func TestAssertGoldenFilePassesForMatchingContent(t *testing.T) {
+	goldenPath := filepath.Join(t.TempDir(), "golden.md")
+	if err := os.WriteFile(goldenPath, []byte("# Rendered\nLine two\n"), 0644); err != nil {
+		t.Fatalf("Failed to write golden fixture: %v", err)
+	}
+
+	mockT := NewMockT(t)
+	AssertGoldenFile(mockT, []byte("# Rendered\nLine two\n"), goldenPath)
+
+	if mockT.HasFailed {
+		t.Errorf("Expected AssertGoldenFile to pass, got failures: %v", mockT.Messages)
+	}
+}
+
+func TestAssertGoldenFileFailsForMismatchedContent(t *testing.T) {
+	goldenPath := filepath.Join(t.TempDir(), "golden.md")
+	if err := os.WriteFile(goldenPath, []byte("# Rendered\nLine two\n"), 0644); err != nil {
+		t.Fatalf("Failed to write golden fixture: %v", err)
+	}
+
+	mockT := NewMockT(t)
+	AssertGoldenFile(mockT, []byte("# Rendered\nSomething else\n"), goldenPath)
+
+	if !mockT.HasFailed {
+		t.Error("Expected AssertGoldenFile to fail for mismatched content")
+	}
+}
+
+func TestAssertGoldenTreePassesForMatchingTree(t *testing.T) {
+	goldenDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(goldenDir, "instructions.md"), []byte("# Homework 1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write golden fixture: %v", err)
+	}
+
+	ws := NewTempWorkspace(t)
+	ws.CreateFromSpec(map[string]interface{}{
+		"instructions.md": "# Homework 1\n",
+	})
+
+	mockT := NewMockT(t)
+	AssertGoldenTree(mockT, ws.RootDir, goldenDir)
+
+	if mockT.HasFailed {
+		t.Errorf("Expected AssertGoldenTree to pass, got failures: %v", mockT.Messages)
+	}
+}
+
+func TestAssertGoldenTreeFailsForExtraFile(t *testing.T) {
+	goldenDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(goldenDir, "instructions.md"), []byte("# Homework 1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write golden fixture: %v", err)
+	}
+
+	ws := NewTempWorkspace(t)
+	ws.CreateFromSpec(map[string]interface{}{
+		"instructions.md": "# Homework 1\n",
+		"extra.md":        "unexpected\n",
+	})
+
+	mockT := NewMockT(t)
+	AssertGoldenTree(mockT, ws.RootDir, goldenDir)
+
+	if !mockT.HasFailed {
+		t.Error("Expected AssertGoldenTree to fail for an unexpected extra file")
+	}
+}
+
+func TestAssertTreeMatchesPassesForMatchingTree(t *testing.T) {
+	ws := NewTempWorkspace(t)
+
+	spec := map[string]interface{}{
+		"cs101": map[string]interface{}{
+			"hw-1": map[string]interface{}{
+				"instructions.md": "# Homework 1",
+			},
+		},
+	}
+
+	ws.CreateFromSpec(spec)
+	ws.AssertTreeMatches(".", spec)
+}
@@ -0,0 +1,88 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"assignment-pull-request/internal/constants"
+	"assignment-pull-request/internal/github"
+)
+
+// remoteWorkflowUsesPattern matches a reusable workflow reference of the form
+// "owner/repo/path/to/workflow.yml@ref", as opposed to a regular marketplace action
+// reference such as "actions/checkout@v4" or "owner/repo/some-action@v1"
+var remoteWorkflowUsesPattern = regexp.MustCompile(`^([^/]+)/([^/]+)/(.+\.ya?ml)@(.+)$`)
+
+// followReusableWorkflow checks whether uses points at another workflow file rather than an
+// action, and if so recursively parses it, merging any assignment-regex and branch-filter
+// inputs it finds. Local references (starting with "./") resolve against the current working
+// directory (the repository root, per ParseAllFiles/SparseCheckout); remote references
+// ("owner/repo/path@ref") are fetched via the GitHub API. Parse failures are treated as
+// warnings, never aborting discovery.
+func (p *Processor) followReusableWorkflow(uses string) {
+	if uses == "" {
+		return
+	}
+
+	if localPath, ok := strings.CutPrefix(uses, "./"); ok {
+		if !isWorkflowFilePath(localPath) {
+			return
+		}
+		if p.visitedWorkflows[localPath] {
+			return
+		}
+		p.visitedWorkflows[localPath] = true
+
+		if err := p.parseFile(localPath); err != nil {
+			fmt.Printf("Warning: could not follow reusable workflow '%s': %v\n", uses, err)
+		}
+		return
+	}
+
+	match := remoteWorkflowUsesPattern.FindStringSubmatch(uses)
+	if match == nil {
+		return
+	}
+	owner, repo, path, ref := match[1], match[2], match[3], match[4]
+
+	cacheKey := fmt.Sprintf("%s/%s/%s@%s", owner, repo, path, ref)
+	if p.visitedWorkflows[cacheKey] {
+		return
+	}
+	p.visitedWorkflows[cacheKey] = true
+
+	content, err := p.fetchRemoteWorkflow(owner, repo, path, ref, cacheKey)
+	if err != nil {
+		fmt.Printf("Warning: could not follow reusable workflow '%s': %v\n", uses, err)
+		return
+	}
+
+	if err := p.parseContent([]byte(content)); err != nil {
+		fmt.Printf("Warning: could not parse reusable workflow '%s': %v\n", uses, err)
+	}
+}
+
+// fetchRemoteWorkflow retrieves a reusable workflow file's content via the GitHub API,
+// caching results by "owner/repo/path@ref" so the same reference is never fetched twice
+func (p *Processor) fetchRemoteWorkflow(owner, repo, path, ref, cacheKey string) (string, error) {
+	if content, ok := p.remoteWorkflowCache[cacheKey]; ok {
+		return content, nil
+	}
+
+	client := github.NewClient(os.Getenv(constants.EnvGitHubToken), fmt.Sprintf("%s/%s", owner, repo), false)
+	content, err := client.GetFileContent(owner, repo, path, ref)
+	if err != nil {
+		return "", err
+	}
+
+	p.remoteWorkflowCache[cacheKey] = content
+	return content, nil
+}
+
+// isWorkflowFilePath reports whether a local `uses:` path looks like a reusable workflow file
+// (ends in .yml/.yaml) as opposed to a composite or Docker action directory
+func isWorkflowFilePath(path string) bool {
+	return strings.HasSuffix(path, constants.YamlExtension) || strings.HasSuffix(path, constants.YamlAltExtension)
+}
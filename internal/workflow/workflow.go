@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"assignment-pull-request/internal/branchfilter"
 	"assignment-pull-request/internal/constants"
 	"assignment-pull-request/internal/regex"
 
@@ -35,12 +36,27 @@ type Step struct {
 // Processor handles workflow file parsing and pattern extraction
 type Processor struct {
 	assignmentPattern *regex.Processor
+
+	branchInclude []string
+	branchExclude []string
+	tagInclude    []string
+	tagExclude    []string
+
+	// visitedWorkflows guards against cycles when following reusable workflow `uses:`
+	// references, keyed by resolved local path or "owner/repo/path@ref"
+	visitedWorkflows map[string]bool
+
+	// remoteWorkflowCache caches fetched reusable workflow file content by
+	// "owner/repo/path@ref" so the same reference is never fetched twice
+	remoteWorkflowCache map[string]string
 }
 
 // New creates a new workflow processor
 func New() *Processor {
 	return &Processor{
-		assignmentPattern: regex.New(),
+		assignmentPattern:   regex.New(),
+		visitedWorkflows:    make(map[string]bool),
+		remoteWorkflowCache: make(map[string]string),
 	}
 }
 
@@ -49,6 +65,18 @@ func (p *Processor) AssignmentPattern() *regex.Processor {
 	return p.assignmentPattern
 }
 
+// BranchFilter builds a branchfilter.Filter from the assignment-branches/assignment-branches-ignore
+// patterns collected across the parsed workflow files
+func (p *Processor) BranchFilter() (*branchfilter.Filter, error) {
+	return branchfilter.New(p.branchInclude, p.branchExclude)
+}
+
+// TagFilter builds a branchfilter.Filter from the assignment-tags/assignment-tags-ignore
+// patterns collected across the parsed workflow files
+func (p *Processor) TagFilter() (*branchfilter.Filter, error) {
+	return branchfilter.New(p.tagInclude, p.tagExclude)
+}
+
 // ParseAllFiles finds and parses all workflow files
 func (p *Processor) ParseAllFiles() error {
 	workflowFiles, err := p.findFiles()
@@ -137,59 +165,86 @@ func (p *Processor) isAssignmentAction(uses string) bool {
 
 // parseFile parses a single workflow file and extracts patterns
 func (p *Processor) parseFile(filePath string) error {
-
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("error reading workflow file %s: %w", filePath, err)
 	}
 
+	if err := p.parseContent(data); err != nil {
+		return fmt.Errorf("error parsing workflow file %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// parseContent parses already-loaded workflow YAML content and extracts patterns, following
+// any reusable workflow references it finds along the way
+func (p *Processor) parseContent(data []byte) error {
 	var config Action
 	if err := yaml.Unmarshal(data, &config); err != nil {
-		return fmt.Errorf("error parsing workflow file %s: %w", filePath, err)
+		return err
 	}
 
 	// Look for jobs that use the assignment action
 	for _, job := range config.Jobs {
 		// Case 1: Reusable workflow at job level
 		if p.isAssignmentAction(job.Uses) {
-			if with := job.With; with != nil {
-				// Extract assignment patterns
-				if assignmentPatterns, ok := with[constants.WorkflowAssignmentRegexKey]; ok {
-					if assignmentStr, ok := assignmentPatterns.(string); ok {
-						p.assignmentPattern.AddCommaSeparated(assignmentStr)
-					}
-				}
-			}
+			p.extractAssignmentInputs(job.With)
+		} else {
+			// job.Uses may instead point at another reusable workflow file; follow it so
+			// an assignment-regex configured there isn't invisible to discovery
+			p.followReusableWorkflow(job.Uses)
 		}
 
 		// Case 2: Steps within job
 		for _, step := range job.Steps {
 			if p.isAssignmentAction(step.Uses) {
-				if with := step.With; with != nil {
-					// Extract assignment patterns
-					if assignmentPatterns, ok := with[constants.WorkflowAssignmentRegexKey]; ok {
-						if assignmentStr, ok := assignmentPatterns.(string); ok {
-							p.assignmentPattern.AddCommaSeparated(assignmentStr)
-						}
-					}
-				}
+				p.extractAssignmentInputs(step.With)
 			}
 		}
 
 		// Case 2: Steps within job
 		for _, step := range job.Steps {
 			if p.isAssignmentAction(step.Uses) {
-				if with := step.With; with != nil {
-					// Extract assignment patterns
-					if assignmentPatterns, ok := with[constants.WorkflowAssignmentRegexKey]; ok {
-						if assignmentStr, ok := assignmentPatterns.(string); ok {
-							p.assignmentPattern.AddCommaSeparated(assignmentStr)
-						}
-					}
-				}
+				p.extractAssignmentInputs(step.With)
 			}
 		}
 	}
 
 	return nil
 }
+
+// extractAssignmentInputs pulls the assignment-regex pattern list plus the branch/tag
+// include-exclude filter pattern lists out of a job or step's `with:` inputs
+func (p *Processor) extractAssignmentInputs(with map[string]interface{}) {
+	if with == nil {
+		return
+	}
+
+	if assignmentPatterns, ok := with[constants.WorkflowAssignmentRegexKey]; ok {
+		if assignmentStr, ok := assignmentPatterns.(string); ok {
+			p.assignmentPattern.AddCommaSeparated(assignmentStr)
+		}
+	}
+
+	p.branchInclude = append(p.branchInclude, parseCommaSeparatedInput(with, constants.WorkflowAssignmentBranchesKey)...)
+	p.branchExclude = append(p.branchExclude, parseCommaSeparatedInput(with, constants.WorkflowAssignmentBranchesIgnoreKey)...)
+	p.tagInclude = append(p.tagInclude, parseCommaSeparatedInput(with, constants.WorkflowAssignmentTagsKey)...)
+	p.tagExclude = append(p.tagExclude, parseCommaSeparatedInput(with, constants.WorkflowAssignmentTagsIgnoreKey)...)
+}
+
+// parseCommaSeparatedInput reads a string `with:` input by key and splits it on commas,
+// mirroring how assignment-regex is parsed
+func parseCommaSeparatedInput(with map[string]interface{}, key string) []string {
+	value, ok := with[key]
+	if !ok {
+		return nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return nil
+	}
+
+	return regex.ParseCommaSeparated(str)
+}